@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"go-gin-test-job/src/config"
 	"go-gin-test-job/src/database"
 	"go-gin-test-job/src/logger"
+	cronModule "go-gin-test-job/src/modules/cron"
 	"go-gin-test-job/src/routes"
 )
 
@@ -29,6 +31,7 @@ func main() {
 	if err := database.Connect(); err != nil {
 		logger.Logger.Fatal().Msg("Connect to database error. Error - " + err.Error())
 	}
+	cronModule.StartElectrumSubscriptions(context.Background())
 	app, listenAddress := routes.New()
 	if err := app.Run(listenAddress); err != nil {
 		logger.Logger.Fatal().Msg("Startup error. Error - " + err.Error())
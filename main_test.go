@@ -5,7 +5,12 @@ import (
 	"go-gin-test-job/test"
 	testDatabase "go-gin-test-job/test/database"
 	accountTests "go-gin-test-job/test/tests/account"
+	auditTests "go-gin-test-job/test/tests/audit"
+	blockchainTests "go-gin-test-job/test/tests/blockchain"
 	cronTests "go-gin-test-job/test/tests/cron"
+	electrumTests "go-gin-test-job/test/tests/electrum"
+	portfolioTests "go-gin-test-job/test/tests/portfolio"
+	tagTests "go-gin-test-job/test/tests/tag"
 	"testing"
 )
 
@@ -21,4 +26,9 @@ func TestMain(m *testing.M) {
 func TestAllRoutes(t *testing.T) {
 	t.Run("TestAccountRoute", accountTests.TestAccountRoute)
 	t.Run("TestCronRoute", cronTests.TestCronRoute)
+	t.Run("TestTagRoute", tagTests.TestTagRoute)
+	t.Run("TestPortfolioRoute", portfolioTests.TestPortfolioRoute)
+	t.Run("TestAuditRoute", auditTests.TestAuditRoute)
+	t.Run("TestBlockchainRoute", blockchainTests.TestBlockchainRoute)
+	t.Run("TestElectrumRoute", electrumTests.TestElectrumRoute)
 }
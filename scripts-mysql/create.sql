@@ -1,17 +1,140 @@
+DROP TABLE IF EXISTS idempotency_key;
+DROP TABLE IF EXISTS `transaction`;
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS account_balance_history;
+DROP TABLE IF EXISTS portfolio_account;
+DROP TABLE IF EXISTS portfolio;
+DROP TABLE IF EXISTS account_tag;
+DROP TABLE IF EXISTS tag;
 DROP TABLE IF EXISTS account;
 CREATE TABLE account (
     id BIGINT NOT NULL AUTO_INCREMENT,
     name VARCHAR(255) NOT NULL,
     `rank` TINYINT NOT NULL,
     memo TEXT,
+    metadata JSON NULL,
+    chain ENUM('BTC', 'LTC', 'BCH', 'DOGE') NOT NULL DEFAULT 'BTC',
+    network ENUM('mainnet', 'testnet', 'signet', 'regtest') NOT NULL DEFAULT 'mainnet',
     address VARCHAR(64) NOT NULL,
+    address_type ENUM('p2pkh', 'p2sh', 'p2wpkh', 'p2wsh', 'p2tr') NOT NULL,
     balance DECIMAL(64, 8) NOT NULL DEFAULT 0,
-    status ENUM('On', 'Off') NOT NULL,
+    unconfirmed_balance DECIMAL(64, 8) NOT NULL DEFAULT 0,
+    total_balance DECIMAL(64, 8) NOT NULL DEFAULT 0,
+    status ENUM('Pending', 'Active', 'Suspended', 'Archived', 'Error') NOT NULL,
+    status_reason VARCHAR(255) NOT NULL DEFAULT '',
+    error_count INT UNSIGNED NOT NULL DEFAULT 0,
+    balance_checked_at INT NULL,
+    version BIGINT UNSIGNED NOT NULL DEFAULT 1,
     created_at INT NOT NULL,
     updated_at INT NOT NULL,
+    deleted_at INT NULL,
     PRIMARY KEY (id),
-    UNIQUE INDEX account_address_unique_idx (address),
+    UNIQUE INDEX account_chain_network_address_unique_idx (chain, network, address),
     INDEX account_status_idx (status),
+    INDEX account_address_type_idx (address_type),
     INDEX account_updated_idx (updated_at),
+    INDEX account_balance_checked_at_idx (balance_checked_at),
+    INDEX account_deleted_at_idx (deleted_at),
+    FULLTEXT INDEX account_name_memo_fulltext_idx (name, memo),
     CONSTRAINT rank_check CHECK (`rank` <= 100)
 );
+
+CREATE TABLE tag (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(64) NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX tag_name_unique_idx (name)
+);
+
+CREATE TABLE account_tag (
+    account_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+    created_at INT NOT NULL,
+    PRIMARY KEY (account_id, tag_id),
+    INDEX account_tag_tag_idx (tag_id),
+    CONSTRAINT account_tag_account_fk FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE,
+    CONSTRAINT account_tag_tag_fk FOREIGN KEY (tag_id) REFERENCES tag (id) ON DELETE CASCADE
+);
+
+CREATE TABLE portfolio (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX portfolio_name_unique_idx (name)
+);
+
+CREATE TABLE portfolio_account (
+    portfolio_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    created_at INT NOT NULL,
+    PRIMARY KEY (portfolio_id, account_id),
+    INDEX portfolio_account_account_idx (account_id),
+    CONSTRAINT portfolio_account_portfolio_fk FOREIGN KEY (portfolio_id) REFERENCES portfolio (id) ON DELETE CASCADE,
+    CONSTRAINT portfolio_account_account_fk FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+);
+
+CREATE TABLE account_balance_history (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    account_id BIGINT NOT NULL,
+    old_balance DECIMAL(64, 8) NOT NULL,
+    new_balance DECIMAL(64, 8) NOT NULL,
+    delta DECIMAL(64, 8) NOT NULL,
+    source VARCHAR(64) NOT NULL,
+    created_at INT NOT NULL,
+    PRIMARY KEY (id),
+    INDEX account_balance_history_account_created_idx (account_id, created_at, id),
+    INDEX account_balance_history_created_idx (created_at),
+    CONSTRAINT account_balance_history_account_fk FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+);
+
+CREATE TABLE `transaction` (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    account_id BIGINT NOT NULL,
+    txid CHAR(64) NOT NULL,
+    block_height INT NULL,
+    `time` INT NOT NULL,
+    amount DECIMAL(64, 8) NOT NULL,
+    fee DECIMAL(64, 8) NOT NULL DEFAULT 0,
+    confirmations INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX transaction_account_txid_unique_idx (account_id, txid),
+    INDEX transaction_account_time_idx (account_id, `time`, id),
+    CONSTRAINT transaction_account_fk FOREIGN KEY (account_id) REFERENCES account (id) ON DELETE CASCADE
+);
+
+-- audit_log has no foreign key, the entries outlive purged accounts
+CREATE TABLE audit_log (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    account_id BIGINT NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    actor VARCHAR(64) NOT NULL,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    `before` JSON NULL,
+    `after` JSON NULL,
+    created_at INT NOT NULL,
+    PRIMARY KEY (id),
+    INDEX audit_log_account_idx (account_id, id),
+    INDEX audit_log_actor_idx (actor, id),
+    INDEX audit_log_action_idx (action, id),
+    INDEX audit_log_created_idx (created_at)
+);
+
+CREATE TABLE idempotency_key (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    request_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    response_status SMALLINT NOT NULL DEFAULT 0,
+    response_content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body MEDIUMBLOB NOT NULL,
+    created_at INT NOT NULL,
+    expires_at INT NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idempotency_key_request_key_unique_idx (request_key),
+    INDEX idempotency_key_expires_at_idx (expires_at)
+);
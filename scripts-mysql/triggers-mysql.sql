@@ -1,7 +1,14 @@
-CREATE TRIGGER account_BEFORE_UPDATE BEFORE UPDATE ON account FOR EACH ROW SET new.updated_at = UNIX_TIMESTAMP(NOW());
-
 DELIMITER $$
 
+-- Every account write moves the version, the balance check time alone is not a change of the account
+CREATE TRIGGER account_BEFORE_UPDATE
+  BEFORE UPDATE ON account FOR EACH ROW
+BEGIN
+  IF new.version <> old.version THEN
+    SET new.updated_at = UNIX_TIMESTAMP(NOW());
+  END IF;
+END$$
+
 CREATE TRIGGER account_BEFORE_INSERT
   BEFORE INSERT ON account FOR EACH ROW
 BEGIN
@@ -10,3 +17,41 @@ BEGIN
 END$$
 
 DELIMITER ;
+
+CREATE TRIGGER tag_BEFORE_UPDATE BEFORE UPDATE ON tag FOR EACH ROW SET new.updated_at = UNIX_TIMESTAMP(NOW());
+
+DELIMITER $$
+
+CREATE TRIGGER tag_BEFORE_INSERT
+  BEFORE INSERT ON tag FOR EACH ROW
+BEGIN
+  SET new.created_at = UNIX_TIMESTAMP(NOW()),
+    new.updated_at = UNIX_TIMESTAMP(NOW());
+END$$
+
+DELIMITER ;
+
+CREATE TRIGGER account_tag_BEFORE_INSERT BEFORE INSERT ON account_tag FOR EACH ROW SET new.created_at = UNIX_TIMESTAMP(NOW());
+
+CREATE TRIGGER portfolio_BEFORE_UPDATE BEFORE UPDATE ON portfolio FOR EACH ROW SET new.updated_at = UNIX_TIMESTAMP(NOW());
+
+DELIMITER $$
+
+CREATE TRIGGER portfolio_BEFORE_INSERT
+  BEFORE INSERT ON portfolio FOR EACH ROW
+BEGIN
+  SET new.created_at = UNIX_TIMESTAMP(NOW()),
+    new.updated_at = UNIX_TIMESTAMP(NOW());
+END$$
+
+DELIMITER ;
+
+CREATE TRIGGER portfolio_account_BEFORE_INSERT BEFORE INSERT ON portfolio_account FOR EACH ROW SET new.created_at = UNIX_TIMESTAMP(NOW());
+
+CREATE TRIGGER audit_log_BEFORE_INSERT BEFORE INSERT ON audit_log FOR EACH ROW SET new.created_at = UNIX_TIMESTAMP(NOW());
+
+CREATE TRIGGER audit_log_BEFORE_UPDATE BEFORE UPDATE ON audit_log FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is immutable';
+
+CREATE TRIGGER audit_log_BEFORE_DELETE BEFORE DELETE ON audit_log FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is immutable';
+
+CREATE TRIGGER idempotency_key_BEFORE_INSERT BEFORE INSERT ON idempotency_key FOR EACH ROW SET new.created_at = UNIX_TIMESTAMP(NOW());
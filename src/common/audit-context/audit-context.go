@@ -0,0 +1,30 @@
+package auditContext
+
+import (
+	"go-gin-test-job/src/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	actorContextKey     = "auditActor"
+	requestIdContextKey = "auditRequestId"
+)
+
+// SetActor stores the identity of the api key, it is set by the api key guards
+func SetActor(c *gin.Context, actor string) {
+	c.Set(actorContextKey, actor)
+}
+
+// SetRequestId stores the X-Request-ID of the request, it is set by the request id middleware
+func SetRequestId(c *gin.Context, requestId string) {
+	c.Set(requestIdContextKey, requestId)
+}
+
+// Get returns the audit context of the request for the database write functions
+func Get(c *gin.Context) database.AuditContext {
+	return database.AuditContext{
+		Actor:     c.GetString(actorContextKey),
+		RequestId: c.GetString(requestIdContextKey),
+	}
+}
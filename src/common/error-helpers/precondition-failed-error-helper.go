@@ -0,0 +1,25 @@
+package errorHelpers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+type ResponsePreconditionFailedErrorHTTP struct {
+	Success bool   `json:"success" validate:"required" example:"false"`
+	Message string `json:"message" validate:"required" example:"Precondition failed error"`
+}
+
+func NewResponsePreconditionFailedErrorHTTP(message string) *ResponsePreconditionFailedErrorHTTP {
+	return &ResponsePreconditionFailedErrorHTTP{
+		Success: false,
+		Message: message,
+	}
+}
+
+func RespondPreconditionFailedError(c *gin.Context, message string) error {
+	if c != nil {
+		c.JSON(412, NewResponsePreconditionFailedErrorHTTP(message))
+	}
+	return fmt.Errorf("Precondition failed error. %s", message)
+}
@@ -0,0 +1,25 @@
+package errorHelpers
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+)
+
+type ResponseUnprocessableEntityErrorHTTP struct {
+	Success bool   `json:"success" validate:"required" example:"false"`
+	Message string `json:"message" validate:"required" example:"Unprocessable entity error"`
+}
+
+func NewResponseUnprocessableEntityErrorHTTP(message string) *ResponseUnprocessableEntityErrorHTTP {
+	return &ResponseUnprocessableEntityErrorHTTP{
+		Success: false,
+		Message: message,
+	}
+}
+
+func RespondUnprocessableEntityError(c *gin.Context, message string) error {
+	if c != nil {
+		c.JSON(422, NewResponseUnprocessableEntityErrorHTTP(message))
+	}
+	return fmt.Errorf("Unprocessable entity error. %s", message)
+}
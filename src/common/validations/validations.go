@@ -3,26 +3,69 @@ package validations
 import (
 	"go-gin-test-job/src/database/entities"
 	addressValidationUtil "go-gin-test-job/src/utils/address-validation"
+	metadataValidationUtil "go-gin-test-job/src/utils/metadata-validation"
 	nameValidationUtil "go-gin-test-job/src/utils/name-validation"
 	rankValidationUtil "go-gin-test-job/src/utils/rank-validation"
+	tagValidationUtil "go-gin-test-job/src/utils/tag-validation"
+	"slices"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
 )
 
 func AccountStatusValidation(fl validator.FieldLevel) bool {
 	status := fl.Field().String()
 	status = strings.Trim(status, "\"")
-	switch entities.AccountStatus(status) {
-	case entities.AccountStatusOn, entities.AccountStatusOff:
-		return true
+	return slices.Contains(entities.AccountStatusList, status)
+}
+
+// AccountInitialStatusValidation checks the status is one an account can be created with
+func AccountInitialStatusValidation(fl validator.FieldLevel) bool {
+	status := fl.Field().String()
+	status = strings.Trim(status, "\"")
+	return slices.Contains(entities.AccountInitialStatusList, status)
+}
+
+// AccountStatusListValidation checks a comma-separated list of statuses
+func AccountStatusListValidation(fl validator.FieldLevel) bool {
+	for _, status := range strings.Split(fl.Field().String(), ",") {
+		status = strings.Trim(strings.TrimSpace(status), "\"")
+		if !slices.Contains(entities.AccountStatusList, status) {
+			return false
+		}
 	}
-	return false
+	return true
+}
+
+// AccountMetadataValidation checks the metadata is a JSON object or null within the size and depth limits
+func AccountMetadataValidation(fl validator.FieldLevel) bool {
+	return metadataValidationUtil.IsValidMetadata(fl.Field().Bytes())
+}
+
+func AccountChainValidation(fl validator.FieldLevel) bool {
+	chain := fl.Field().String()
+	return slices.Contains(entities.AccountChainList, chain)
+}
+
+func AccountNetworkValidation(fl validator.FieldLevel) bool {
+	network := fl.Field().String()
+	return slices.Contains(entities.AccountNetworkList, network)
+}
+
+// AccountChainNetworkValidation checks the chain of the Chain field of the same struct has the network
+func AccountChainNetworkValidation(fl validator.FieldLevel) bool {
+	network := fl.Field().String()
+	chain := fl.Parent().FieldByName("Chain").String()
+	return addressValidationUtil.IsValidChainNetwork(addressValidationUtil.Chain(chain), addressValidationUtil.Network(network))
 }
 
+// AccountAddressValidation checks the address against the Chain and Network fields of the same struct
 func AccountAddressValidation(fl validator.FieldLevel) bool {
 	address := fl.Field().String()
-	return addressValidationUtil.IsValidAddress(address)
+	chain := fl.Parent().FieldByName("Chain").String()
+	network := fl.Parent().FieldByName("Network").String()
+	return addressValidationUtil.IsValidAddress(addressValidationUtil.Chain(chain), addressValidationUtil.Network(network), address)
 }
 
 func NotEmpty(fl validator.FieldLevel) bool {
@@ -39,3 +82,34 @@ func AccountNameValidation(fl validator.FieldLevel) bool {
 	name := fl.Field().String()
 	return nameValidationUtil.IsValidName(name)
 }
+
+// AccountBalanceBoundValidation checks a balance filter bound against the BalanceKind field of the same struct,
+// only the confirmed balance can not be negative
+func AccountBalanceBoundValidation(fl validator.FieldLevel) bool {
+	balance, err := decimal.NewFromString(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	balanceKind := entities.AccountBalanceKind(fl.Parent().FieldByName("BalanceKind").String())
+	return !balance.IsNegative() || (balanceKind != "" && balanceKind != entities.AccountBalanceKindConfirmed)
+}
+
+func TagNameValidation(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	return tagValidationUtil.IsValidTagName(name)
+}
+
+// TagNameListValidation checks a comma-separated list of tag names
+func TagNameListValidation(fl validator.FieldLevel) bool {
+	for _, name := range strings.Split(fl.Field().String(), ",") {
+		if !tagValidationUtil.IsValidTagName(strings.TrimSpace(name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func PortfolioNameValidation(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	return nameValidationUtil.IsValidName(name)
+}
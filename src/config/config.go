@@ -6,7 +6,9 @@ import (
 	"go-gin-test-job/src/logger"
 	typeUtil "go-gin-test-job/src/utils/type"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 type DbConnectionConfig struct {
@@ -40,8 +42,49 @@ type Config struct {
 	CronXApiKey       string
 	RequestTimeoutSec int
 	CronBatchCount    int
-	Database          DbConfig
-	TestDatabase      TestDbConfig
+	BulkAccountMax    int
+	// ImportReportRowMax is how many row results the import report lists, the counts cover all rows
+	ImportReportRowMax int
+	// BalanceHistoryRetentionDays is how long balance history is kept, 0 keeps it forever
+	BalanceHistoryRetentionDays int
+	// TransactionImportCount is how many of the latest address transactions are read with every balance update
+	TransactionImportCount int
+	// TransactionSettledConfirmations is the confirmations count after which a stored transaction is not read again
+	TransactionSettledConfirmations int
+	// IdempotencyKeyTtlSec is how long the response of a request with an Idempotency-Key is replayed
+	IdempotencyKeyTtlSec int
+	// IdempotencyKeyWaitSec is how long a retry waits for the request with the same Idempotency-Key in progress
+	IdempotencyKeyWaitSec int
+	// AccountErrorThreshold is how many balance updates in a row the provider rejects before the account moves to Error
+	AccountErrorThreshold int
+	// BlockchainProviders is the ordered list of the balance providers, the next one is asked when a provider fails
+	BlockchainProviders []string
+	// BlockchainUrls, EsploraUrls and BitcoindUrls are the provider URLs of each chain network keyed by GetBlockchainUrlKey,
+	// the accounts of a chain network without a URL of any of the providers are not polled
+	BlockchainUrls map[string]string
+	EsploraUrls    map[string]string
+	// BitcoindUrls are the JSON-RPC URLs, the credentials go into the URL user info
+	BitcoindUrls map[string]string
+	// ElectrumUrls are the Electrum servers, tcp://host:port or tls://host:port. The accounts of a chain network
+	// with an Electrum server get the balances pushed by the subscriptions instead of the cron polling
+	ElectrumUrls map[string]string
+	// ElectrumRefreshSec is how often the subscriptions pick up the new accounts
+	ElectrumRefreshSec int
+	Database           DbConfig
+	TestDatabase       TestDbConfig
+}
+
+// BlockchainProviderList is the available balance providers
+var BlockchainProviderList = []string{"bitcore", "esplora", "bitcoind"}
+
+var blockchainChainList = []string{"BTC", "LTC", "BCH", "DOGE"}
+var blockchainNetworkList = []string{"mainnet", "testnet", "signet", "regtest"}
+
+// esploraDefaultUrls are the public Esplora instances, other chains and networks have no default
+var esploraDefaultUrls = map[string]string{
+	GetBlockchainUrlKey("BTC", "mainnet"): "https://blockstream.info/api",
+	GetBlockchainUrlKey("BTC", "testnet"): "https://blockstream.info/testnet/api",
+	GetBlockchainUrlKey("BTC", "signet"):  "https://mempool.space/signet/api",
 }
 
 var AppConfig *Config
@@ -58,6 +101,37 @@ func LoadConfig() {
 	cronXApiKey := getEnvAsString("CRON_X_API_KEY", nil)
 	requestTimeoutSec := getEnvAsInt("REQUEST_TIMEOUT_SEC", typeUtil.Int(20))
 	cronBatchCount := getEnvAsInt("CRON_BATCH_COUNT", typeUtil.Int(5))
+	bulkAccountMax := getEnvAsInt("BULK_ACCOUNT_MAX", typeUtil.Int(500))
+	importReportRowMax := getEnvAsInt("IMPORT_REPORT_ROW_MAX", typeUtil.Int(1000))
+	balanceHistoryRetentionDays := getEnvAsInt("BALANCE_HISTORY_RETENTION_DAYS", typeUtil.Int(365))
+	transactionImportCount := getEnvAsInt("TRANSACTION_IMPORT_COUNT", typeUtil.Int(25))
+	transactionSettledConfirmations := getEnvAsInt("TRANSACTION_SETTLED_CONFIRMATIONS", typeUtil.Int(6))
+	idempotencyKeyTtlSec := getEnvAsInt("IDEMPOTENCY_KEY_TTL_SEC", typeUtil.Int(86400))
+	idempotencyKeyWaitSec := getEnvAsInt("IDEMPOTENCY_KEY_WAIT_SEC", typeUtil.Int(30))
+	accountErrorThreshold := getEnvAsInt("ACCOUNT_ERROR_THRESHOLD", typeUtil.Int(3))
+	blockchainProviders := getEnvAsList("BLOCKCHAIN_PROVIDERS", typeUtil.String("bitcore"))
+	for _, provider := range blockchainProviders {
+		if !slices.Contains(BlockchainProviderList, provider) {
+			logger.Logger.Fatal().Msg(fmt.Sprintf("Environment variable BLOCKCHAIN_PROVIDERS must be a list of %s, got %s", strings.Join(BlockchainProviderList, ","), provider))
+		}
+	}
+	// Bitcore serves the mainnet and testnet of every chain
+	blockchainUrls := getEnvAsChainNetworkUrls("BLOCKCHAIN", func(chain string, network string) string {
+		if network == "mainnet" || network == "testnet" {
+			return fmt.Sprintf("https://api.bitcore.io/api/%s/%s", chain, network)
+		}
+		return ""
+	})
+	esploraUrls := getEnvAsChainNetworkUrls("ESPLORA", func(chain string, network string) string {
+		return esploraDefaultUrls[GetBlockchainUrlKey(chain, network)]
+	})
+	bitcoindUrls := getEnvAsChainNetworkUrls("BITCOIND", func(chain string, network string) string {
+		return ""
+	})
+	electrumUrls := getEnvAsChainNetworkUrls("ELECTRUM", func(chain string, network string) string {
+		return ""
+	})
+	electrumRefreshSec := getEnvAsInt("ELECTRUM_REFRESH_SEC", typeUtil.Int(60))
 
 	dbHost := getEnvAsString("DB_HOST", typeUtil.String("localhost"))
 	dbPort := getEnvAsInt("DB_PORT", typeUtil.Int(3306))
@@ -79,14 +153,28 @@ func LoadConfig() {
 	}
 
 	AppConfig = &Config{
-		AppName:           appName,
-		AppHost:           appHost,
-		Port:              port,
-		IsDebug:           isDebug,
-		AdminXApiKey:      adminXApiKey,
-		CronXApiKey:       cronXApiKey,
-		RequestTimeoutSec: requestTimeoutSec,
-		CronBatchCount:    cronBatchCount,
+		AppName:                         appName,
+		AppHost:                         appHost,
+		Port:                            port,
+		IsDebug:                         isDebug,
+		AdminXApiKey:                    adminXApiKey,
+		CronXApiKey:                     cronXApiKey,
+		RequestTimeoutSec:               requestTimeoutSec,
+		CronBatchCount:                  cronBatchCount,
+		BulkAccountMax:                  bulkAccountMax,
+		ImportReportRowMax:              importReportRowMax,
+		BalanceHistoryRetentionDays:     balanceHistoryRetentionDays,
+		TransactionImportCount:          transactionImportCount,
+		TransactionSettledConfirmations: transactionSettledConfirmations,
+		IdempotencyKeyTtlSec:            idempotencyKeyTtlSec,
+		IdempotencyKeyWaitSec:           idempotencyKeyWaitSec,
+		AccountErrorThreshold:           accountErrorThreshold,
+		BlockchainProviders:             blockchainProviders,
+		BlockchainUrls:                  blockchainUrls,
+		EsploraUrls:                     esploraUrls,
+		BitcoindUrls:                    bitcoindUrls,
+		ElectrumUrls:                    electrumUrls,
+		ElectrumRefreshSec:              electrumRefreshSec,
 		Database: DbConfig{
 			Dsn:        dbDns,
 			Connection: defaultDbConnection,
@@ -104,6 +192,34 @@ func LoadConfig() {
 	}
 }
 
+// GetBlockchainUrlKey is the BlockchainUrls key of the chain network, BTC/mainnet
+func GetBlockchainUrlKey(chain string, network string) string {
+	return chain + "/" + network
+}
+
+// getEnvAsChainNetworkUrls reads the <PREFIX>_<CHAIN>_<NETWORK>_URL variables of every chain network
+func getEnvAsChainNetworkUrls(prefix string, getDefaultUrl func(chain string, network string) string) map[string]string {
+	urls := make(map[string]string)
+	for _, chain := range blockchainChainList {
+		for _, network := range blockchainNetworkList {
+			envName := fmt.Sprintf("%s_%s_%s_URL", prefix, chain, strings.ToUpper(network))
+			urls[GetBlockchainUrlKey(chain, network)] = getEnvAsString(envName, typeUtil.String(getDefaultUrl(chain, network)))
+		}
+	}
+	return urls
+}
+
+// getEnvAsList reads a comma-separated list, the empty items are skipped
+func getEnvAsList(key string, defaultValue *string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(getEnvAsString(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnvAsString(key string, defaultValue *string) string {
 	value, exists := os.LookupEnv(key)
 	if !exists {
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"go-gin-test-job/src/database/entities"
+
+	"gorm.io/gorm"
+)
+
+type AccountBalanceHistoryBucket string
+
+const (
+	AccountBalanceHistoryBucketHour AccountBalanceHistoryBucket = "hour"
+	AccountBalanceHistoryBucketDay  AccountBalanceHistoryBucket = "day"
+	AccountBalanceHistoryBucketWeek AccountBalanceHistoryBucket = "week"
+)
+
+// accountBalanceHistoryBuckets holds the bucket length and the bucket start offset in seconds,
+// buckets are UTC and weeks start on Monday, the unix epoch was a Thursday
+var accountBalanceHistoryBuckets = map[AccountBalanceHistoryBucket]struct {
+	seconds int64
+	offset  int64
+}{
+	AccountBalanceHistoryBucketHour: {seconds: 3600, offset: 0},
+	AccountBalanceHistoryBucketDay:  {seconds: 86400, offset: 0},
+	AccountBalanceHistoryBucketWeek: {seconds: 604800, offset: 4 * 86400},
+}
+
+// GetAccountBalanceHistoryBucketStart returns the start of the bucket the unix time falls into
+func GetAccountBalanceHistoryBucketStart(bucket AccountBalanceHistoryBucket, unixTime int64) int64 {
+	bucketParams := accountBalanceHistoryBuckets[bucket]
+	index := (unixTime - bucketParams.offset) / bucketParams.seconds
+	if (unixTime-bucketParams.offset)%bucketParams.seconds < 0 {
+		index--
+	}
+	return index*bucketParams.seconds + bucketParams.offset
+}
+
+type AccountBalanceHistoryFilter struct {
+	AccountId   int64
+	CreatedFrom *int64
+	CreatedTo   *int64
+}
+
+// AccountBalanceHistoryBucketRow is the last balance change of a bucket
+type AccountBalanceHistoryBucketRow struct {
+	entities.AccountBalanceHistory
+	BucketStart int64
+}
+
+func accountBalanceHistoryTableName() string {
+	return entities.AccountBalanceHistory{}.TableName()
+}
+
+func getAccountBalanceHistoryQuery(filter AccountBalanceHistoryFilter) *gorm.DB {
+	query := DbConn.Table(accountBalanceHistoryTableName()+" history").
+		Where("history.account_id = ?", filter.AccountId)
+	if filter.CreatedFrom != nil {
+		query = query.Where("history.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("history.created_at <= ?", *filter.CreatedTo)
+	}
+	return query
+}
+
+func CreateAccountBalanceHistory(tx *gorm.DB, history *entities.AccountBalanceHistory) error {
+	db := getDb(tx)
+	return db.Create(history).Error
+}
+
+// GetAccountBalanceHistory returns the newest changes first, cursorValues are created_at and id of the last returned row
+func GetAccountBalanceHistory(filter AccountBalanceHistoryFilter, cursorValues []interface{}, count int) []*entities.AccountBalanceHistory {
+	var history []*entities.AccountBalanceHistory
+	query := getAccountBalanceHistoryQuery(filter)
+	if len(cursorValues) == 2 {
+		query = query.Where("(history.created_at < ? OR (history.created_at = ? AND history.id < ?))", cursorValues[0], cursorValues[0], cursorValues[1])
+	}
+	query.
+		Order("history.created_at DESC").
+		Order("history.id DESC").
+		Limit(count).
+		Find(&history)
+	return history
+}
+
+// GetAccountBalanceHistoryBuckets returns the last change of every bucket, the newest bucket first,
+// cursorValues is the bucket start of the last returned row
+func GetAccountBalanceHistoryBuckets(filter AccountBalanceHistoryFilter, bucket AccountBalanceHistoryBucket, cursorValues []interface{}, count int) []*AccountBalanceHistoryBucketRow {
+	var rows []*AccountBalanceHistoryBucketRow
+	bucketParams := accountBalanceHistoryBuckets[bucket]
+	bucketedQuery := getAccountBalanceHistoryQuery(filter).
+		Select(
+			"history.*, FLOOR((history.created_at - ?) / ?) * ? + ? AS bucket_start, "+
+				"ROW_NUMBER() OVER (PARTITION BY FLOOR((history.created_at - ?) / ?) ORDER BY history.created_at DESC, history.id DESC) AS bucket_row",
+			bucketParams.offset, bucketParams.seconds, bucketParams.seconds, bucketParams.offset,
+			bucketParams.offset, bucketParams.seconds,
+		)
+	query := DbConn.Table("(?) bucketed", bucketedQuery).
+		Where("bucketed.bucket_row = 1")
+	if len(cursorValues) == 1 {
+		query = query.Where("bucketed.bucket_start < ?", cursorValues[0])
+	}
+	query.
+		Order("bucketed.bucket_start DESC").
+		Limit(count).
+		Find(&rows)
+	return rows
+}
+
+// DeleteAccountBalanceHistoryBefore removes up to limit changes created before the unix time and returns the removed count
+func DeleteAccountBalanceHistoryBefore(before int64, limit int) (int64, error) {
+	result := DbConn.
+		Where("created_at < ?", before).
+		Limit(limit).
+		Delete(&entities.AccountBalanceHistory{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"fmt"
+	"go-gin-test-job/src/database/entities"
+	stringUtil "go-gin-test-job/src/utils/string"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// AccountFilter holds the conditions of account list queries, zero values are not applied
+type AccountFilter struct {
+	Statuses   []entities.AccountStatus
+	Search     string
+	BalanceMin *decimal.Decimal
+	BalanceMax *decimal.Decimal
+	// BalanceKind is the balance BalanceMin and BalanceMax apply to, the confirmed balance when it is empty
+	BalanceKind entities.AccountBalanceKind
+	RankMin     *uint8
+	RankMax     *uint8
+	CreatedFrom *int64
+	CreatedTo   *int64
+	UpdatedFrom *int64
+	UpdatedTo   *int64
+	Chain       entities.AccountChain
+	Network     entities.AccountNetwork
+	AddressType entities.AccountAddressType
+	Tags        []string
+	TagMode     AccountTagMode
+	MetaFilters []AccountMetaFilter
+	// Fields limits the read fields to these account columns and tags, all fields are read when it is empty
+	Fields []string
+}
+
+// AccountMetaFilter matches the metadata value at Path, a dot-separated path of validated object keys
+type AccountMetaFilter struct {
+	Path  string
+	Value string
+}
+
+type AccountTagMode string
+
+const (
+	AccountTagModeAny AccountTagMode = "any"
+	AccountTagModeAll AccountTagMode = "all"
+)
+
+// getAccountAddressTypes returns the stored address types matched by the filter value
+func getAccountAddressTypes(addressType entities.AccountAddressType) []entities.AccountAddressType {
+	if addressType == entities.AccountAddressTypeBech32 {
+		return entities.AccountSegwitAddressTypes
+	}
+	return []entities.AccountAddressType{addressType}
+}
+
+func applyAccountFilter(query *gorm.DB, filter AccountFilter) *gorm.DB {
+	if len(filter.Statuses) > 0 {
+		query = query.Where("account.status IN ?", filter.Statuses)
+	}
+	if filter.Search != "" {
+		query = applyAccountSearch(query, filter.Search)
+	}
+	// Balance is bound as a string and cast back, so the comparison never goes through float
+	balanceColumn := "account." + filter.BalanceKind.GetField()
+	if filter.BalanceMin != nil {
+		query = query.Where(balanceColumn+" >= CAST(? AS DECIMAL(64, 8))", filter.BalanceMin.String())
+	}
+	if filter.BalanceMax != nil {
+		query = query.Where(balanceColumn+" <= CAST(? AS DECIMAL(64, 8))", filter.BalanceMax.String())
+	}
+	if filter.RankMin != nil {
+		query = query.Where("account.rank >= ?", *filter.RankMin)
+	}
+	if filter.RankMax != nil {
+		query = query.Where("account.rank <= ?", *filter.RankMax)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("account.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("account.created_at <= ?", *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		query = query.Where("account.updated_at >= ?", *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		query = query.Where("account.updated_at <= ?", *filter.UpdatedTo)
+	}
+	if filter.Chain != "" {
+		query = query.Where("account.chain = ?", filter.Chain)
+	}
+	if filter.Network != "" {
+		query = query.Where("account.network = ?", filter.Network)
+	}
+	if filter.AddressType != "" {
+		query = query.Where("account.address_type IN ?", getAccountAddressTypes(filter.AddressType))
+	}
+	if len(filter.Tags) > 0 {
+		query = applyAccountTags(query, filter.Tags, filter.TagMode)
+	}
+	for _, metaFilter := range filter.MetaFilters {
+		query = applyAccountMetaFilter(query, metaFilter)
+	}
+	return query
+}
+
+// applyAccountMetaFilter compares the unquoted JSON value as a string, so 42, "42" and the param 42 match.
+// The ->> operator takes a literal path only, JSON_EXTRACT lets the path be bound like the value
+func applyAccountMetaFilter(query *gorm.DB, metaFilter AccountMetaFilter) *gorm.DB {
+	return query.Where("JSON_UNQUOTE(JSON_EXTRACT(account.metadata, ?)) = ?", "$."+metaFilter.Path, metaFilter.Value)
+}
+
+// applyAccountTags keeps accounts with any of the tags, or with every tag for the all mode
+func applyAccountTags(query *gorm.DB, tags []string, tagMode AccountTagMode) *gorm.DB {
+	subQuery := fmt.Sprintf(
+		"SELECT account_tag.account_id FROM %s account_tag JOIN %s tag ON tag.id = account_tag.tag_id WHERE tag.name IN ?",
+		accountTagTableName(), tagTableName(),
+	)
+	if tagMode == AccountTagModeAll {
+		return query.Where("account.id IN ("+subQuery+" GROUP BY account_tag.account_id HAVING COUNT(*) = ?)", tags, len(tags))
+	}
+	return query.Where("account.id IN ("+subQuery+")", tags)
+}
+
+// applyAccountSearch matches name and memo words by the FULLTEXT index and the address by prefix,
+// so both conditions can use an index instead of a leading-wildcard scan
+func applyAccountSearch(query *gorm.DB, search string) *gorm.DB {
+	addressPattern := stringUtil.EscapeLike(strings.TrimSpace(search)) + "%"
+	fulltextQuery := GetAccountFulltextQuery(search)
+	if fulltextQuery == "" {
+		return query.Where("account.address LIKE ?", addressPattern)
+	}
+	return query.Where(
+		"(MATCH(account.name, account.memo) AGAINST (? IN BOOLEAN MODE) OR account.address LIKE ?)",
+		fulltextQuery, addressPattern,
+	)
+}
+
+// GetAccountFulltextQuery converts user input to a boolean mode query where every word is required
+// and matched as a prefix, boolean operators of the input are dropped like the other non-word characters
+func GetAccountFulltextQuery(search string) string {
+	words := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, "+"+word+"*")
+	}
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"go-gin-test-job/src/database/entities"
+	orderUtil "go-gin-test-job/src/utils/order"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccountStatusStats is the number of accounts and their summed balance of one status and chain,
+// the balance is in the chain currency
+type AccountStatusStats struct {
+	Status  entities.AccountStatus
+	Chain   entities.AccountChain
+	Count   int64
+	Balance decimal.Decimal
+}
+
+// AccountChainStats is the number of accounts and their summed balance of one chain, the balance is in the chain currency
+type AccountChainStats struct {
+	Chain   entities.AccountChain
+	Count   int64
+	Balance decimal.Decimal
+}
+
+// AccountRankBucket is the number of accounts with rank from Bucket * bucketSize + 1 to (Bucket + 1) * bucketSize
+type AccountRankBucket struct {
+	Bucket int
+	Count  int64
+}
+
+type AccountCountStats struct {
+	ZeroBalanceCount int64
+	StaleCount       int64
+}
+
+// GetAccountsStatusStats groups the accounts by status and chain, the balance sum stays DECIMAL up to the scan
+func GetAccountsStatusStats(filter AccountFilter) []*AccountStatusStats {
+	var stats []*AccountStatusStats
+	getBaseAccountsQuery(filter).
+		Select("account.status AS status, account.chain AS chain, COUNT(*) AS count, COALESCE(SUM(account.balance), 0) AS balance").
+		Group("account.status, account.chain").
+		Order("account.status ASC, account.chain ASC").
+		Scan(&stats)
+	return stats
+}
+
+// GetAccountsChainStats groups the accounts by chain, the balances of different chains are never summed
+func GetAccountsChainStats(filter AccountFilter) []*AccountChainStats {
+	var stats []*AccountChainStats
+	getBaseAccountsQuery(filter).
+		Select("account.chain AS chain, COUNT(*) AS count, COALESCE(SUM(account.balance), 0) AS balance").
+		Group("account.chain").
+		Order("account.chain ASC").
+		Scan(&stats)
+	return stats
+}
+
+// GetAccountsRankHistogram returns the non-empty rank buckets ordered by bucket
+func GetAccountsRankHistogram(filter AccountFilter, bucketSize int) []*AccountRankBucket {
+	var buckets []*AccountRankBucket
+	getBaseAccountsQuery(filter).
+		Select("(account.rank - 1) DIV ? AS bucket, COUNT(*) AS count", bucketSize).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&buckets)
+	return buckets
+}
+
+// GetAccountsCountStats counts the zero balance accounts and the accounts which balance was not checked since staleBefore,
+// a never checked account is stale once it was created before staleBefore
+func GetAccountsCountStats(filter AccountFilter, staleBefore int64) AccountCountStats {
+	var stats AccountCountStats
+	getBaseAccountsQuery(filter).
+		Select("COALESCE(SUM(account.balance = 0), 0) AS zero_balance_count, COALESCE(SUM(COALESCE(account.balance_checked_at, account.created_at) < ?), 0) AS stale_count", staleBefore).
+		Scan(&stats)
+	return stats
+}
+
+// GetAccountsTopByBalance returns the count accounts of the chain with the highest balance, ties are broken by id.
+// The balances of different chains are in different currencies, so they are never ranked together
+func GetAccountsTopByBalance(filter AccountFilter, chain entities.AccountChain, count int) []*entities.Account {
+	var accounts []*entities.Account
+	filter.Chain = chain
+	query := applyAccountsOrder(getBaseAccountsQuery(filter), []orderUtil.OrderParam{
+		{Field: "balance", Direction: "DESC"},
+		{Field: "id", Direction: "ASC"},
+	})
+	query.
+		Limit(count).
+		Find(&accounts)
+	loadAccountsTags(accounts)
+	return accounts
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"encoding/json"
+	"go-gin-test-job/src/database/entities"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// AuditContext identifies who made a change, it is written with every audit entry
+type AuditContext struct {
+	Actor     string
+	RequestId string
+}
+
+type AuditLogFilter struct {
+	AccountId   *int64
+	Actor       string
+	Action      entities.AuditAction
+	CreatedFrom *int64
+	CreatedTo   *int64
+}
+
+// accountAuditFields maps the audited account fields to the keys of the audit JSON,
+// the update data of the entity mutators is keyed by the field names
+var accountAuditFields = []struct {
+	field string
+	key   string
+}{
+	{field: "Chain", key: "chain"},
+	{field: "Network", key: "network"},
+	{field: "Address", key: "address"},
+	{field: "Name", key: "name"},
+	{field: "Rank", key: "rank"},
+	{field: "Memo", key: "memo"},
+	{field: "Metadata", key: "metadata"},
+	{field: "Balance", key: "balance"},
+	{field: "UnconfirmedBalance", key: "unconfirmed_balance"},
+	{field: "Status", key: "status"},
+	{field: "StatusReason", key: "status_reason"},
+	{field: "DeletedAt", key: "deleted_at"},
+}
+
+func auditLogTableName() string {
+	return entities.AuditLog{}.TableName()
+}
+
+func getAccountAuditValue(account *entities.Account, field string) interface{} {
+	switch field {
+	case "Chain":
+		return string(account.Chain)
+	case "Network":
+		return string(account.Network)
+	case "Address":
+		return account.Address
+	case "Name":
+		return account.Name
+	case "Rank":
+		return account.Rank
+	case "Memo":
+		return account.Memo
+	case "Metadata":
+		if account.Metadata == nil {
+			return nil
+		}
+		return json.RawMessage(*account.Metadata)
+	case "Balance":
+		return account.Balance.String()
+	case "UnconfirmedBalance":
+		return account.UnconfirmedBalance.String()
+	case "Status":
+		return string(account.Status)
+	case "StatusReason":
+		return account.StatusReason
+	case "DeletedAt":
+		if account.DeletedAt == nil {
+			return nil
+		}
+		return *account.DeletedAt
+	}
+	return nil
+}
+
+// getAccountAuditValues returns the audited fields of the account, fields limits them to the given field names
+func getAccountAuditValues(account *entities.Account, fields map[string]interface{}) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, auditField := range accountAuditFields {
+		if fields != nil {
+			if _, exists := fields[auditField.field]; !exists {
+				continue
+			}
+		}
+		values[auditField.key] = getAccountAuditValue(account, auditField.field)
+	}
+	return values
+}
+
+func marshalAuditValues(values map[string]interface{}) (*string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	result := string(data)
+	return &result, nil
+}
+
+// createAuditLog appends the audit entry, tx must be the transaction of the change
+func createAuditLog(tx *gorm.DB, audit AuditContext, accountId int64, action entities.AuditAction, before map[string]interface{}, after map[string]interface{}) error {
+	beforeJson, err := marshalAuditValues(before)
+	if err != nil {
+		return err
+	}
+	afterJson, err := marshalAuditValues(after)
+	if err != nil {
+		return err
+	}
+	return tx.Create(entities.CreateAuditLog(accountId, action, audit.Actor, audit.RequestId, beforeJson, afterJson)).Error
+}
+
+// createAccountUpdateAuditLog appends the changed fields of updateData, before is the stored row and
+// account holds the new values. Nothing is written when no audited field changed
+func createAccountUpdateAuditLog(tx *gorm.DB, audit AuditContext, action entities.AuditAction, before *entities.Account, account *entities.Account, updateData map[string]interface{}) error {
+	beforeValues := getAccountAuditValues(before, updateData)
+	afterValues := getAccountAuditValues(account, updateData)
+	for key, beforeValue := range beforeValues {
+		if reflect.DeepEqual(beforeValue, afterValues[key]) {
+			delete(beforeValues, key)
+			delete(afterValues, key)
+		}
+	}
+	if len(afterValues) == 0 {
+		return nil
+	}
+	return createAuditLog(tx, audit, account.Id, action, beforeValues, afterValues)
+}
+
+// inTransaction runs fn in tx, or in a new transaction when tx is nil, so the change and its audit entry are written together
+func inTransaction(tx *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if tx != nil {
+		return fn(tx)
+	}
+	return DbConn.Transaction(fn, DefaultTxOptions)
+}
+
+// GetAuditLogs returns the newest entries first, cursorValues is the id of the last returned entry
+func GetAuditLogs(filter AuditLogFilter, cursorValues []interface{}, count int) []*entities.AuditLog {
+	var auditLogs []*entities.AuditLog
+	query := DbConn.Table(auditLogTableName() + " audit")
+	if filter.AccountId != nil {
+		query = query.Where("audit.account_id = ?", *filter.AccountId)
+	}
+	if filter.Actor != "" {
+		query = query.Where("audit.actor = ?", filter.Actor)
+	}
+	if filter.Action != "" {
+		query = query.Where("audit.action = ?", filter.Action)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("audit.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("audit.created_at <= ?", *filter.CreatedTo)
+	}
+	if len(cursorValues) == 1 {
+		query = query.Where("audit.id < ?", cursorValues[0])
+	}
+	query.
+		Order("audit.id DESC").
+		Limit(count).
+		Find(&auditLogs)
+	return auditLogs
+}
@@ -0,0 +1,22 @@
+package entities
+
+const AccountTagTable = "account_tag"
+
+// AccountTag links an account to a tag, the pair is the primary key
+type AccountTag struct {
+	AccountId int64 `json:"account_id" gorm:"primaryKey;autoIncrement:false"`
+	TagId     int64 `json:"tag_id" gorm:"primaryKey;autoIncrement:false;index:account_tag_tag_idx"`
+	CreatedAt int64 `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// Set the table name for the model
+func (AccountTag) TableName() string {
+	return AccountTagTable
+}
+
+func CreateAccountTag(accountId int64, tagId int64) *AccountTag {
+	return &AccountTag{
+		AccountId: accountId,
+		TagId:     tagId,
+	}
+}
@@ -1,7 +1,9 @@
 package entities
 
 import (
+	addressValidationUtil "go-gin-test-job/src/utils/address-validation"
 	timeUtils "go-gin-test-job/src/utils/time"
+	"slices"
 
 	"github.com/shopspring/decimal"
 )
@@ -11,22 +13,176 @@ const AccountTable = "account"
 type AccountStatus string
 
 const (
-	AccountStatusOn  AccountStatus = "On"
-	AccountStatusOff AccountStatus = "Off"
+	// AccountStatusPending is an account which balance has never been checked
+	AccountStatusPending   AccountStatus = "Pending"
+	AccountStatusActive    AccountStatus = "Active"
+	AccountStatusSuspended AccountStatus = "Suspended"
+	AccountStatusArchived  AccountStatus = "Archived"
+	// AccountStatusError is an account which address is repeatedly rejected by the balance provider
+	AccountStatusError AccountStatus = "Error"
 )
 
-var AccountStatusList = []string{string(AccountStatusOn), string(AccountStatusOff)}
+var AccountStatusList = []string{
+	string(AccountStatusPending),
+	string(AccountStatusActive),
+	string(AccountStatusSuspended),
+	string(AccountStatusArchived),
+	string(AccountStatusError),
+}
+
+// AccountInitialStatusList is the statuses an account can be created with
+var AccountInitialStatusList = []string{string(AccountStatusPending), string(AccountStatusActive), string(AccountStatusSuspended)}
+
+// AccountPollingStatuses is the statuses which balances are updated by the cron
+var AccountPollingStatuses = []AccountStatus{AccountStatusPending, AccountStatusActive}
+
+// accountStatusTransitions is the account lifecycle, the statuses each status can move to
+var accountStatusTransitions = map[AccountStatus][]AccountStatus{
+	AccountStatusPending:   {AccountStatusActive, AccountStatusSuspended, AccountStatusArchived, AccountStatusError},
+	AccountStatusActive:    {AccountStatusSuspended, AccountStatusArchived, AccountStatusError},
+	AccountStatusSuspended: {AccountStatusActive, AccountStatusArchived},
+	AccountStatusArchived:  {AccountStatusActive},
+	AccountStatusError:     {AccountStatusPending, AccountStatusSuspended, AccountStatusArchived},
+}
+
+// CanTransitionTo checks the lifecycle allows moving from the status to the given one
+func (s AccountStatus) CanTransitionTo(status AccountStatus) bool {
+	return slices.Contains(accountStatusTransitions[s], status)
+}
+
+// IsPolling checks the cron updates the balance of an account with the status
+func (s AccountStatus) IsPolling() bool {
+	return slices.Contains(AccountPollingStatuses, s)
+}
+
+// AccountChain is the chain of the address, its code is the currency code of the balance
+type AccountChain string
+
+const (
+	AccountChainBtc  = AccountChain(addressValidationUtil.ChainBtc)
+	AccountChainLtc  = AccountChain(addressValidationUtil.ChainLtc)
+	AccountChainBch  = AccountChain(addressValidationUtil.ChainBch)
+	AccountChainDoge = AccountChain(addressValidationUtil.ChainDoge)
+)
+
+var AccountChainList = []string{
+	string(AccountChainBtc),
+	string(AccountChainLtc),
+	string(AccountChainBch),
+	string(AccountChainDoge),
+}
+
+// GetCurrency returns the currency code of the chain balances
+func (c AccountChain) GetCurrency() string {
+	return string(c)
+}
+
+type AccountNetwork string
+
+const (
+	AccountNetworkMainnet = AccountNetwork(addressValidationUtil.NetworkMainnet)
+	AccountNetworkTestnet = AccountNetwork(addressValidationUtil.NetworkTestnet)
+	AccountNetworkSignet  = AccountNetwork(addressValidationUtil.NetworkSignet)
+	AccountNetworkRegtest = AccountNetwork(addressValidationUtil.NetworkRegtest)
+)
+
+var AccountNetworkList = []string{
+	string(AccountNetworkMainnet),
+	string(AccountNetworkTestnet),
+	string(AccountNetworkSignet),
+	string(AccountNetworkRegtest),
+}
+
+// AccountBalanceKind selects the balance the account list is filtered and sorted by
+type AccountBalanceKind string
 
+const (
+	AccountBalanceKindConfirmed   AccountBalanceKind = "confirmed"
+	AccountBalanceKindUnconfirmed AccountBalanceKind = "unconfirmed"
+	AccountBalanceKindTotal       AccountBalanceKind = "total"
+)
+
+var AccountBalanceKindList = []string{
+	string(AccountBalanceKindConfirmed),
+	string(AccountBalanceKindUnconfirmed),
+	string(AccountBalanceKindTotal),
+}
+
+// GetField returns the account field of the balance, the confirmed balance when the kind is empty
+func (k AccountBalanceKind) GetField() string {
+	switch k {
+	case AccountBalanceKindUnconfirmed:
+		return "unconfirmed_balance"
+	case AccountBalanceKindTotal:
+		return "total_balance"
+	default:
+		return "balance"
+	}
+}
+
+// AccountChainNetwork is a network of a chain, the balances are polled per chain network
+type AccountChainNetwork struct {
+	Chain   AccountChain
+	Network AccountNetwork
+}
+
+type AccountAddressType string
+
+const (
+	AccountAddressTypeP2pkh  = AccountAddressType(addressValidationUtil.AddressTypeP2pkh)
+	AccountAddressTypeP2sh   = AccountAddressType(addressValidationUtil.AddressTypeP2sh)
+	AccountAddressTypeP2wpkh = AccountAddressType(addressValidationUtil.AddressTypeP2wpkh)
+	AccountAddressTypeP2wsh  = AccountAddressType(addressValidationUtil.AddressTypeP2wsh)
+	AccountAddressTypeP2tr   = AccountAddressType(addressValidationUtil.AddressTypeP2tr)
+	// AccountAddressTypeBech32 is a filter value only, it matches all the SegWit address types
+	AccountAddressTypeBech32 AccountAddressType = "bech32"
+)
+
+// AccountSegwitAddressTypes is the address types matched by the bech32 filter
+var AccountSegwitAddressTypes = []AccountAddressType{AccountAddressTypeP2wpkh, AccountAddressTypeP2wsh, AccountAddressTypeP2tr}
+
+// AccountAddressTypeList is the address type filter values
+var AccountAddressTypeList = []string{
+	string(AccountAddressTypeP2pkh),
+	string(AccountAddressTypeP2sh),
+	string(AccountAddressTypeP2wpkh),
+	string(AccountAddressTypeP2wsh),
+	string(AccountAddressTypeP2tr),
+	string(AccountAddressTypeBech32),
+}
+
+// Account Chain and Network are the chain network of the address, an address is unique within its chain network.
+// AddressType is classified by the address decoder when the account is created.
+// Metadata is the JSON object of the integration data, nil when there is none.
+// Balance is the confirmed balance, UnconfirmedBalance is the mempool change of it, negative when the mempool
+// spends the confirmed outputs, and TotalBalance is their sum.
+// StatusReason is the reason of the last status transition,
+// ErrorCount is the number of balance updates in a row which address was rejected by the provider,
+// BalanceCheckedAt is the time of the last balance check by the cron, nil when the balance has never been checked
 type Account struct {
-	Id        int64           `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name      string          `json:"name" gorm:"type:varchar(255);not null"`
-	Rank      uint8           `json:"rank" gorm:"type:tinyint;not null;check:rank <= 100"`
-	Memo      string          `json:"memo" gorm:"type:text"`
-	Address   string          `json:"address" gorm:"uniqueIndex:account_address_unique_idx;type:varchar(64);not null"`
-	Balance   decimal.Decimal `json:"balance" gorm:"type:decimal(64,8);default:0;not null"`
-	Status    AccountStatus   `json:"status" gorm:"index:account_status_idx;type:enum('On','Off');not null"`
-	CreatedAt int64           `json:"created_at" gorm:"autoCreateTime;not null"`
-	UpdatedAt int64           `json:"updated_at" gorm:"autoUpdateTime;index:account_updated_at_idx;not null"`
+	Id                 int64              `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name               string             `json:"name" gorm:"type:varchar(255);not null;index:account_name_memo_fulltext_idx,class:FULLTEXT"`
+	Rank               uint8              `json:"rank" gorm:"type:tinyint;not null;check:rank <= 100"`
+	Memo               string             `json:"memo" gorm:"type:text;index:account_name_memo_fulltext_idx,class:FULLTEXT"`
+	Metadata           *string            `json:"metadata" gorm:"type:json"`
+	Chain              AccountChain       `json:"chain" gorm:"uniqueIndex:account_chain_network_address_unique_idx;type:enum('BTC','LTC','BCH','DOGE');default:BTC;not null"`
+	Network            AccountNetwork     `json:"network" gorm:"uniqueIndex:account_chain_network_address_unique_idx;type:enum('mainnet','testnet','signet','regtest');default:mainnet;not null"`
+	Address            string             `json:"address" gorm:"uniqueIndex:account_chain_network_address_unique_idx;type:varchar(64);not null"`
+	AddressType        AccountAddressType `json:"address_type" gorm:"index:account_address_type_idx;type:enum('p2pkh','p2sh','p2wpkh','p2wsh','p2tr');not null"`
+	Balance            decimal.Decimal    `json:"balance" gorm:"type:decimal(64,8);default:0;not null"`
+	UnconfirmedBalance decimal.Decimal    `json:"unconfirmed_balance" gorm:"type:decimal(64,8);default:0;not null"`
+	TotalBalance       decimal.Decimal    `json:"total_balance" gorm:"type:decimal(64,8);default:0;not null"`
+	Status             AccountStatus      `json:"status" gorm:"index:account_status_idx;type:enum('Pending','Active','Suspended','Archived','Error');not null"`
+	StatusReason       string             `json:"status_reason" gorm:"type:varchar(255);default:'';not null"`
+	ErrorCount         uint               `json:"error_count" gorm:"default:0;not null"`
+	BalanceCheckedAt   *int64             `json:"balance_checked_at" gorm:"index:account_balance_checked_at_idx"`
+	// Version grows with every write, the ETag is built from it
+	Version   uint64 `json:"version" gorm:"default:1;not null"`
+	CreatedAt int64  `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt int64  `json:"updated_at" gorm:"autoUpdateTime;index:account_updated_at_idx;not null"`
+	DeletedAt *int64 `json:"deleted_at" gorm:"index:account_deleted_at_idx"`
+	// Tags are loaded by the repository read functions, they are stored in account_tag
+	Tags []*Tag `json:"tags" gorm:"-"`
 }
 
 // Set the table name for the model
@@ -34,21 +190,74 @@ func (Account) TableName() string {
 	return AccountTable
 }
 
-func CreateAccount(address string, status AccountStatus, name string, rank uint8, memo string) *Account {
+func CreateAccount(chain AccountChain, network AccountNetwork, address string, status AccountStatus, name string, rank uint8, memo string) *Account {
+	addressType := addressValidationUtil.GetAddressType(addressValidationUtil.Chain(chain), addressValidationUtil.Network(network), address)
 	return &Account{
-		Address: address,
-		Status:  status,
-		Name:    name,
-		Rank:    rank,
-		Memo:    memo,
+		Chain:       chain,
+		Network:     network,
+		Address:     address,
+		AddressType: AccountAddressType(addressType),
+		Status:      status,
+		Name:        name,
+		Rank:        rank,
+		Memo:        memo,
+		Version:     1,
 	}
 }
 
-func (a *Account) UpdateBalance(balance decimal.Decimal) map[string]interface{} {
+func (a *Account) UpdateBalance(balance decimal.Decimal, unconfirmedBalance decimal.Decimal) map[string]interface{} {
 	a.Balance = balance
+	a.UnconfirmedBalance = unconfirmedBalance
+	a.TotalBalance = balance.Add(unconfirmedBalance)
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"Balance":            a.Balance,
+		"UnconfirmedBalance": a.UnconfirmedBalance,
+		"TotalBalance":       a.TotalBalance,
+		"UpdatedAt":          a.UpdatedAt,
+	}
+}
+
+func (a *Account) UpdateName(name string) map[string]interface{} {
+	a.Name = name
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"Name":      a.Name,
+		"UpdatedAt": a.UpdatedAt,
+	}
+}
+
+func (a *Account) UpdateRank(rank uint8) map[string]interface{} {
+	a.Rank = rank
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"Rank":      a.Rank,
+		"UpdatedAt": a.UpdatedAt,
+	}
+}
+
+// UpdateMemo sets the memo; nil clears it to NULL
+func (a *Account) UpdateMemo(memo *string) map[string]interface{} {
+	var value interface{}
+	if memo != nil {
+		a.Memo = *memo
+		value = a.Memo
+	} else {
+		a.Memo = ""
+	}
 	a.UpdatedAt = timeUtils.GetUnixTime()
 	return map[string]interface{}{
-		"Balance":   a.Balance,
+		"Memo":      value,
+		"UpdatedAt": a.UpdatedAt,
+	}
+}
+
+// UpdateMetadata sets the metadata JSON; nil clears it to NULL
+func (a *Account) UpdateMetadata(metadata *string) map[string]interface{} {
+	a.Metadata = metadata
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"Metadata":  a.Metadata,
 		"UpdatedAt": a.UpdatedAt,
 	}
 }
@@ -61,3 +270,62 @@ func (a *Account) UpdateStatus(status AccountStatus) map[string]interface{} {
 		"UpdatedAt": a.UpdatedAt,
 	}
 }
+
+// Transition moves the account to the status with the reason, the error counter starts over
+// when the balance is polled again
+func (a *Account) Transition(status AccountStatus, reason string) map[string]interface{} {
+	a.Status = status
+	a.StatusReason = reason
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	updateData := map[string]interface{}{
+		"Status":       a.Status,
+		"StatusReason": a.StatusReason,
+		"UpdatedAt":    a.UpdatedAt,
+	}
+	if status.IsPolling() {
+		a.ErrorCount = 0
+		updateData["ErrorCount"] = a.ErrorCount
+	}
+	return updateData
+}
+
+func (a *Account) IncrementErrorCount() map[string]interface{} {
+	a.ErrorCount++
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"ErrorCount": a.ErrorCount,
+		"UpdatedAt":  a.UpdatedAt,
+	}
+}
+
+func (a *Account) ResetErrorCount() map[string]interface{} {
+	a.ErrorCount = 0
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"ErrorCount": a.ErrorCount,
+		"UpdatedAt":  a.UpdatedAt,
+	}
+}
+
+func (a *Account) MarkDeleted() map[string]interface{} {
+	deletedAt := timeUtils.GetUnixTime()
+	a.DeletedAt = &deletedAt
+	a.UpdatedAt = deletedAt
+	return map[string]interface{}{
+		"DeletedAt": a.DeletedAt,
+		"UpdatedAt": a.UpdatedAt,
+	}
+}
+
+func (a *Account) Restore() map[string]interface{} {
+	a.DeletedAt = nil
+	a.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"DeletedAt": nil,
+		"UpdatedAt": a.UpdatedAt,
+	}
+}
+
+func (a *Account) IsDeleted() bool {
+	return a.DeletedAt != nil
+}
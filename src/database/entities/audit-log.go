@@ -0,0 +1,64 @@
+package entities
+
+const AuditLogTable = "audit_log"
+
+type AuditAction string
+
+const (
+	AuditActionCreate    AuditAction = "create"
+	AuditActionUpdate    AuditAction = "update"
+	AuditActionDelete    AuditAction = "delete"
+	AuditActionRestore   AuditAction = "restore"
+	AuditActionPurge     AuditAction = "purge"
+	AuditActionTagAttach AuditAction = "tag_attach"
+	AuditActionTagDetach AuditAction = "tag_detach"
+	// AuditActionTransition is a status change through the account lifecycle
+	AuditActionTransition AuditAction = "transition"
+)
+
+var AuditActionList = []string{
+	string(AuditActionCreate),
+	string(AuditActionUpdate),
+	string(AuditActionDelete),
+	string(AuditActionRestore),
+	string(AuditActionPurge),
+	string(AuditActionTagAttach),
+	string(AuditActionTagDetach),
+	string(AuditActionTransition),
+}
+
+// Actors are the identities of the api keys and of the Electrum subscriptions
+const (
+	AuditActorAdmin    = "admin"
+	AuditActorCron     = "cron"
+	AuditActorElectrum = "electrum"
+)
+
+// AuditLog is an account change, Before and After hold the JSON of the changed fields only.
+// Rows are immutable, the database triggers reject updates and deletes
+type AuditLog struct {
+	Id        int64       `json:"id" gorm:"primaryKey;autoIncrement"`
+	AccountId int64       `json:"account_id" gorm:"index:audit_log_account_idx;not null"`
+	Action    AuditAction `json:"action" gorm:"type:varchar(32);index:audit_log_action_idx;not null"`
+	Actor     string      `json:"actor" gorm:"type:varchar(64);index:audit_log_actor_idx;not null"`
+	RequestId string      `json:"request_id" gorm:"type:varchar(128);not null"`
+	Before    *string     `json:"before" gorm:"type:json"`
+	After     *string     `json:"after" gorm:"type:json"`
+	CreatedAt int64       `json:"created_at" gorm:"autoCreateTime;index:audit_log_created_idx;not null"`
+}
+
+// Set the table name for the model
+func (AuditLog) TableName() string {
+	return AuditLogTable
+}
+
+func CreateAuditLog(accountId int64, action AuditAction, actor string, requestId string, before *string, after *string) *AuditLog {
+	return &AuditLog{
+		AccountId: accountId,
+		Action:    action,
+		Actor:     actor,
+		RequestId: requestId,
+		Before:    before,
+		After:     after,
+	}
+}
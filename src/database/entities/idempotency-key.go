@@ -0,0 +1,66 @@
+package entities
+
+const IdempotencyKeyTable = "idempotency_key"
+
+// IdempotencyKey is the stored response of a request sent with an Idempotency-Key header.
+// A row without a response is claimed by the request in progress, the handler runs after the claim is committed
+type IdempotencyKey struct {
+	Id                  int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	RequestKey          string `json:"request_key" gorm:"type:varchar(255);uniqueIndex:idempotency_key_request_key_unique_idx;not null"`
+	Fingerprint         string `json:"fingerprint" gorm:"type:char(64);not null"`
+	ResponseStatus      int    `json:"response_status" gorm:"type:smallint;default:0;not null"`
+	ResponseContentType string `json:"response_content_type" gorm:"type:varchar(255);not null"`
+	ResponseBody        []byte `json:"response_body" gorm:"type:mediumblob;not null"`
+	CreatedAt           int64  `json:"created_at" gorm:"autoCreateTime;not null"`
+	ExpiresAt           int64  `json:"expires_at" gorm:"index:idempotency_key_expires_at_idx;not null"`
+}
+
+// Set the table name for the model
+func (IdempotencyKey) TableName() string {
+	return IdempotencyKeyTable
+}
+
+func CreateIdempotencyKey(requestKey string, fingerprint string, expiresAt int64) *IdempotencyKey {
+	return &IdempotencyKey{
+		RequestKey:   requestKey,
+		Fingerprint:  fingerprint,
+		ResponseBody: []byte{},
+		ExpiresAt:    expiresAt,
+	}
+}
+
+func (k *IdempotencyKey) IsExpired(unixTime int64) bool {
+	return k.ExpiresAt <= unixTime
+}
+
+// IsCompleted tells if the response is stored, the key is claimed by a request in progress otherwise
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseStatus != 0
+}
+
+// Reset reuses an expired key for a new request
+func (k *IdempotencyKey) Reset(fingerprint string, expiresAt int64) map[string]interface{} {
+	k.Fingerprint = fingerprint
+	k.ResponseStatus = 0
+	k.ResponseContentType = ""
+	k.ResponseBody = []byte{}
+	k.ExpiresAt = expiresAt
+	return map[string]interface{}{
+		"Fingerprint":         k.Fingerprint,
+		"ResponseStatus":      k.ResponseStatus,
+		"ResponseContentType": k.ResponseContentType,
+		"ResponseBody":        k.ResponseBody,
+		"ExpiresAt":           k.ExpiresAt,
+	}
+}
+
+func (k *IdempotencyKey) UpdateResponse(status int, contentType string, body []byte) map[string]interface{} {
+	k.ResponseStatus = status
+	k.ResponseContentType = contentType
+	k.ResponseBody = body
+	return map[string]interface{}{
+		"ResponseStatus":      k.ResponseStatus,
+		"ResponseContentType": k.ResponseContentType,
+		"ResponseBody":        k.ResponseBody,
+	}
+}
@@ -0,0 +1,22 @@
+package entities
+
+const PortfolioAccountTable = "portfolio_account"
+
+// PortfolioAccount is the membership of an account in a portfolio, the pair is the primary key
+type PortfolioAccount struct {
+	PortfolioId int64 `json:"portfolio_id" gorm:"primaryKey;autoIncrement:false"`
+	AccountId   int64 `json:"account_id" gorm:"primaryKey;autoIncrement:false;index:portfolio_account_account_idx"`
+	CreatedAt   int64 `json:"created_at" gorm:"autoCreateTime;not null"`
+}
+
+// Set the table name for the model
+func (PortfolioAccount) TableName() string {
+	return PortfolioAccountTable
+}
+
+func CreatePortfolioAccount(portfolioId int64, accountId int64) *PortfolioAccount {
+	return &PortfolioAccount{
+		PortfolioId: portfolioId,
+		AccountId:   accountId,
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	timeUtils "go-gin-test-job/src/utils/time"
+)
+
+const PortfolioTable = "portfolio"
+
+type Portfolio struct {
+	Id        int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string `json:"name" gorm:"uniqueIndex:portfolio_name_unique_idx;type:varchar(255);not null"`
+	CreatedAt int64  `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt int64  `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// Set the table name for the model
+func (Portfolio) TableName() string {
+	return PortfolioTable
+}
+
+func CreatePortfolio(name string) *Portfolio {
+	return &Portfolio{
+		Name: name,
+	}
+}
+
+func (p *Portfolio) UpdateName(name string) map[string]interface{} {
+	p.Name = name
+	p.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"Name":      p.Name,
+		"UpdatedAt": p.UpdatedAt,
+	}
+}
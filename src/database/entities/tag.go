@@ -0,0 +1,34 @@
+package entities
+
+import (
+	timeUtils "go-gin-test-job/src/utils/time"
+)
+
+const TagTable = "tag"
+
+type Tag struct {
+	Id        int64  `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string `json:"name" gorm:"uniqueIndex:tag_name_unique_idx;type:varchar(64);not null"`
+	CreatedAt int64  `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt int64  `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// Set the table name for the model
+func (Tag) TableName() string {
+	return TagTable
+}
+
+func CreateTag(name string) *Tag {
+	return &Tag{
+		Name: name,
+	}
+}
+
+func (t *Tag) UpdateName(name string) map[string]interface{} {
+	t.Name = name
+	t.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"Name":      t.Name,
+		"UpdatedAt": t.UpdatedAt,
+	}
+}
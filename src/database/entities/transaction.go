@@ -0,0 +1,88 @@
+package entities
+
+import (
+	timeUtils "go-gin-test-job/src/utils/time"
+
+	"github.com/shopspring/decimal"
+)
+
+const TransactionTable = "transaction"
+
+type TransactionDirection string
+
+const (
+	TransactionDirectionIn  TransactionDirection = "in"
+	TransactionDirectionOut TransactionDirection = "out"
+)
+
+var TransactionDirectionList = []string{
+	string(TransactionDirectionIn),
+	string(TransactionDirectionOut),
+}
+
+// Transaction is a transaction of the account address, a txid is stored once per account.
+// Amount is the net change of the account balance, negative when the transaction spends from the address,
+// Fee is the fee of the whole transaction. BlockHeight is nil while the transaction is in the mempool,
+// Time is the block time, or the time the transaction was first seen until it is confirmed
+type Transaction struct {
+	Id            int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	AccountId     int64           `json:"account_id" gorm:"uniqueIndex:transaction_account_txid_unique_idx,priority:1;index:transaction_account_time_idx,priority:1;not null"`
+	Txid          string          `json:"txid" gorm:"uniqueIndex:transaction_account_txid_unique_idx,priority:2;type:char(64);not null"`
+	BlockHeight   *int64          `json:"block_height"`
+	Time          int64           `json:"time" gorm:"index:transaction_account_time_idx,priority:2;not null"`
+	Amount        decimal.Decimal `json:"amount" gorm:"type:decimal(64,8);not null"`
+	Fee           decimal.Decimal `json:"fee" gorm:"type:decimal(64,8);default:0;not null"`
+	Confirmations uint            `json:"confirmations" gorm:"default:0;not null"`
+	CreatedAt     int64           `json:"created_at" gorm:"autoCreateTime;not null"`
+	UpdatedAt     int64           `json:"updated_at" gorm:"autoUpdateTime;not null"`
+}
+
+// Set the table name for the model
+func (Transaction) TableName() string {
+	return TransactionTable
+}
+
+func CreateTransaction(accountId int64, txid string, blockHeight *int64, time int64, amount decimal.Decimal, fee decimal.Decimal, confirmations uint) *Transaction {
+	return &Transaction{
+		AccountId:     accountId,
+		Txid:          txid,
+		BlockHeight:   blockHeight,
+		Time:          time,
+		Amount:        amount,
+		Fee:           fee,
+		Confirmations: confirmations,
+	}
+}
+
+func (t *Transaction) IsConfirmed() bool {
+	return t.BlockHeight != nil
+}
+
+// GetDirection returns out for the transactions spending from the address, in for the others
+func (t *Transaction) GetDirection() TransactionDirection {
+	if t.Amount.IsNegative() {
+		return TransactionDirectionOut
+	}
+	return TransactionDirectionIn
+}
+
+// UpdateChainData refreshes the transaction read again from the provider, a mempool transaction keeps the time
+// it was first seen until it is confirmed
+func (t *Transaction) UpdateChainData(blockHeight *int64, time int64, amount decimal.Decimal, fee decimal.Decimal, confirmations uint) map[string]interface{} {
+	if blockHeight != nil {
+		t.Time = time
+	}
+	t.BlockHeight = blockHeight
+	t.Amount = amount
+	t.Fee = fee
+	t.Confirmations = confirmations
+	t.UpdatedAt = timeUtils.GetUnixTime()
+	return map[string]interface{}{
+		"BlockHeight":   t.BlockHeight,
+		"Time":          t.Time,
+		"Amount":        t.Amount,
+		"Fee":           t.Fee,
+		"Confirmations": t.Confirmations,
+		"UpdatedAt":     t.UpdatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"go-gin-test-job/src/database/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateIdempotencyKeyForUpdate inserts the key unless it exists and locks its row, so a concurrent request
+// with the same key waits until tx ends. It returns the locked row and whether it was inserted by this call
+func CreateIdempotencyKeyForUpdate(tx *gorm.DB, newKey *entities.IdempotencyKey) (*entities.IdempotencyKey, bool, error) {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(newKey)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	var idempotencyKey *entities.IdempotencyKey
+	err := tx.Model(entities.IdempotencyKey{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("request_key = ?", newKey.RequestKey).
+		First(&idempotencyKey).Error
+	if err != nil {
+		return nil, false, err
+	}
+	return idempotencyKey, result.RowsAffected == 1, nil
+}
+
+func UpdateIdempotencyKey(tx *gorm.DB, idempotencyKey *entities.IdempotencyKey, updateData map[string]interface{}) error {
+	db := getDb(tx)
+	return db.Model(entities.IdempotencyKey{}).Where("id = ?", idempotencyKey.Id).Updates(updateData).Error
+}
+
+// DeleteClaimedIdempotencyKey removes the key unless its response is stored, so the request can be retried
+func DeleteClaimedIdempotencyKey(tx *gorm.DB, idempotencyKey *entities.IdempotencyKey) error {
+	db := getDb(tx)
+	return db.Where("id = ? AND response_status = 0", idempotencyKey.Id).Delete(&entities.IdempotencyKey{}).Error
+}
+
+// DeleteIdempotencyKeysExpiredBefore removes up to limit keys expired before the unix time and returns the removed count
+func DeleteIdempotencyKeysExpiredBefore(before int64, limit int) (int64, error) {
+	result := DbConn.
+		Where("expires_at < ?", before).
+		Limit(limit).
+		Delete(&entities.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"fmt"
+	"go-gin-test-job/src/database/entities"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func portfolioTableName() string {
+	return entities.Portfolio{}.TableName()
+}
+
+func portfolioAccountTableName() string {
+	return entities.PortfolioAccount{}.TableName()
+}
+
+func getPortfoliosQuery(db *gorm.DB) *gorm.DB {
+	return db.Table(portfolioTableName() + " portfolio")
+}
+
+// getPortfolioAccountsQuery returns the not deleted member accounts of the portfolio
+func getPortfolioAccountsQuery(portfolioId int64) *gorm.DB {
+	return getAccountsQuery(DbConn).
+		Joins(fmt.Sprintf("JOIN %s portfolio_account ON portfolio_account.account_id = account.id", portfolioAccountTableName())).
+		Where("portfolio_account.portfolio_id = ?", portfolioId)
+}
+
+// PortfolioSummary holds the aggregates of the portfolio member accounts, ranks are nil without members.
+// The balances are summed per chain, the chains have different currencies
+type PortfolioSummary struct {
+	AccountCount int64
+	Balances     []*PortfolioChainBalance `gorm:"-"`
+	MaxRank      *uint8
+	MinRank      *uint8
+}
+
+// PortfolioChainBalance is the number of member accounts of one chain and their summed balance in the chain currency
+type PortfolioChainBalance struct {
+	Chain        entities.AccountChain
+	AccountCount int64
+	Balance      decimal.Decimal
+}
+
+func GetPortfolios() []*entities.Portfolio {
+	var portfolios []*entities.Portfolio
+	getPortfoliosQuery(DbConn).
+		Order("portfolio.name ASC").
+		Find(&portfolios)
+	return portfolios
+}
+
+func GetPortfolioById(id int64) *entities.Portfolio {
+	var portfolio *entities.Portfolio
+	getPortfoliosQuery(DbConn).
+		Where("portfolio.id = ?", id).
+		First(&portfolio)
+	if portfolio.Id == 0 {
+		return nil
+	}
+	return portfolio
+}
+
+func GetPortfolioByIdForUpdate(tx *gorm.DB, id int64) *entities.Portfolio {
+	var portfolio *entities.Portfolio
+	getPortfoliosQuery(tx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("portfolio.id = ?", id).
+		First(&portfolio)
+	if portfolio.Id == 0 {
+		return nil
+	}
+	return portfolio
+}
+
+func IsPortfolioNameExists(tx *gorm.DB, name string, excludeId int64) bool {
+	db := getDb(tx)
+	var portfolio *entities.Portfolio
+	getPortfoliosQuery(db).
+		Where("portfolio.name = ? AND portfolio.id <> ?", name, excludeId).
+		First(&portfolio)
+	return portfolio.Id != 0
+}
+
+func CreatePortfolio(tx *gorm.DB, newPortfolio *entities.Portfolio) (*entities.Portfolio, error) {
+	err := tx.Create(newPortfolio).Error
+	if err != nil {
+		return nil, err
+	}
+	return newPortfolio, nil
+}
+
+func UpdatePortfolio(tx *gorm.DB, portfolio *entities.Portfolio, updateData map[string]interface{}) error {
+	db := getDb(tx)
+	return db.Model(entities.Portfolio{}).Where("id = ?", portfolio.Id).Updates(updateData).Error
+}
+
+// DeletePortfolio removes the portfolio, its memberships are removed by the foreign key cascade
+func DeletePortfolio(tx *gorm.DB, portfolio *entities.Portfolio) error {
+	db := getDb(tx)
+	return db.Where("id = ?", portfolio.Id).Delete(&entities.Portfolio{}).Error
+}
+
+// AddPortfolioAccount adds the account to the portfolio, an existing membership is kept as is
+func AddPortfolioAccount(tx *gorm.DB, portfolioId int64, accountId int64) error {
+	db := getDb(tx)
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(entities.CreatePortfolioAccount(portfolioId, accountId)).Error
+}
+
+func RemovePortfolioAccount(tx *gorm.DB, portfolioId int64, accountId int64) error {
+	db := getDb(tx)
+	return db.Where("portfolio_id = ? AND account_id = ?", portfolioId, accountId).Delete(&entities.PortfolioAccount{}).Error
+}
+
+// GetPortfolioAccounts returns every member account ordered by id, Off accounts included
+func GetPortfolioAccounts(portfolioId int64) []*entities.Account {
+	var accounts []*entities.Account
+	getPortfolioAccountsQuery(portfolioId).
+		Select("account.*").
+		Order("account.id ASC").
+		Find(&accounts)
+	loadAccountsTags(accounts)
+	return accounts
+}
+
+// GetPortfolioSummary aggregates the member accounts in SQL, the balance sums stay DECIMAL up to the scan.
+// Without includeOff only the accounts which balances are polled are counted
+func GetPortfolioSummary(portfolioId int64, includeOff bool) PortfolioSummary {
+	var summary PortfolioSummary
+	getPortfolioSummaryQuery(portfolioId, includeOff).
+		Select("COUNT(*) AS account_count, MAX(account.rank) AS max_rank, MIN(account.rank) AS min_rank").
+		Scan(&summary)
+	getPortfolioSummaryQuery(portfolioId, includeOff).
+		Select("account.chain AS chain, COUNT(*) AS account_count, COALESCE(SUM(account.balance), 0) AS balance").
+		Group("account.chain").
+		Order("account.chain ASC").
+		Scan(&summary.Balances)
+	return summary
+}
+
+func getPortfolioSummaryQuery(portfolioId int64, includeOff bool) *gorm.DB {
+	query := getPortfolioAccountsQuery(portfolioId)
+	if !includeOff {
+		query = query.Where("account.status IN ?", entities.AccountPollingStatuses)
+	}
+	return query
+}
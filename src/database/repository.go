@@ -1,9 +1,14 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"go-gin-test-job/src/database/entities"
+	orderUtil "go-gin-test-job/src/utils/order"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"strings"
 )
 
 func accountTableName() string {
@@ -22,42 +27,194 @@ func getDb(tx *gorm.DB) *gorm.DB {
 
 ///// Account queries
 
-func GetAccountsAndTotal(status entities.AccountStatus, orderParams map[string]string, offset int, count int, search string) ([]*entities.Account, int64) {
+// getAccountsQuery returns the account table query excluding soft-deleted rows
+func getAccountsQuery(db *gorm.DB) *gorm.DB {
+	return db.Table(accountTableName() + " account").
+		Where("account.deleted_at IS NULL")
+}
+
+// getAccountsWithDeletedQuery returns the account table query including soft-deleted rows
+func getAccountsWithDeletedQuery(db *gorm.DB) *gorm.DB {
+	return db.Table(accountTableName() + " account")
+}
+
+func GetAccountsAndTotal(filter AccountFilter, orderParams []orderUtil.OrderParam, offset int, count int) ([]*entities.Account, int64) {
 	var total int64
 	var accounts []*entities.Account
-	query := getBaseAccountsQuery(status, search)
-	totalQuery := getBaseAccountsQuery(status, search)
-	for key, value := range orderParams {
-		query = query.Order(fmt.Sprintf("account.%s %s", key, value))
-	}
+	query := applyAccountFields(getBaseAccountsQuery(filter), filter.Fields)
+	totalQuery := getBaseAccountsQuery(filter)
+	query = applyAccountsOrder(query, orderParams)
 	query.
 		Limit(count).
 		Offset(offset).
 		Find(&accounts)
 	totalQuery.Count(&total)
+	loadAccountsFieldsTags(accounts, filter.Fields)
 	return accounts, total
 }
 
-func getBaseAccountsQuery(status entities.AccountStatus, search string) *gorm.DB {
-	query := DbConn.Table(accountTableName() + " account")
-	if status != "" {
-		query = query.Where("account.status = ?", status)
+// GetAccountsAfterCursorAndTotal seeks past the row with cursorValues instead of using OFFSET,
+// cursorValues must have a value for every order param
+func GetAccountsAfterCursorAndTotal(filter AccountFilter, orderParams []orderUtil.OrderParam, cursorValues []interface{}, count int) ([]*entities.Account, int64) {
+	var total int64
+	var accounts []*entities.Account
+	query := applyAccountFields(getBaseAccountsQuery(filter), filter.Fields)
+	totalQuery := getBaseAccountsQuery(filter)
+	query = applyAccountsKeyset(query, orderParams, cursorValues)
+	query = applyAccountsOrder(query, orderParams)
+	query.
+		Limit(count).
+		Find(&accounts)
+	totalQuery.Count(&total)
+	loadAccountsFieldsTags(accounts, filter.Fields)
+	return accounts, total
+}
+
+// AccountWithScore is an account row with its full-text relevance score
+type AccountWithScore struct {
+	entities.Account
+	Score float64
+}
+
+// GetAccountsByRelevanceAndTotal orders by the full-text score of filter.Search first and then by orderParams
+func GetAccountsByRelevanceAndTotal(filter AccountFilter, orderParams []orderUtil.OrderParam, offset int, count int) ([]*AccountWithScore, int64) {
+	var total int64
+	var accounts []*AccountWithScore
+	query := getBaseAccountsQuery(filter)
+	totalQuery := getBaseAccountsQuery(filter)
+	query = query.
+		Select(getAccountColumns(filter.Fields)+", MATCH(account.name, account.memo) AGAINST (? IN BOOLEAN MODE) AS score", GetAccountFulltextQuery(filter.Search)).
+		Order("score DESC")
+	query = applyAccountsOrder(query, orderParams)
+	query.
+		Limit(count).
+		Offset(offset).
+		Find(&accounts)
+	totalQuery.Count(&total)
+	scoredAccounts := make([]*entities.Account, 0, len(accounts))
+	for _, account := range accounts {
+		scoredAccounts = append(scoredAccounts, &account.Account)
 	}
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Where(
-			"account.address LIKE ? OR account.name LIKE ? OR account.memo LIKE ?",
-			searchPattern, searchPattern, searchPattern,
-		)
+	loadAccountsFieldsTags(scoredAccounts, filter.Fields)
+	return accounts, total
+}
+
+// GetAccountsRows returns a cursor over all matching accounts, so callers can stream any number of rows
+func GetAccountsRows(filter AccountFilter, orderParams []orderUtil.OrderParam) (*sql.Rows, error) {
+	query := applyAccountFields(getBaseAccountsQuery(filter), filter.Fields)
+	query = applyAccountsOrder(query, orderParams)
+	return query.Rows()
+}
+
+func ScanAccountRow(rows *sql.Rows) (*entities.Account, error) {
+	var account entities.Account
+	if err := DbConn.ScanRows(rows, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// accountSortColumns overrides the sort expression and the cursor value placeholder of a field,
+// status is compared as text to match the cursor string and balance is never compared as float
+var accountSortColumns = map[string]struct {
+	expression  string
+	placeholder string
+}{
+	"status":              {expression: "CAST(account.status AS CHAR)", placeholder: "?"},
+	"balance":             {expression: "account.balance", placeholder: "CAST(? AS DECIMAL(64, 8))"},
+	"unconfirmed_balance": {expression: "account.unconfirmed_balance", placeholder: "CAST(? AS DECIMAL(64, 8))"},
+	"total_balance":       {expression: "account.total_balance", placeholder: "CAST(? AS DECIMAL(64, 8))"},
+}
+
+func getAccountSortColumn(field string) (string, string) {
+	if column, exists := accountSortColumns[field]; exists {
+		return column.expression, column.placeholder
+	}
+	return "account." + field, "?"
+}
+
+func applyAccountsOrder(query *gorm.DB, orderParams []orderUtil.OrderParam) *gorm.DB {
+	for _, orderParam := range orderParams {
+		expression, _ := getAccountSortColumn(orderParam.Field)
+		query = query.Order(fmt.Sprintf("%s %s", expression, orderParam.Direction))
 	}
 	return query
 }
 
-func IsAddressExists(tx *gorm.DB, address string) bool {
+// applyAccountsKeyset adds the expanded form of (col1, col2, id) > (?, ?, ?),
+// which unlike the row constructor also works for mixed sort directions
+func applyAccountsKeyset(query *gorm.DB, orderParams []orderUtil.OrderParam, cursorValues []interface{}) *gorm.DB {
+	conditions := make([]string, 0, len(orderParams))
+	args := make([]interface{}, 0)
+	for index, orderParam := range orderParams {
+		parts := make([]string, 0, index+1)
+		for prevIndex := 0; prevIndex < index; prevIndex++ {
+			expression, placeholder := getAccountSortColumn(orderParams[prevIndex].Field)
+			parts = append(parts, fmt.Sprintf("%s = %s", expression, placeholder))
+			args = append(args, cursorValues[prevIndex])
+		}
+		operator := ">"
+		if orderParam.Direction == "DESC" {
+			operator = "<"
+		}
+		expression, placeholder := getAccountSortColumn(orderParam.Field)
+		parts = append(parts, fmt.Sprintf("%s %s %s", expression, operator, placeholder))
+		args = append(args, cursorValues[index])
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+	if len(conditions) == 0 {
+		return query
+	}
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
+
+// accountTagsField is the field read from account_tag instead of an account column
+const accountTagsField = "tags"
+
+// accountCurrencyField is the field derived from the chain column
+const accountCurrencyField = "currency"
+
+// getAccountColumns returns the select list of the account fields, all columns when fields is empty
+func getAccountColumns(fields []string) string {
+	if len(fields) == 0 {
+		return "account.*"
+	}
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field == accountCurrencyField {
+			field = "chain"
+		}
+		if field != accountTagsField && !slices.Contains(columns, "account."+field) {
+			columns = append(columns, "account."+field)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+func applyAccountFields(query *gorm.DB, fields []string) *gorm.DB {
+	if len(fields) == 0 {
+		return query
+	}
+	return query.Select(getAccountColumns(fields))
+}
+
+// loadAccountsFieldsTags loads the tags unless fields leaves them out
+func loadAccountsFieldsTags(accounts []*entities.Account, fields []string) {
+	if len(fields) > 0 && !slices.Contains(fields, accountTagsField) {
+		return
+	}
+	loadAccountsTags(accounts)
+}
+
+func getBaseAccountsQuery(filter AccountFilter) *gorm.DB {
+	return applyAccountFilter(getAccountsQuery(DbConn), filter)
+}
+
+func IsAddressExists(tx *gorm.DB, chain entities.AccountChain, network entities.AccountNetwork, address string) bool {
 	db := getDb(tx)
 	var account *entities.Account
-	db.Table(accountTableName()+" account").
-		Where("account.address = ?", address).
+	getAccountsQuery(db).
+		Where("account.chain = ? AND account.network = ? AND account.address = ?", chain, network, address).
 		First(&account)
 	if account.Id != 0 {
 		return true
@@ -65,44 +222,204 @@ func IsAddressExists(tx *gorm.DB, address string) bool {
 	return false
 }
 
-func GetAccountByAddress(address string) *entities.Account {
+func GetAccountByAddress(chain entities.AccountChain, network entities.AccountNetwork, address string) *entities.Account {
+	return GetAccountByAddressWithFields(chain, network, address, nil)
+}
+
+// GetAccountByAddressWithFields reads only the given fields, see AccountFilter.Fields
+func GetAccountByAddressWithFields(chain entities.AccountChain, network entities.AccountNetwork, address string, fields []string) *entities.Account {
 	var account *entities.Account
-	DbConn.Table(accountTableName()+" account").
-		Where("account.address = ?", address).
+	applyAccountFields(getAccountsQuery(DbConn), fields).
+		Where("account.chain = ? AND account.network = ? AND account.address = ?", chain, network, address).
 		First(&account)
 	if account.Id == 0 {
 		return nil
 	}
+	loadAccountsFieldsTags([]*entities.Account{account}, fields)
 	return account
 }
 
-func CreateAccount(tx *gorm.DB, newAccount *entities.Account) (*entities.Account, error) {
-	err := tx.Create(newAccount).Error
+// GetAccountsByAddresses returns the accounts with the addresses in all chain networks
+func GetAccountsByAddresses(addresses []string) []*entities.Account {
+	var accounts []*entities.Account
+	getAccountsQuery(DbConn).
+		Where("account.address IN(?)", addresses).
+		Find(&accounts)
+	return accounts
+}
+
+// GetAccountByAddressWithDeletedForUpdate locks the account row with the chain network and address, soft-deleted or not
+func GetAccountByAddressWithDeletedForUpdate(tx *gorm.DB, chain entities.AccountChain, network entities.AccountNetwork, address string) *entities.Account {
+	var account *entities.Account
+	getAccountsWithDeletedQuery(tx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("account.chain = ? AND account.network = ? AND account.address = ?", chain, network, address).
+		First(&account)
+	if account.Id == 0 {
+		return nil
+	}
+	return account
+}
+
+func GetAccountById(id int64) *entities.Account {
+	return GetAccountByIdWithFields(id, nil)
+}
+
+// GetAccountByIdWithFields reads only the given fields, see AccountFilter.Fields
+func GetAccountByIdWithFields(id int64, fields []string) *entities.Account {
+	var account *entities.Account
+	applyAccountFields(getAccountsQuery(DbConn), fields).
+		Where("account.id = ?", id).
+		First(&account)
+	if account.Id == 0 {
+		return nil
+	}
+	loadAccountsFieldsTags([]*entities.Account{account}, fields)
+	return account
+}
+
+// GetAccountByIdWithDeleted returns the account, soft-deleted or not
+func GetAccountByIdWithDeleted(id int64) *entities.Account {
+	var account *entities.Account
+	getAccountsWithDeletedQuery(DbConn).
+		Where("account.id = ?", id).
+		First(&account)
+	if account.Id == 0 {
+		return nil
+	}
+	loadAccountsTags([]*entities.Account{account})
+	return account
+}
+
+func GetAccountByIdForUpdate(tx *gorm.DB, id int64) *entities.Account {
+	var account *entities.Account
+	getAccountsQuery(tx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("account.id = ?", id).
+		First(&account)
+	if account.Id == 0 {
+		return nil
+	}
+	return account
+}
+
+// GetAccountByIdWithDeletedForUpdate locks the account row, soft-deleted or not
+func GetAccountByIdWithDeletedForUpdate(tx *gorm.DB, id int64) *entities.Account {
+	var account *entities.Account
+	getAccountsWithDeletedQuery(tx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("account.id = ?", id).
+		First(&account)
+	if account.Id == 0 {
+		return nil
+	}
+	return account
+}
+
+// CreateAccount inserts the account and its create audit entry
+func CreateAccount(tx *gorm.DB, audit AuditContext, newAccount *entities.Account) (*entities.Account, error) {
+	err := inTransaction(tx, func(tx *gorm.DB) error {
+		if err := tx.Create(newAccount).Error; err != nil {
+			return err
+		}
+		return createAuditLog(tx, audit, newAccount.Id, entities.AuditActionCreate, nil, getAccountAuditValues(newAccount, nil))
+	})
 	if err != nil {
 		return nil, err
 	}
 	return newAccount, nil
 }
 
-func GetAccountsBatch(limit int) []*entities.Account {
+// GetAccountsBatch returns the accounts of the chain networks which balances are polled, the least recently checked first,
+// the never checked accounts before all
+func GetAccountsBatch(chainNetworks []entities.AccountChainNetwork, limit int) []*entities.Account {
+	if len(chainNetworks) == 0 {
+		return []*entities.Account{}
+	}
+	chainNetworkValues := make([][]interface{}, 0, len(chainNetworks))
+	for _, chainNetwork := range chainNetworks {
+		chainNetworkValues = append(chainNetworkValues, []interface{}{chainNetwork.Chain, chainNetwork.Network})
+	}
 	var accounts []*entities.Account
-	DbConn.Table(accountTableName()+" account").
-		Where("account.status = ?", entities.AccountStatusOn).
-		Order("account.updated_at ASC").
+	getAccountsQuery(DbConn).
+		Where("account.status IN ? AND (account.chain, account.network) IN ?", entities.AccountPollingStatuses, chainNetworkValues).
+		Order("account.balance_checked_at ASC, account.id ASC").
 		Limit(limit).
 		Find(&accounts)
 	return accounts
 }
 
+// GetPollingAccounts returns all the accounts of the chain network which balances are kept up to date
+func GetPollingAccounts(chainNetwork entities.AccountChainNetwork) []*entities.Account {
+	var accounts []*entities.Account
+	getAccountsQuery(DbConn).
+		Where("account.status IN ? AND account.chain = ? AND account.network = ?", entities.AccountPollingStatuses, chainNetwork.Chain, chainNetwork.Network).
+		Order("account.id ASC").
+		Find(&accounts)
+	return accounts
+}
+
 func GetAccountsByIds(accountIds []int64) []*entities.Account {
 	var accounts []*entities.Account
-	DbConn.Table(accountTableName()+" account").
+	getAccountsQuery(DbConn).
 		Where("account.id IN(?)", accountIds).
 		Find(&accounts)
 	return accounts
 }
 
-func UpdateAccount(tx *gorm.DB, account *entities.Account, updateData map[string]interface{}) error {
-	db := getDb(tx)
-	return db.Model(entities.Account{}).Where("id = ?", account.Id).Updates(updateData).Error
+// UpdateAccount updates the account unless it was soft-deleted meanwhile and moves its version,
+// the changed fields are written to the audit log with the action
+func UpdateAccount(tx *gorm.DB, audit AuditContext, action entities.AuditAction, account *entities.Account, updateData map[string]interface{}) error {
+	return inTransaction(tx, func(tx *gorm.DB) error {
+		var before *entities.Account
+		getAccountsQuery(tx).
+			Where("account.id = ?", account.Id).
+			First(&before)
+		if before.Id == 0 {
+			return nil
+		}
+		updateData["Version"] = gorm.Expr("version + 1")
+		account.Version = before.Version + 1
+		if err := tx.Model(entities.Account{}).Where("id = ? AND deleted_at IS NULL", account.Id).Updates(updateData).Error; err != nil {
+			return err
+		}
+		return createAccountUpdateAuditLog(tx, audit, action, before, account, updateData)
+	})
+}
+
+// UpdateAccountWithDeleted updates the account even when it is soft-deleted, used for delete and restore
+func UpdateAccountWithDeleted(tx *gorm.DB, audit AuditContext, action entities.AuditAction, account *entities.Account, updateData map[string]interface{}) error {
+	return inTransaction(tx, func(tx *gorm.DB) error {
+		var before *entities.Account
+		getAccountsWithDeletedQuery(tx).
+			Where("account.id = ?", account.Id).
+			First(&before)
+		if before.Id == 0 {
+			return nil
+		}
+		updateData["Version"] = gorm.Expr("version + 1")
+		account.Version = before.Version + 1
+		if err := tx.Model(entities.Account{}).Where("id = ?", account.Id).Updates(updateData).Error; err != nil {
+			return err
+		}
+		return createAccountUpdateAuditLog(tx, audit, action, before, account, updateData)
+	})
+}
+
+// UpdateAccountBalanceCheckedAt records the balance check of the cron, it is not a change of the account,
+// so neither the version, the updated_at nor the audit log move
+func UpdateAccountBalanceCheckedAt(tx *gorm.DB, account *entities.Account, checkedAt int64) error {
+	account.BalanceCheckedAt = &checkedAt
+	return getDb(tx).Model(entities.Account{}).Where("id = ?", account.Id).UpdateColumn("balance_checked_at", checkedAt).Error
+}
+
+// PurgeAccount removes the account row, the audit entry keeps its last values
+func PurgeAccount(tx *gorm.DB, audit AuditContext, account *entities.Account) error {
+	return inTransaction(tx, func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", account.Id).Delete(&entities.Account{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return createAuditLog(tx, audit, account.Id, entities.AuditActionPurge, getAccountAuditValues(account, nil), nil)
+	})
 }
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"go-gin-test-job/src/database/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func tagTableName() string {
+	return entities.Tag{}.TableName()
+}
+
+func accountTagTableName() string {
+	return entities.AccountTag{}.TableName()
+}
+
+func getTagsQuery(db *gorm.DB) *gorm.DB {
+	return db.Table(tagTableName() + " tag")
+}
+
+func GetTags() []*entities.Tag {
+	var tags []*entities.Tag
+	getTagsQuery(DbConn).
+		Order("tag.name ASC").
+		Find(&tags)
+	return tags
+}
+
+func GetTagById(id int64) *entities.Tag {
+	var tag *entities.Tag
+	getTagsQuery(DbConn).
+		Where("tag.id = ?", id).
+		First(&tag)
+	if tag.Id == 0 {
+		return nil
+	}
+	return tag
+}
+
+func GetTagByIdForUpdate(tx *gorm.DB, id int64) *entities.Tag {
+	var tag *entities.Tag
+	getTagsQuery(tx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("tag.id = ?", id).
+		First(&tag)
+	if tag.Id == 0 {
+		return nil
+	}
+	return tag
+}
+
+func IsTagNameExists(tx *gorm.DB, name string, excludeId int64) bool {
+	db := getDb(tx)
+	var tag *entities.Tag
+	getTagsQuery(db).
+		Where("tag.name = ? AND tag.id <> ?", name, excludeId).
+		First(&tag)
+	return tag.Id != 0
+}
+
+func CreateTag(tx *gorm.DB, newTag *entities.Tag) (*entities.Tag, error) {
+	err := tx.Create(newTag).Error
+	if err != nil {
+		return nil, err
+	}
+	return newTag, nil
+}
+
+func UpdateTag(tx *gorm.DB, tag *entities.Tag, updateData map[string]interface{}) error {
+	db := getDb(tx)
+	return db.Model(entities.Tag{}).Where("id = ?", tag.Id).Updates(updateData).Error
+}
+
+// DeleteTag removes the tag, its account links are removed by the foreign key cascade.
+// Every linked account gets a detach entry first, the links can not be read after the delete
+func DeleteTag(tx *gorm.DB, audit AuditContext, tag *entities.Tag) error {
+	return inTransaction(tx, func(tx *gorm.DB) error {
+		var accountIds []int64
+		err := tx.Table(accountTagTableName()).
+			Where("tag_id = ?", tag.Id).
+			Order("account_id ASC").
+			Pluck("account_id", &accountIds).Error
+		if err != nil {
+			return err
+		}
+		for _, accountId := range accountIds {
+			if err := createAuditLog(tx, audit, accountId, entities.AuditActionTagDetach, map[string]interface{}{"tag_id": tag.Id}, nil); err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", tag.Id).Delete(&entities.Tag{}).Error
+	})
+}
+
+// AttachAccountTag links the tag to the account, an existing link is kept as is and is not audited
+func AttachAccountTag(tx *gorm.DB, audit AuditContext, accountId int64, tagId int64) error {
+	return inTransaction(tx, func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(entities.CreateAccountTag(accountId, tagId))
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return createAuditLog(tx, audit, accountId, entities.AuditActionTagAttach, nil, map[string]interface{}{"tag_id": tagId})
+	})
+}
+
+func DetachAccountTag(tx *gorm.DB, audit AuditContext, accountId int64, tagId int64) error {
+	return inTransaction(tx, func(tx *gorm.DB) error {
+		result := tx.Where("account_id = ? AND tag_id = ?", accountId, tagId).Delete(&entities.AccountTag{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return createAuditLog(tx, audit, accountId, entities.AuditActionTagDetach, map[string]interface{}{"tag_id": tagId}, nil)
+	})
+}
+
+// loadAccountsTags sets Tags of every account with a single query, tags are ordered by name
+func loadAccountsTags(accounts []*entities.Account) {
+	if len(accounts) == 0 {
+		return
+	}
+	accountIds := make([]int64, 0, len(accounts))
+	accountsById := make(map[int64]*entities.Account, len(accounts))
+	for _, account := range accounts {
+		account.Tags = make([]*entities.Tag, 0)
+		accountIds = append(accountIds, account.Id)
+		accountsById[account.Id] = account
+	}
+	var rows []struct {
+		AccountId int64
+		entities.Tag
+	}
+	getTagsQuery(DbConn).
+		Select("account_tag.account_id, tag.*").
+		Joins("JOIN "+accountTagTableName()+" account_tag ON account_tag.tag_id = tag.id").
+		Where("account_tag.account_id IN(?)", accountIds).
+		Order("tag.name ASC").
+		Find(&rows)
+	for index := range rows {
+		if account, exists := accountsById[rows[index].AccountId]; exists {
+			account.Tags = append(account.Tags, &rows[index].Tag)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"go-gin-test-job/src/database/entities"
+
+	"gorm.io/gorm"
+)
+
+type TransactionFilter struct {
+	AccountId int64
+	Direction entities.TransactionDirection
+}
+
+func transactionTableName() string {
+	return entities.Transaction{}.TableName()
+}
+
+func getTransactionsQuery(filter TransactionFilter) *gorm.DB {
+	query := DbConn.Table("`"+transactionTableName()+"` account_transaction").
+		Where("account_transaction.account_id = ?", filter.AccountId)
+	switch filter.Direction {
+	case entities.TransactionDirectionIn:
+		query = query.Where("account_transaction.amount >= 0")
+	case entities.TransactionDirectionOut:
+		query = query.Where("account_transaction.amount < 0")
+	}
+	return query
+}
+
+// GetTransactions returns the newest transactions first, cursorValues are time and id of the last returned row
+func GetTransactions(filter TransactionFilter, cursorValues []interface{}, count int) []*entities.Transaction {
+	var transactions []*entities.Transaction
+	query := getTransactionsQuery(filter)
+	if len(cursorValues) == 2 {
+		query = query.Where("(account_transaction.time < ? OR (account_transaction.time = ? AND account_transaction.id < ?))", cursorValues[0], cursorValues[0], cursorValues[1])
+	}
+	query.
+		Order("account_transaction.time DESC").
+		Order("account_transaction.id DESC").
+		Limit(count).
+		Find(&transactions)
+	return transactions
+}
+
+// GetTransactionsByTxids returns the stored transactions of the account with the txids
+func GetTransactionsByTxids(tx *gorm.DB, accountId int64, txids []string) []*entities.Transaction {
+	var transactions []*entities.Transaction
+	if len(txids) == 0 {
+		return transactions
+	}
+	db := getDb(tx)
+	db.Where("account_id = ? AND txid IN ?", accountId, txids).
+		Find(&transactions)
+	return transactions
+}
+
+// GetSettledTransactionTxids returns the txids among the latest count transactions of the account which have
+// at least minConfirmations
+func GetSettledTransactionTxids(accountId int64, minConfirmations int, count int) map[string]bool {
+	var transactions []*entities.Transaction
+	DbConn.Select("txid", "confirmations").
+		Where("account_id = ?", accountId).
+		Order("time DESC").
+		Order("id DESC").
+		Limit(count).
+		Find(&transactions)
+	txids := make(map[string]bool)
+	for _, transaction := range transactions {
+		if transaction.IsConfirmed() && int(transaction.Confirmations) >= minConfirmations {
+			txids[transaction.Txid] = true
+		}
+	}
+	return txids
+}
+
+func CreateTransactions(tx *gorm.DB, transactions []*entities.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	db := getDb(tx)
+	return db.Create(transactions).Error
+}
+
+func UpdateTransaction(tx *gorm.DB, transaction *entities.Transaction, updateData map[string]interface{}) error {
+	db := getDb(tx)
+	return db.Model(transaction).Updates(updateData).Error
+}
+
+// DeleteUnconfirmedTransactionsExcept removes the mempool transactions of the account which are not in txids,
+// they were replaced or dropped from the mempool
+func DeleteUnconfirmedTransactionsExcept(tx *gorm.DB, accountId int64, txids []string) error {
+	db := getDb(tx)
+	query := db.Where("account_id = ? AND block_height IS NULL", accountId)
+	if len(txids) > 0 {
+		query = query.Where("txid NOT IN ?", txids)
+	}
+	return query.Delete(&entities.Transaction{}).Error
+}
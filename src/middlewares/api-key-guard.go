@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	auditContext "go-gin-test-job/src/common/audit-context"
 	errorHelper "go-gin-test-job/src/common/error-helpers"
 	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database/entities"
 )
 
 func AdminApiKeyGuard() gin.HandlerFunc {
@@ -14,6 +16,7 @@ func AdminApiKeyGuard() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		auditContext.SetActor(c, entities.AuditActorAdmin)
 		c.Next()
 	}
 }
@@ -26,6 +29,7 @@ func CronApiKeyGuard() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		auditContext.SetActor(c, entities.AuditActorCron)
 		c.Next()
 	}
 }
@@ -21,6 +21,10 @@ func ErrorHandler() gin.HandlerFunc {
 					c.JSON(http.StatusNotFound, errorHelpers.NewResponseNotFoundErrorHTTP(err.Error()))
 				case http.StatusConflict:
 					c.JSON(http.StatusConflict, errorHelpers.NewResponseConflictErrorHTTP(err.Error()))
+				case http.StatusPreconditionFailed:
+					c.JSON(http.StatusPreconditionFailed, errorHelpers.NewResponsePreconditionFailedErrorHTTP(err.Error()))
+				case http.StatusUnprocessableEntity:
+					c.JSON(http.StatusUnprocessableEntity, errorHelpers.NewResponseUnprocessableEntityErrorHTTP(err.Error()))
 				case http.StatusInternalServerError:
 					c.JSON(http.StatusInternalServerError, errorHelpers.NewResponseInternalErrorHTTP(err.Error()))
 				default:
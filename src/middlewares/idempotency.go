@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/logger"
+	timeUtil "go-gin-test-job/src/utils/time"
+	"gorm.io/gorm"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyMaxLength  = 255
+	// idempotencyKeyPollInterval is how often a retry checks the key claimed by the request in progress
+	idempotencyKeyPollInterval = 100 * time.Millisecond
+)
+
+// idempotencyResponseWriter keeps a copy of the response body to store it with the key
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// getIdempotencyFingerprint hashes the method, the path with the query and the body of the request
+func getIdempotencyFingerprint(c *gin.Context, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyKeyState is the outcome of claiming the key
+type idempotencyKeyState int
+
+const (
+	idempotencyKeyClaimed idempotencyKeyState = iota
+	idempotencyKeyCompleted
+	idempotencyKeyInProgress
+)
+
+// IdempotencyKeyMiddleware makes a request with an Idempotency-Key header run once: a retry with the same key
+// and the same request gets the stored response, the same key with another request gets 422.
+// The key row is claimed in a short transaction under the row lock before the handler runs, a concurrent
+// duplicate waits for the stored response up to IdempotencyKeyWaitSec and gets 409 after that.
+// Responses with a 5xx status are not stored, the key is released so the request can be retried. When the
+// response can not be stored after the handler is done, the key stays claimed until it expires, so a retry
+// does not repeat the change. The keys are not scoped per API key, the clients should send unique keys such as
+// UUIDs. The request body is read into memory, so the middleware is not used for streamed uploads
+func IdempotencyKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestKey := c.GetHeader(IdempotencyKeyHeader)
+		if requestKey == "" {
+			c.Next()
+			return
+		}
+		if len(requestKey) > idempotencyKeyMaxLength {
+			_ = errorHelpers.RespondBadRequestError(c, fmt.Sprintf("%s must be shorter than or equal to %d characters", IdempotencyKeyHeader, idempotencyKeyMaxLength))
+			c.Abort()
+			return
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			_ = errorHelpers.RespondBadRequestError(c, "Read request body error. "+err.Error())
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := getIdempotencyFingerprint(c, body)
+
+		waitUntil := time.Now().Add(timeUtil.DurationSeconds(config.AppConfig.IdempotencyKeyWaitSec))
+		for {
+			idempotencyKey, state, err := claimIdempotencyKey(c, requestKey, fingerprint)
+			if err != nil {
+				if !c.Writer.Written() {
+					_ = errorHelpers.RespondInternalError(c, err.Error())
+				}
+				c.Abort()
+				return
+			}
+			switch state {
+			case idempotencyKeyClaimed:
+				runIdempotentRequest(c, idempotencyKey)
+				return
+			case idempotencyKeyCompleted:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(idempotencyKey.ResponseStatus, idempotencyKey.ResponseContentType, idempotencyKey.ResponseBody)
+				c.Abort()
+				return
+			}
+			if time.Now().After(waitUntil) {
+				_ = errorHelpers.RespondConflictError(c, fmt.Sprintf("%s request is still in progress", IdempotencyKeyHeader))
+				c.Abort()
+				return
+			}
+			time.Sleep(idempotencyKeyPollInterval)
+		}
+	}
+}
+
+// IdempotencyKeyUnsupportedMiddleware rejects the Idempotency-Key header on the streamed uploads, the body is not
+// read into memory to fingerprint it, so a retry with the key could not be told from another request
+func IdempotencyKeyUnsupportedMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(IdempotencyKeyHeader) != "" {
+			_ = errorHelpers.RespondBadRequestError(c, fmt.Sprintf("%s is not supported by the streamed uploads", IdempotencyKeyHeader))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// claimIdempotencyKey inserts the key or reuses the expired one for the request. The 422 response is written
+// when the key was used with a different request
+func claimIdempotencyKey(c *gin.Context, requestKey string, fingerprint string) (*entities.IdempotencyKey, idempotencyKeyState, error) {
+	now := timeUtil.GetUnixTime()
+	expiresAt := now + int64(config.AppConfig.IdempotencyKeyTtlSec)
+	var idempotencyKey *entities.IdempotencyKey
+	state := idempotencyKeyInProgress
+	err := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		var isCreated bool
+		var err error
+		idempotencyKey, isCreated, err = database.CreateIdempotencyKeyForUpdate(tx, entities.CreateIdempotencyKey(requestKey, fingerprint, expiresAt))
+		if err != nil {
+			return err
+		}
+		if isCreated {
+			state = idempotencyKeyClaimed
+			return nil
+		}
+		if idempotencyKey.IsExpired(now) {
+			state = idempotencyKeyClaimed
+			return database.UpdateIdempotencyKey(tx, idempotencyKey, idempotencyKey.Reset(fingerprint, expiresAt))
+		}
+		if idempotencyKey.Fingerprint != fingerprint {
+			return errorHelpers.RespondUnprocessableEntityError(c, fmt.Sprintf("%s was used with a different request", IdempotencyKeyHeader))
+		}
+		if idempotencyKey.IsCompleted() {
+			state = idempotencyKeyCompleted
+		}
+		return nil
+	}, database.DefaultTxOptions)
+	return idempotencyKey, state, err
+}
+
+// runIdempotentRequest runs the handler with the claimed key and stores its response. The handler runs outside
+// of the key transaction, so it does not hold a second connection of the pool
+func runIdempotentRequest(c *gin.Context, idempotencyKey *entities.IdempotencyKey) {
+	writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	defer func() {
+		c.Writer = writer.ResponseWriter
+		if recovered := recover(); recovered != nil {
+			releaseIdempotencyKey(idempotencyKey)
+			panic(recovered)
+		}
+	}()
+	c.Next()
+	if writer.Status() >= http.StatusInternalServerError {
+		releaseIdempotencyKey(idempotencyKey)
+		return
+	}
+	updateData := idempotencyKey.UpdateResponse(writer.Status(), writer.Header().Get("Content-Type"), writer.body.Bytes())
+	if err := database.UpdateIdempotencyKey(nil, idempotencyKey, updateData); err != nil {
+		logger.Logger.Error().Msg(fmt.Sprintf("Store %s %s response error, the key stays claimed. %s", IdempotencyKeyHeader, idempotencyKey.RequestKey, err.Error()))
+	}
+}
+
+func releaseIdempotencyKey(idempotencyKey *entities.IdempotencyKey) {
+	if err := database.DeleteClaimedIdempotencyKey(nil, idempotencyKey); err != nil {
+		logger.Logger.Error().Msg(fmt.Sprintf("Release %s %s error. %s", IdempotencyKeyHeader, idempotencyKey.RequestKey, err.Error()))
+	}
+}
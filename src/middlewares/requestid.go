@@ -3,6 +3,7 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	auditContext "go-gin-test-job/src/common/audit-context"
 )
 
 func RequestIDMiddleware() gin.HandlerFunc {
@@ -15,6 +16,8 @@ func RequestIDMiddleware() gin.HandlerFunc {
 
 		// Set the request ID in the response header
 		c.Header("X-Request-ID", requestID)
+		// Keep the request ID for the audit log
+		auditContext.SetRequestId(c, requestID)
 
 		// Continue with the request
 		c.Next()
@@ -0,0 +1,36 @@
+package accountModule
+
+import (
+	"go-gin-test-job/src/database"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getAccountBalanceHistory reads one row more than requested to know if there is a next page
+func getAccountBalanceHistory(c *gin.Context, id int64, dto accountModuleDto.GetAccountBalanceHistoryRequestDto, cursorValues []interface{}) (accountModuleDto.GetAccountBalanceHistoryResponseDto, error) {
+	account, err := getAccountById(c, id)
+	if err != nil {
+		return accountModuleDto.GetAccountBalanceHistoryResponseDto{}, err
+	}
+	filter := dto.CreateAccountBalanceHistoryFilter(account.Id)
+	orderParams := dto.GetOrderParams()
+	if dto.Bucket != "" {
+		rows := database.GetAccountBalanceHistoryBuckets(filter, database.AccountBalanceHistoryBucket(dto.Bucket), cursorValues, dto.Count+1)
+		if len(rows) <= dto.Count {
+			return accountModuleDto.CreateGetAccountBalanceHistoryBucketsResponseDto(rows, nil), nil
+		}
+		rows = rows[:dto.Count]
+		nextCursor := orderUtil.EncodeCursor(orderParams, []interface{}{rows[dto.Count-1].BucketStart})
+		return accountModuleDto.CreateGetAccountBalanceHistoryBucketsResponseDto(rows, &nextCursor), nil
+	}
+	history := database.GetAccountBalanceHistory(filter, cursorValues, dto.Count+1)
+	if len(history) <= dto.Count {
+		return accountModuleDto.CreateGetAccountBalanceHistoryResponseDto(history, nil), nil
+	}
+	history = history[:dto.Count]
+	lastItem := history[dto.Count-1]
+	nextCursor := orderUtil.EncodeCursor(orderParams, []interface{}{lastItem.CreatedAt, lastItem.Id})
+	return accountModuleDto.CreateGetAccountBalanceHistoryResponseDto(history, &nextCursor), nil
+}
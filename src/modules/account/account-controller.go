@@ -1,6 +1,8 @@
 package accountModule
 
 import (
+	"go-gin-test-job/src/common/dto"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
 	accountModuleDto "go-gin-test-job/src/modules/account/dto"
 	orderUtil "go-gin-test-job/src/utils/order"
 
@@ -15,9 +17,27 @@ import (
 // @Produce json
 // @Param offset query int false "This is paging offset. 0 by default" minimum(0) default(0)
 // @Param count query int false "Max item count in single response. 100 by default" minimum(1) maximum(100) default(100)
-// @Param status query string false "Account statuses: On, Off" Enums("On", "Off") default("On")
-// @Param orderBy query string false "Comma-separated sort order options (sort fields: id, updated_at, address, name, rank; sort order: ASC,DESC)" default(id ASC)
-// @Param search query string false "Search in address, name and memo fields"
+// @Param status query string false "Comma-separated account statuses: Pending, Active, Suspended, Archived, Error" example(Pending,Active)
+// @Param search query string false "Full-text search in name and memo words, address prefix search"
+// @Param balanceMin query string false "Min balance, inclusive decimal, negative for the unconfirmed and total balances only" example(0.001)
+// @Param balanceMax query string false "Max balance, inclusive decimal, negative for the unconfirmed and total balances only" example(10.5)
+// @Param balanceKind query string false "Balance of balanceMin, balanceMax and the balance sort: confirmed, unconfirmed or total. confirmed by default" Enums("confirmed", "unconfirmed", "total")
+// @Param rankMin query int false "Min rank, inclusive" minimum(0) maximum(100)
+// @Param rankMax query int false "Max rank, inclusive" minimum(0) maximum(100)
+// @Param createdFrom query int false "Created at from, inclusive unix time" minimum(0)
+// @Param createdTo query int false "Created at to, inclusive unix time" minimum(0)
+// @Param updatedFrom query int false "Updated at from, inclusive unix time" minimum(0)
+// @Param updatedTo query int false "Updated at to, inclusive unix time" minimum(0)
+// @Param chain query string false "Account chain" Enums("BTC", "LTC", "BCH", "DOGE")
+// @Param network query string false "Address network" Enums("mainnet", "testnet", "signet", "regtest")
+// @Param addressType query string false "Address types: p2pkh, p2sh, p2wpkh, p2wsh, p2tr, bech32 for all SegWit types" Enums("p2pkh", "p2sh", "p2wpkh", "p2wsh", "p2tr", "bech32")
+// @Param tags query string false "Comma-separated tag names" example(exchange,cold)
+// @Param tagMode query string false "Match accounts with any of the tags or with all of them. any by default" Enums("any", "all")
+// @Param meta.path query string false "Metadata filter, meta.crm.id=42 matches the metadata {\"crm\": {\"id\": 42}}. Up to 5 filters, the path is dot-separated names of letters, digits and underscores"
+// @Param orderBy query string false "Comma-separated sort order options (sort fields: id, updated_at, created_at, address, name, rank, balance, unconfirmed_balance, total_balance, status; sort order: ASC,DESC)" default(id ASC)
+// @Param sort query string false "Sort by search relevance first, then by orderBy. Requires search, the list items get score" Enums("relevance")
+// @Param cursor query string false "nextCursor of the previous page. Can not be used with offset and sort, orderBy must be the same"
+// @Param fields query string false "Comma-separated list item fields: id, chain, network, address, address_type, name, rank, memo, metadata, balance, unconfirmed_balance, total_balance, currency, status, status_reason, error_count, created_at, updated_at, tags. All by default"
 // @Param X-API-Key header string true "Admin api key"
 // @Success 200 {object} accountModuleDto.GetAccountResponseDto
 // @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
@@ -28,20 +48,287 @@ func GetAccounts(c *gin.Context) {
 	if err != nil {
 		return
 	}
-	orderParams, err := orderUtil.GetOrderByParamsSecure(c, dto.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList)
+	orderParams, err := orderUtil.GetOrderByParamsSecure(c, dto.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
 	if err != nil {
 		return
 	}
-	accounts, total := getAccounts(dto.Status, orderParams, dto.Offset, dto.Count, dto.Search)
-	c.JSON(200, accountModuleDto.CreateGetAccountResponseDto(dto.Offset, dto.Count, total, accounts))
+	orderParams = accountModuleDto.GetAccountBalanceKindOrderParams(orderParams, dto.BalanceKind)
+	fields, err := accountModuleDto.GetAccountFieldsSecure(c, dto.Fields, accountModuleDto.AccountFieldList)
+	if err != nil {
+		return
+	}
+	filter := dto.CreateAccountFilter()
+	filter.Fields = accountModuleDto.GetAccountQueryFields(fields, orderParams, nil)
+	if dto.Sort == accountModuleDto.ACCOUNT_SORT_RELEVANCE {
+		accounts, total := getAccountsByRelevance(filter, orderParams, dto.Offset, dto.Count)
+		c.JSON(200, accountModuleDto.CreateGetAccountByRelevanceResponseDto(dto.Offset, dto.Count, total, accounts, fields))
+		return
+	}
+	var cursorValues []interface{}
+	if dto.Cursor != "" {
+		cursorValues, err = orderUtil.GetCursorValuesSecure(c, dto.Cursor, orderParams)
+		if err != nil {
+			return
+		}
+	}
+	accounts, total, nextCursor := getAccounts(filter, orderParams, cursorValues, dto.Offset, dto.Count)
+	c.JSON(200, accountModuleDto.CreateGetAccountResponseDto(dto.Offset, dto.Count, total, accounts, nextCursor, fields))
+}
+
+// ExportAccounts Export accounts
+// @Summary Export accounts
+// @Description Stream all accounts matching the filters as a file. The format is taken from the format param or the Accept header, csv by default
+// @Tags Account
+// @Accept json
+// @Produce text/csv,text/tab-separated-values,application/x-ndjson
+// @Param status query string false "Comma-separated account statuses: Pending, Active, Suspended, Archived, Error" example(Pending,Active)
+// @Param search query string false "Full-text search in name and memo words, address prefix search"
+// @Param balanceMin query string false "Min balance, inclusive decimal, negative for the unconfirmed and total balances only" example(0.001)
+// @Param balanceMax query string false "Max balance, inclusive decimal, negative for the unconfirmed and total balances only" example(10.5)
+// @Param balanceKind query string false "Balance of balanceMin, balanceMax and the balance sort: confirmed, unconfirmed or total. confirmed by default" Enums("confirmed", "unconfirmed", "total")
+// @Param rankMin query int false "Min rank, inclusive" minimum(0) maximum(100)
+// @Param rankMax query int false "Max rank, inclusive" minimum(0) maximum(100)
+// @Param createdFrom query int false "Created at from, inclusive unix time" minimum(0)
+// @Param createdTo query int false "Created at to, inclusive unix time" minimum(0)
+// @Param updatedFrom query int false "Updated at from, inclusive unix time" minimum(0)
+// @Param updatedTo query int false "Updated at to, inclusive unix time" minimum(0)
+// @Param chain query string false "Account chain" Enums("BTC", "LTC", "BCH", "DOGE")
+// @Param network query string false "Address network" Enums("mainnet", "testnet", "signet", "regtest")
+// @Param addressType query string false "Address types: p2pkh, p2sh, p2wpkh, p2wsh, p2tr, bech32 for all SegWit types" Enums("p2pkh", "p2sh", "p2wpkh", "p2wsh", "p2tr", "bech32")
+// @Param tags query string false "Comma-separated tag names" example(exchange,cold)
+// @Param tagMode query string false "Match accounts with any of the tags or with all of them. any by default" Enums("any", "all")
+// @Param meta.path query string false "Metadata filter, meta.crm.id=42 matches the metadata {\"crm\": {\"id\": 42}}. Up to 5 filters, the path is dot-separated names of letters, digits and underscores"
+// @Param orderBy query string false "Comma-separated sort order options (sort fields: id, updated_at, created_at, address, name, rank, balance, unconfirmed_balance, total_balance, status; sort order: ASC,DESC)" default(id ASC)
+// @Param format query string false "Export formats: csv, tsv, ndjson. Taken from the Accept header when empty" Enums("csv", "tsv", "ndjson")
+// @Param fields query string false "Comma-separated exported fields: id, chain, network, address, address_type, name, rank, memo, metadata, balance, unconfirmed_balance, total_balance, currency, status, status_reason, error_count, created_at, updated_at. All by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {file} file
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Router /account/export [get]
+func ExportAccounts(c *gin.Context) {
+	dto, err := accountModuleDto.CreateGetExportAccountsRequestDto(c)
+	if err != nil {
+		return
+	}
+	orderParams, err := orderUtil.GetOrderByParamsSecure(c, dto.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+	if err != nil {
+		return
+	}
+	orderParams = accountModuleDto.GetAccountBalanceKindOrderParams(orderParams, dto.BalanceKind)
+	fields, err := accountModuleDto.GetAccountExportFieldsSecure(c, dto.Fields)
+	if err != nil {
+		return
+	}
+	_ = exportAccounts(c, dto, orderParams, fields)
+}
+
+// GetAccountStats Get account statistics
+// @Summary Get account statistics
+// @Description Get the accounts count and summed balance grouped by status and chain and by chain, a rank histogram,
+// @Description the top accounts by balance of every chain, the zero balance accounts count and the count of accounts
+// @Description which balance was not checked by the cron within staleAfter seconds.
+// @Description All aggregates use the same filters as the account list, balances are exact decimals in the chain currency.
+// @Description The balances of different chains are never summed or ranked together.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param status query string false "Comma-separated account statuses: Pending, Active, Suspended, Archived, Error" example(Pending,Active)
+// @Param search query string false "Full-text search in name and memo words, address prefix search"
+// @Param balanceMin query string false "Min balance, inclusive decimal, negative for the unconfirmed and total balances only" example(0.001)
+// @Param balanceMax query string false "Max balance, inclusive decimal, negative for the unconfirmed and total balances only" example(10.5)
+// @Param balanceKind query string false "Balance of balanceMin, balanceMax and the balance sort: confirmed, unconfirmed or total. confirmed by default" Enums("confirmed", "unconfirmed", "total")
+// @Param rankMin query int false "Min rank, inclusive" minimum(0) maximum(100)
+// @Param rankMax query int false "Max rank, inclusive" minimum(0) maximum(100)
+// @Param createdFrom query int false "Created at from, inclusive unix time" minimum(0)
+// @Param createdTo query int false "Created at to, inclusive unix time" minimum(0)
+// @Param updatedFrom query int false "Updated at from, inclusive unix time" minimum(0)
+// @Param updatedTo query int false "Updated at to, inclusive unix time" minimum(0)
+// @Param chain query string false "Account chain" Enums("BTC", "LTC", "BCH", "DOGE")
+// @Param network query string false "Address network" Enums("mainnet", "testnet", "signet", "regtest")
+// @Param addressType query string false "Address types: p2pkh, p2sh, p2wpkh, p2wsh, p2tr, bech32 for all SegWit types" Enums("p2pkh", "p2sh", "p2wpkh", "p2wsh", "p2tr", "bech32")
+// @Param tags query string false "Comma-separated tag names" example(exchange,cold)
+// @Param tagMode query string false "Match accounts with any of the tags or with all of them. any by default" Enums("any", "all")
+// @Param meta.path query string false "Metadata filter, meta.crm.id=42 matches the metadata {\"crm\": {\"id\": 42}}. Up to 5 filters, the path is dot-separated names of letters, digits and underscores"
+// @Param rankBucketSize query int false "Rank histogram bucket size" minimum(1) maximum(100) default(10)
+// @Param top query int false "Number of top accounts by balance of every chain" minimum(1) maximum(100) default(10)
+// @Param staleAfter query int false "Seconds since the last balance check after which an account is counted as stale" minimum(1) default(3600)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.GetAccountStatsResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Router /account/stats [get]
+func GetAccountStats(c *gin.Context) {
+	dto, err := accountModuleDto.CreateGetAccountStatsRequestDto(c)
+	if err != nil {
+		return
+	}
+	c.JSON(200, getAccountStats(dto))
+}
+
+// GetAccountById Get account by id
+// @Summary Get account by id
+// @Description Get single account by its numeric id
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param fields query string false "Comma-separated fields: id, chain, network, address, address_type, name, rank, memo, metadata, balance, unconfirmed_balance, total_balance, currency, status, status_reason, error_count, created_at, updated_at, tags. All by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Header 200 {string} ETag "Account version for If-Match"
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/{id} [get]
+func GetAccountById(c *gin.Context) {
+	dto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	fieldsDto, err := accountModuleDto.CreateGetAccountFieldsRequestDto(c)
+	if err != nil {
+		return
+	}
+	fields, err := accountModuleDto.GetAccountFieldsSecure(c, fieldsDto.Fields, accountModuleDto.AccountFieldList)
+	if err != nil {
+		return
+	}
+	account, err := getAccountByIdWithFields(c, dto.Id, accountModuleDto.GetAccountQueryFields(fields, nil, []string{"version"}))
+	if err != nil {
+		return
+	}
+	c.Header("ETag", accountModuleDto.CreateAccountETag(account))
+	c.JSON(200, accountModuleDto.CreateAccountDto(account).WithFields(fields))
+}
+
+// GetAccountBalanceHistory Get account balance history
+// @Summary Get account balance history
+// @Description Get the balance changes recorded by the cron, the newest first. With bucket every hour, day or week
+// @Description bucket (UTC, weeks start on Monday) is reduced to its last change. History older than the retention window is pruned.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param from query int false "Changed at from, inclusive unix time" minimum(0)
+// @Param to query int false "Changed at to, inclusive unix time" minimum(0)
+// @Param count query int false "Number of rows" minimum(1) maximum(1000) default(100)
+// @Param bucket query string false "Downsampling bucket, the last change per bucket" Enums("hour", "day", "week")
+// @Param cursor query string false "nextCursor of the previous page, bucket must be the same"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.GetAccountBalanceHistoryResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/{id}/balance-history [get]
+func GetAccountBalanceHistory(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	dto, err := accountModuleDto.CreateGetAccountBalanceHistoryRequestDto(c)
+	if err != nil {
+		return
+	}
+	var cursorValues []interface{}
+	if dto.Cursor != "" {
+		cursorValues, err = orderUtil.GetCursorValuesSecure(c, dto.Cursor, dto.GetOrderParams())
+		if err != nil {
+			return
+		}
+	}
+	responseDto, err := getAccountBalanceHistory(c, idDto.Id, dto, cursorValues)
+	if err != nil {
+		return
+	}
+	c.JSON(200, responseDto)
+}
+
+// GetAccountTransactions Get account transactions
+// @Summary Get account transactions
+// @Description Get the transactions of the account address imported by the cron, the newest first. A mempool transaction
+// @Description has no block height until it is confirmed, then it is updated in place.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param direction query string false "in for the transactions increasing the balance, out for the ones spending from the address" Enums("in", "out")
+// @Param count query int false "Number of rows" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.GetAccountTransactionsResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/{id}/transactions [get]
+func GetAccountTransactions(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	dto, err := accountModuleDto.CreateGetAccountTransactionsRequestDto(c)
+	if err != nil {
+		return
+	}
+	var cursorValues []interface{}
+	if dto.Cursor != "" {
+		cursorValues, err = orderUtil.GetCursorValuesSecure(c, dto.Cursor, dto.GetOrderParams())
+		if err != nil {
+			return
+		}
+	}
+	responseDto, err := getAccountTransactions(c, idDto.Id, dto, cursorValues)
+	if err != nil {
+		return
+	}
+	c.JSON(200, responseDto)
+}
+
+// GetAccountByAddress Get account by address
+// @Summary Get account by address
+// @Description Get single account by its exact address
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param address path string true "Account address"
+// @Param chain query string false "Account chain, BTC by default" Enums("BTC", "LTC", "BCH", "DOGE")
+// @Param network query string false "Address network, mainnet by default" Enums("mainnet", "testnet", "signet", "regtest")
+// @Param fields query string false "Comma-separated fields: id, chain, network, address, address_type, name, rank, memo, metadata, balance, unconfirmed_balance, total_balance, currency, status, status_reason, error_count, created_at, updated_at, tags. All by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Header 200 {string} ETag "Account version for If-Match"
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/by-address/{address} [get]
+func GetAccountByAddress(c *gin.Context) {
+	dto, err := accountModuleDto.CreateGetAccountByAddressRequestDto(c)
+	if err != nil {
+		return
+	}
+	fieldsDto, err := accountModuleDto.CreateGetAccountFieldsRequestDto(c)
+	if err != nil {
+		return
+	}
+	fields, err := accountModuleDto.GetAccountFieldsSecure(c, fieldsDto.Fields, accountModuleDto.AccountFieldList)
+	if err != nil {
+		return
+	}
+	account, err := getAccountByAddressWithFields(c, dto.Chain, dto.Network, dto.Address, accountModuleDto.GetAccountQueryFields(fields, nil, []string{"version"}))
+	if err != nil {
+		return
+	}
+	c.Header("ETag", accountModuleDto.CreateAccountETag(account))
+	c.JSON(200, accountModuleDto.CreateAccountDto(account).WithFields(fields))
 }
 
 // CreateAccount Create new account
 // @Summary Create new account
-// @Description Create new account
+// @Description Create new account. Creating an account with the address of a deleted account restores that account with the new details.
 // @Tags Account
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Retries with the same key get the stored response, it is kept for a day by default"
 // @Param X-API-Key header string true "Admin api key"
 // @Param request body accountModuleDto.PostCreateAccountRequestDto true "Request body"
 // @Param request.name body string true "Account name (1-255 characters)"
@@ -51,6 +338,7 @@ func GetAccounts(c *gin.Context) {
 // @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
 // @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
 // @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Failure 422 {object} errorHelpers.ResponseUnprocessableEntityErrorHTTP{}
 // @Router /account [post]
 func CreateAccount(c *gin.Context) {
 	dto, err := accountModuleDto.CreatePostCreateAccountRequestDto(c)
@@ -63,3 +351,276 @@ func CreateAccount(c *gin.Context) {
 	}
 	c.JSON(200, accountModuleDto.CreatePostCreateAccountResponseDto(account))
 }
+
+// UpdateAccount Partially update account
+// @Summary Partially update account
+// @Description Update name, rank, memo, metadata or status using JSON merge-patch semantics. Omitted fields stay unchanged, "memo": null clears the memo.
+// @Description Metadata is merged into the stored object the same way, "metadata": null clears it.
+// @Description A status change follows the account lifecycle like the transition endpoint and clears the status reason.
+// @Description Send the ETag from a previous read in If-Match to reject the write when the account has changed since.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Param If-Match header string false "ETag of the account version being modified"
+// @Param request body accountModuleDto.PatchUpdateAccountRequestDto true "Request body"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Header 200 {string} ETag "New account version"
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Failure 412 {object} errorHelpers.ResponsePreconditionFailedErrorHTTP{}
+// @Router /account/{id} [patch]
+func UpdateAccount(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	dto, err := accountModuleDto.CreatePatchUpdateAccountRequestDto(c)
+	if err != nil {
+		return
+	}
+	account, err := updateAccount(c, idDto.Id, c.GetHeader("If-Match"), dto)
+	if err != nil {
+		return
+	}
+	c.Header("ETag", accountModuleDto.CreateAccountETag(account))
+	c.JSON(200, accountModuleDto.CreateAccountDto(account))
+}
+
+// CreateAccountsBulk Create accounts in bulk
+// @Summary Create accounts in bulk
+// @Description Create up to BULK_ACCOUNT_MAX accounts at once. Every item is validated like POST /account and gets its own result.
+// @Description In atomic mode all items are created in a single transaction, so one invalid or conflicting item cancels the whole request and the valid items are reported as skipped.
+// @Description In bestEffort mode every valid item is created on its own.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Retries with the same key get the stored response, it is kept for a day by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Param request body accountModuleDto.PostCreateAccountsBulkRequestDto true "Request body"
+// @Success 200 {object} accountModuleDto.PostCreateAccountsBulkResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 422 {object} errorHelpers.ResponseUnprocessableEntityErrorHTTP{}
+// @Router /account/bulk [post]
+func CreateAccountsBulk(c *gin.Context) {
+	dto, err := accountModuleDto.CreatePostCreateAccountsBulkRequestDto(c)
+	if err != nil {
+		return
+	}
+	results, err := createAccountsBulk(c, dto)
+	if err != nil {
+		return
+	}
+	c.JSON(200, accountModuleDto.CreatePostCreateAccountsBulkResponseDto(dto.Mode, results))
+}
+
+// ImportAccounts Import accounts from CSV or NDJSON file
+// @Summary Import accounts from CSV or NDJSON file
+// @Description Stream a CSV (header row required) or NDJSON file with the columns chain, network, address, name, rank, memo, status and metadata. The chain is BTC and the network is mainnet when they are empty.
+// @Description The metadata is a JSON object, in CSV the cell holds its JSON text. An update replaces the stored metadata only when the row has it.
+// @Description The file is sent as the request body or as the "file" part of a multipart form. Rows are validated like POST /account.
+// @Description With dryRun=true nothing is written and the report shows the rows which would be created, updated, skipped or rejected.
+// @Description The report lists up to IMPORT_REPORT_ROW_MAX rows, the counts cover the whole file.
+// @Description A file which breaks off is not rolled back, the report has aborted=true and the line the read stopped at, the rows before it are imported.
+// @Description The import does not support the Idempotency-Key header, a request with it gets 400. Re-sending a file with onExisting=skip does not create the imported rows twice.
+// @Tags Account
+// @Accept text/csv,application/x-ndjson,multipart/form-data
+// @Produce json
+// @Param format query string false "File format, detected from the content type or file name by default" Enums("csv", "ndjson")
+// @Param dryRun query bool false "Only report what would be done" default(false)
+// @Param onExisting query string false "What to do with addresses which already exist" Enums("skip", "update") default("skip")
+// @Param X-API-Key header string true "Admin api key"
+// @Param file formData file false "Import file for multipart requests"
+// @Success 200 {object} accountModuleDto.PostImportAccountsResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Router /account/import [post]
+func ImportAccounts(c *gin.Context) {
+	dto, err := accountModuleDto.CreatePostImportAccountsRequestDto(c)
+	if err != nil {
+		return
+	}
+	file, contentType, fileName, err := accountModuleDto.GetAccountImportFile(c)
+	if err != nil {
+		return
+	}
+	format := accountModuleDto.GetAccountImportFormat(dto.Format, contentType, fileName)
+	reader, err := accountModuleDto.NewAccountImportRowReader(format, file)
+	if err != nil {
+		_ = errorHelpers.RespondBadRequestError(c, err.Error())
+		return
+	}
+	report, err := importAccounts(c, dto, reader)
+	if err != nil {
+		return
+	}
+	c.JSON(200, report)
+}
+
+// DeleteAccount Soft delete account
+// @Summary Soft delete account
+// @Description Mark account as deleted. Deleted accounts are hidden from every read and are not polled by the cron.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} dto.SuccessDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/{id} [delete]
+func DeleteAccount(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	if err := deleteAccount(c, idDto.Id); err != nil {
+		return
+	}
+	c.JSON(200, dto.CreateSuccessDto())
+}
+
+// RestoreAccount Restore deleted account
+// @Summary Restore deleted account
+// @Description Restore soft deleted account
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param Idempotency-Key header string false "Retries with the same key get the stored response, it is kept for a day by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Failure 422 {object} errorHelpers.ResponseUnprocessableEntityErrorHTTP{}
+// @Router /account/{id}/restore [post]
+func RestoreAccount(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	account, err := restoreAccount(c, idDto.Id)
+	if err != nil {
+		return
+	}
+	c.JSON(200, accountModuleDto.CreateAccountDto(account))
+}
+
+// TransitionAccount Change account status
+// @Summary Change account status
+// @Description Move the account through its lifecycle and record the reason. Pending moves to Active, Suspended, Archived or Error;
+// @Description Active to Suspended, Archived or Error; Suspended to Active or Archived; Archived to Active; Error to Pending, Suspended or Archived.
+// @Description The cron updates the balances of Pending and Active accounts only.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param Idempotency-Key header string false "Retries with the same key get the stored response, it is kept for a day by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Param request body accountModuleDto.PostTransitionAccountRequestDto true "Request body"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Failure 422 {object} errorHelpers.ResponseUnprocessableEntityErrorHTTP{}
+// @Router /account/{id}/transition [post]
+func TransitionAccount(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	dto, err := accountModuleDto.CreatePostTransitionAccountRequestDto(c)
+	if err != nil {
+		return
+	}
+	account, err := transitionAccount(c, idDto.Id, dto)
+	if err != nil {
+		return
+	}
+	c.JSON(200, accountModuleDto.CreateAccountDto(account))
+}
+
+// PurgeAccount Permanently remove deleted account
+// @Summary Permanently remove deleted account
+// @Description Hard delete an account which was soft deleted before. Admin only.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} dto.SuccessDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Router /account/{id}/purge [delete]
+func PurgeAccount(c *gin.Context) {
+	idDto, err := accountModuleDto.CreateGetAccountByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	if err := purgeAccount(c, idDto.Id); err != nil {
+		return
+	}
+	c.JSON(200, dto.CreateSuccessDto())
+}
+
+// AttachAccountTag Attach tag to account
+// @Summary Attach tag to account
+// @Description Attach existing tag to account. Attaching an already attached tag changes nothing.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param tagId path int true "Tag id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/{id}/tag/{tagId} [put]
+func AttachAccountTag(c *gin.Context) {
+	dto, err := accountModuleDto.CreateAccountTagRequestDto(c)
+	if err != nil {
+		return
+	}
+	account, err := attachAccountTag(c, dto.Id, dto.TagId)
+	if err != nil {
+		return
+	}
+	c.JSON(200, accountModuleDto.CreateAccountDto(account))
+}
+
+// DetachAccountTag Detach tag from account
+// @Summary Detach tag from account
+// @Description Detach tag from account. Detaching a tag that is not attached changes nothing.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param id path int true "Account id" minimum(1)
+// @Param tagId path int true "Tag id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} accountModuleDto.AccountDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /account/{id}/tag/{tagId} [delete]
+func DetachAccountTag(c *gin.Context) {
+	dto, err := accountModuleDto.CreateAccountTagRequestDto(c)
+	if err != nil {
+		return
+	}
+	account, err := detachAccountTag(c, dto.Id, dto.TagId)
+	if err != nil {
+		return
+	}
+	c.JSON(200, accountModuleDto.CreateAccountDto(account))
+}
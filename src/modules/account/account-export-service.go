@@ -0,0 +1,70 @@
+package accountModule
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/logger"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+
+	"github.com/gin-gonic/gin"
+)
+
+const accountExportFlushRowCount = 500
+
+// exportAccounts streams matching accounts straight from the DB cursor to the response,
+// only one row is held in memory at a time
+func exportAccounts(c *gin.Context, dto accountModuleDto.GetExportAccountsRequestDto, orderParams []orderUtil.OrderParam, fields []string) error {
+	filter := dto.CreateAccountFilter()
+	filter.Fields = accountModuleDto.GetAccountQueryFields(fields, orderParams, nil)
+	rows, err := database.GetAccountsRows(filter, orderParams)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	fileName := fmt.Sprintf("accounts-%d.%s", time.Now().Unix(), dto.Format)
+	c.Header("Content-Type", accountModuleDto.AccountExportFormatContentType[dto.Format]+"; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	writer := accountModuleDto.NewAccountExportWriter(dto.Format, c.Writer, fields)
+	if err := writer.WriteHeader(); err != nil {
+		return logAccountExportError(err)
+	}
+	rowCount := 0
+	for rows.Next() {
+		account, err := database.ScanAccountRow(rows)
+		if err != nil {
+			return logAccountExportError(err)
+		}
+		if err := writer.Write(accountModuleDto.CreateAccountDto(account)); err != nil {
+			return logAccountExportError(err)
+		}
+		rowCount++
+		if rowCount%accountExportFlushRowCount == 0 {
+			if err := writer.Flush(); err != nil {
+				return logAccountExportError(err)
+			}
+			c.Writer.Flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return logAccountExportError(err)
+	}
+	if err := writer.Flush(); err != nil {
+		return logAccountExportError(err)
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// logAccountExportError reports an error after the response status is already sent, so it can only be logged
+func logAccountExportError(err error) error {
+	logger.Logger.Error().Msg(fmt.Sprintf("Export accounts error. %s", err.Error()))
+	return err
+}
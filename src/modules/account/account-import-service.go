@@ -0,0 +1,192 @@
+package accountModule
+
+import (
+	"errors"
+	auditContext "go-gin-test-job/src/common/audit-context"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"hash/fnv"
+	"io"
+	"maps"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Rows are looked up in the database in chunks to keep memory flat for large files
+const accountImportChunkSize = 500
+
+// accountImporter keeps the hashes of the imported addresses to reject the duplicates in the file,
+// 8 bytes per row instead of the address, the report lists a limited number of rows
+type accountImporter struct {
+	dto              accountModuleDto.PostImportAccountsRequestDto
+	audit            database.AuditContext
+	report           *accountModuleDto.PostImportAccountsResponseDto
+	importAddresses  map[uint64]struct{}
+	pendingRows      []*accountModuleDto.AccountImportRow
+	pendingAddresses []string
+}
+
+func importAccounts(c *gin.Context, dto accountModuleDto.PostImportAccountsRequestDto, reader accountModuleDto.AccountImportRowReader) (*accountModuleDto.PostImportAccountsResponseDto, error) {
+	importer := &accountImporter{
+		dto:             dto,
+		audit:           auditContext.Get(c),
+		report:          accountModuleDto.CreatePostImportAccountsResponseDto(dto),
+		importAddresses: make(map[uint64]struct{}),
+	}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var readError *accountModuleDto.AccountImportReadError
+		if errors.As(err, &readError) {
+			// The rows before the broken line are imported all the same, the report shows where the file stopped
+			if err := importer.flush(); err != nil {
+				return nil, errorHelpers.RespondInternalError(c, err.Error())
+			}
+			importer.report.Abort(readError.Line, readError.Error())
+			return importer.report, nil
+		}
+		if err != nil {
+			return nil, errorHelpers.RespondBadRequestError(c, "Read import file error. "+err.Error())
+		}
+		importer.addRow(row)
+		if len(importer.pendingRows) >= accountImportChunkSize {
+			if err := importer.flush(); err != nil {
+				return nil, errorHelpers.RespondInternalError(c, err.Error())
+			}
+		}
+	}
+	if err := importer.flush(); err != nil {
+		return nil, errorHelpers.RespondInternalError(c, err.Error())
+	}
+	return importer.report, nil
+}
+
+// addRow rejects the row right away or queues it for the database lookup
+func (i *accountImporter) addRow(row *accountModuleDto.AccountImportRow) {
+	errorMessage := row.ErrorMessage
+	if errorMessage == "" {
+		errorMessage = accountModuleDto.GetPostCreateAccountRequestDtoErrorMessage(&row.Dto)
+	}
+	addressHash := getAccountAddressHash(row.Dto.Chain, row.Dto.Network, row.Dto.Address)
+	if _, exists := i.importAddresses[addressHash]; errorMessage == "" && exists {
+		errorMessage = "Duplicate address in file"
+	}
+	if errorMessage != "" {
+		i.report.AddRow(accountModuleDto.PostImportAccountsRowResultDto{
+			Line:    row.Line,
+			Address: row.Dto.Address,
+			Result:  accountModuleDto.AccountImportRowResultRejected,
+			Message: errorMessage,
+		})
+		return
+	}
+	i.importAddresses[addressHash] = struct{}{}
+	i.pendingRows = append(i.pendingRows, row)
+	i.pendingAddresses = append(i.pendingAddresses, row.Dto.Address)
+}
+
+func getAccountAddressHash(chain entities.AccountChain, network entities.AccountNetwork, address string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(getAccountAddressKey(chain, network, address)))
+	return hash.Sum64()
+}
+
+func (i *accountImporter) flush() error {
+	if len(i.pendingRows) == 0 {
+		return nil
+	}
+	existingAccounts := make(map[string]*entities.Account)
+	for _, account := range database.GetAccountsByAddresses(i.pendingAddresses) {
+		existingAccounts[getAccountAddressKey(account.Chain, account.Network, account.Address)] = account
+	}
+	for _, row := range i.pendingRows {
+		result, err := i.importRow(row, existingAccounts[getAccountAddressKey(row.Dto.Chain, row.Dto.Network, row.Dto.Address)])
+		if err != nil {
+			return err
+		}
+		i.report.AddRow(result)
+	}
+	i.pendingRows = i.pendingRows[:0]
+	i.pendingAddresses = i.pendingAddresses[:0]
+	return nil
+}
+
+func (i *accountImporter) importRow(row *accountModuleDto.AccountImportRow, existingAccount *entities.Account) (accountModuleDto.PostImportAccountsRowResultDto, error) {
+	result := accountModuleDto.PostImportAccountsRowResultDto{Line: row.Line, Address: row.Dto.Address}
+	if existingAccount == nil {
+		result.Result = accountModuleDto.AccountImportRowResultCreated
+		if i.dto.DryRun {
+			return result, nil
+		}
+		transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+			_, _, err := createAccountTx(tx, i.audit, row.Dto)
+			return err
+		}, database.DefaultTxOptions)
+		if errors.Is(transactionError, errAddressExists) {
+			// Created concurrently after the lookup
+			result.Result = accountModuleDto.AccountImportRowResultSkipped
+			result.Message = errAddressExists.Error()
+			return result, nil
+		}
+		return result, transactionError
+	}
+	if i.dto.OnExisting == accountModuleDto.AccountImportOnExistingSkip {
+		result.Result = accountModuleDto.AccountImportRowResultSkipped
+		result.Message = errAddressExists.Error()
+		return result, nil
+	}
+	result.Changes = accountModuleDto.CreateAccountImportChanges(existingAccount, row.Dto)
+	if len(result.Changes) == 0 {
+		result.Result = accountModuleDto.AccountImportRowResultSkipped
+		result.Message = "No changes"
+		return result, nil
+	}
+	if row.Dto.Status != existingAccount.Status {
+		if err := getAccountStatusTransitionError(existingAccount, row.Dto.Status); err != nil {
+			result.Result = accountModuleDto.AccountImportRowResultRejected
+			result.Message = err.Error()
+			result.Changes = nil
+			return result, nil
+		}
+	}
+	result.Result = accountModuleDto.AccountImportRowResultUpdated
+	if i.dto.DryRun {
+		return result, nil
+	}
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdForUpdate(tx, existingAccount.Id)
+		if account == nil {
+			// Deleted concurrently after the lookup
+			result.Result = accountModuleDto.AccountImportRowResultSkipped
+			result.Message = "Account not found"
+			result.Changes = nil
+			return nil
+		}
+		isStatusChanged := row.Dto.Status != account.Status
+		if isStatusChanged {
+			if err := getAccountStatusTransitionError(account, row.Dto.Status); err != nil {
+				// Status changed concurrently after the lookup
+				result.Result = accountModuleDto.AccountImportRowResultRejected
+				result.Message = err.Error()
+				result.Changes = nil
+				return nil
+			}
+		}
+		updateData := account.UpdateName(row.Dto.Name)
+		maps.Copy(updateData, account.UpdateRank(row.Dto.Rank))
+		maps.Copy(updateData, account.UpdateMemo(&row.Dto.Memo))
+		if row.Dto.IsMetadataSet() {
+			maps.Copy(updateData, account.UpdateMetadata(row.Dto.GetMetadata()))
+		}
+		if isStatusChanged {
+			maps.Copy(updateData, account.Transition(row.Dto.Status, ""))
+		}
+		return database.UpdateAccount(tx, i.audit, entities.AuditActionUpdate, account, updateData)
+	}, database.DefaultTxOptions)
+	return result, transactionError
+}
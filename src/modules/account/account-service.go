@@ -1,35 +1,373 @@
 package accountModule
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+
+	auditContext "go-gin-test-job/src/common/audit-context"
 	errorHelpers "go-gin-test-job/src/common/error-helpers"
 	"go-gin-test-job/src/database"
 	"go-gin-test-job/src/database/entities"
 	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	jsonUtil "go-gin-test-job/src/utils/json"
+	metadataValidationUtil "go-gin-test-job/src/utils/metadata-validation"
+	orderUtil "go-gin-test-job/src/utils/order"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func getAccounts(status entities.AccountStatus, orderParams map[string]string, offset int, count int, search string) ([]*entities.Account, int64) {
-	return database.GetAccountsAndTotal(status, orderParams, offset, count, search)
+// getAccounts reads one extra row to find out whether the next page cursor is needed
+func getAccounts(filter database.AccountFilter, orderParams []orderUtil.OrderParam, cursorValues []interface{}, offset int, count int) ([]*entities.Account, int64, *string) {
+	var accounts []*entities.Account
+	var total int64
+	if cursorValues != nil {
+		accounts, total = database.GetAccountsAfterCursorAndTotal(filter, orderParams, cursorValues, count+1)
+	} else {
+		accounts, total = database.GetAccountsAndTotal(filter, orderParams, offset, count+1)
+	}
+	if len(accounts) <= count {
+		return accounts, total, nil
+	}
+	accounts = accounts[:count]
+	nextCursor := orderUtil.EncodeCursor(orderParams, accountModuleDto.GetAccountSortValues(accounts[count-1], orderParams))
+	return accounts, total, &nextCursor
+}
+
+func getAccountsByRelevance(filter database.AccountFilter, orderParams []orderUtil.OrderParam, offset int, count int) ([]*database.AccountWithScore, int64) {
+	return database.GetAccountsByRelevanceAndTotal(filter, orderParams, offset, count)
+}
+
+func getAccountById(c *gin.Context, id int64) (*entities.Account, error) {
+	return getAccountByIdWithFields(c, id, nil)
+}
+
+// getAccountByIdWithFields reads only the given fields, nil reads all of them
+func getAccountByIdWithFields(c *gin.Context, id int64, fields []string) (*entities.Account, error) {
+	account := database.GetAccountByIdWithFields(id, fields)
+	if account == nil {
+		return nil, errorHelpers.RespondNotFoundError(c, "Account not found")
+	}
+	return account, nil
+}
+
+// getAccountByAddressWithFields reads only the given fields, nil reads all of them
+func getAccountByAddressWithFields(c *gin.Context, chain entities.AccountChain, network entities.AccountNetwork, address string, fields []string) (*entities.Account, error) {
+	account := database.GetAccountByAddressWithFields(chain, network, address, fields)
+	if account == nil {
+		return nil, errorHelpers.RespondNotFoundError(c, "Account not found")
+	}
+	return account, nil
+}
+
+// getAccountAddressKey identifies an address across the chain networks, an address is unique within its chain network only
+func getAccountAddressKey(chain entities.AccountChain, network entities.AccountNetwork, address string) string {
+	return string(chain) + "/" + string(network) + "/" + address
+}
+
+var errAddressExists = errors.New("Address already exists")
+var errBulkItemFailed = errors.New("Bulk item failed")
+
+// getAccountStatusTransitionError checks the account lifecycle allows the status change, nil when it does
+func getAccountStatusTransitionError(account *entities.Account, status entities.AccountStatus) error {
+	if account.Status.CanTransitionTo(status) {
+		return nil
+	}
+	return fmt.Errorf("Account status can not change from %s to %s", account.Status, status)
 }
 
 func createAccount(c *gin.Context, dto accountModuleDto.PostCreateAccountRequestDto) (*entities.Account, error) {
 	var account *entities.Account
+	isRestored := false
 	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
-		if database.IsAddressExists(tx, dto.Address) {
-			return errorHelpers.RespondConflictError(c, "Address already exists")
-		}
-		newAccount := entities.CreateAccount(dto.Address, dto.Status, dto.Name, dto.Rank, dto.Memo)
 		var err error
-		account, err = database.CreateAccount(tx, newAccount)
+		account, isRestored, err = createAccountTx(tx, auditContext.Get(c), dto)
+		return err
+	}, database.DefaultTxOptions)
+	if errors.Is(transactionError, errAddressExists) {
+		return nil, errorHelpers.RespondConflictError(c, errAddressExists.Error())
+	}
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	if isRestored {
+		return getAccountById(c, account.Id)
+	}
+	return account, nil
+}
+
+// createAccountTx creates the account inside the transaction, it fails with errAddressExists for a taken address
+func createAccountTx(tx *gorm.DB, audit database.AuditContext, dto accountModuleDto.PostCreateAccountRequestDto) (*entities.Account, bool, error) {
+	existingAccount := database.GetAccountByAddressWithDeletedForUpdate(tx, dto.Chain, dto.Network, dto.Address)
+	if existingAccount != nil && !existingAccount.IsDeleted() {
+		return nil, false, errAddressExists
+	}
+	if existingAccount != nil {
+		// Re-creating a deleted address restores the old row with the new details
+		account := existingAccount
+		updateData := account.Restore()
+		maps.Copy(updateData, account.UpdateName(dto.Name))
+		maps.Copy(updateData, account.UpdateRank(dto.Rank))
+		maps.Copy(updateData, account.UpdateMemo(&dto.Memo))
+		maps.Copy(updateData, account.UpdateMetadata(dto.GetMetadata()))
+		maps.Copy(updateData, account.UpdateStatus(dto.Status))
+		if err := database.UpdateAccountWithDeleted(tx, audit, entities.AuditActionRestore, account, updateData); err != nil {
+			return nil, false, err
+		}
+		return account, true, nil
+	}
+	newAccount := entities.CreateAccount(dto.Chain, dto.Network, dto.Address, dto.Status, dto.Name, dto.Rank, dto.Memo)
+	newAccount.Metadata = dto.GetMetadata()
+	account, err := database.CreateAccount(tx, audit, newAccount)
+	if err != nil {
+		return nil, false, err
+	}
+	return account, false, nil
+}
+
+func createAccountsBulk(c *gin.Context, dto accountModuleDto.PostCreateAccountsBulkRequestDto) ([]accountModuleDto.PostCreateAccountsBulkItemResultDto, error) {
+	results := make([]accountModuleDto.PostCreateAccountsBulkItemResultDto, len(dto.Items))
+	validIndexes := make([]int, 0, len(dto.Items))
+	requestAddresses := make(map[string]bool)
+	for index := range dto.Items {
+		item := &dto.Items[index]
+		results[index] = accountModuleDto.PostCreateAccountsBulkItemResultDto{Index: index, Address: item.Address}
+		if errorMessage := accountModuleDto.GetPostCreateAccountRequestDtoErrorMessage(item); errorMessage != "" {
+			results[index].Status = accountModuleDto.AccountBulkItemStatusInvalid
+			results[index].Message = errorMessage
+			continue
+		}
+		addressKey := getAccountAddressKey(item.Chain, item.Network, item.Address)
+		if requestAddresses[addressKey] {
+			results[index].Status = accountModuleDto.AccountBulkItemStatusConflict
+			results[index].Message = "Duplicate address in request"
+			continue
+		}
+		requestAddresses[addressKey] = true
+		validIndexes = append(validIndexes, index)
+	}
+	audit := auditContext.Get(c)
+	accounts := make(map[int]*entities.Account)
+	restoredIndexes := make([]int, 0)
+	createItem := func(tx *gorm.DB, index int) error {
+		account, isRestored, err := createAccountTx(tx, audit, dto.Items[index])
+		if errors.Is(err, errAddressExists) {
+			results[index].Status = accountModuleDto.AccountBulkItemStatusConflict
+			results[index].Message = err.Error()
+			return err
+		}
 		if err != nil {
 			return err
 		}
+		accounts[index] = account
+		if isRestored {
+			restoredIndexes = append(restoredIndexes, index)
+		}
 		return nil
+	}
+	if dto.Mode == accountModuleDto.AccountBulkModeAtomic {
+		transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+			isFailed := len(validIndexes) != len(dto.Items)
+			// Keep going after a conflict so every conflicting item is reported
+			for _, index := range validIndexes {
+				err := createItem(tx, index)
+				if errors.Is(err, errAddressExists) {
+					isFailed = true
+				} else if err != nil {
+					return err
+				}
+			}
+			if isFailed {
+				return errBulkItemFailed
+			}
+			return nil
+		}, database.DefaultTxOptions)
+		if transactionError != nil && !errors.Is(transactionError, errBulkItemFailed) {
+			return nil, errorHelpers.RespondInternalError(c, transactionError.Error())
+		}
+		if transactionError != nil {
+			// Nothing was written, valid items are reported as skipped
+			for index := range results {
+				if results[index].Status == "" {
+					results[index].Status = accountModuleDto.AccountBulkItemStatusSkipped
+					results[index].Message = "Not created because another item failed"
+				}
+			}
+			return results, nil
+		}
+	} else {
+		for _, index := range validIndexes {
+			transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+				return createItem(tx, index)
+			}, database.DefaultTxOptions)
+			if transactionError != nil && !errors.Is(transactionError, errAddressExists) {
+				return nil, errorHelpers.RespondInternalError(c, transactionError.Error())
+			}
+		}
+	}
+	// Restored rows are reloaded to pick up the values set by the database triggers
+	for _, index := range restoredIndexes {
+		accounts[index] = database.GetAccountById(accounts[index].Id)
+	}
+	for index, account := range accounts {
+		accountDto := accountModuleDto.CreateAccountDto(account)
+		results[index].Status = accountModuleDto.AccountBulkItemStatusCreated
+		results[index].Account = &accountDto
+	}
+	return results, nil
+}
+
+func updateAccount(c *gin.Context, id int64, ifMatch string, dto accountModuleDto.PatchUpdateAccountRequestDto) (*entities.Account, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		if ifMatch != "" && !accountModuleDto.IsAccountETagMatch(account, ifMatch) {
+			return errorHelpers.RespondPreconditionFailedError(c, "Account has been modified")
+		}
+		updateData := make(map[string]interface{})
+		if dto.Name != nil {
+			maps.Copy(updateData, account.UpdateName(*dto.Name))
+		}
+		if dto.Rank != nil {
+			maps.Copy(updateData, account.UpdateRank(*dto.Rank))
+		}
+		if dto.IsMemoSet {
+			maps.Copy(updateData, account.UpdateMemo(dto.Memo))
+		}
+		if dto.IsMetadataSet {
+			metadata, err := mergeAccountMetadata(account.Metadata, dto.GetMetadataPatch())
+			if err != nil {
+				return errorHelpers.RespondBadRequestError(c, err.Error())
+			}
+			maps.Copy(updateData, account.UpdateMetadata(metadata))
+		}
+		if dto.Status != nil && *dto.Status != account.Status {
+			if err := getAccountStatusTransitionError(account, *dto.Status); err != nil {
+				return errorHelpers.RespondConflictError(c, err.Error())
+			}
+			maps.Copy(updateData, account.Transition(*dto.Status, ""))
+		}
+		if len(updateData) == 0 {
+			return nil
+		}
+		return database.UpdateAccount(tx, auditContext.Get(c), entities.AuditActionUpdate, account, updateData)
 	}, database.DefaultTxOptions)
 	if transactionError != nil {
 		return nil, transactionError
 	}
-	return account, nil
+	// Reload to pick up the updated_at set by the database trigger
+	return getAccountById(c, id)
+}
+
+func transitionAccount(c *gin.Context, id int64, dto accountModuleDto.PostTransitionAccountRequestDto) (*entities.Account, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		if account.Status == dto.Status {
+			return errorHelpers.RespondConflictError(c, fmt.Sprintf("Account status is already %s", account.Status))
+		}
+		if err := getAccountStatusTransitionError(account, dto.Status); err != nil {
+			return errorHelpers.RespondConflictError(c, err.Error())
+		}
+		return database.UpdateAccount(tx, auditContext.Get(c), entities.AuditActionTransition, account, account.Transition(dto.Status, dto.Reason))
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getAccountById(c, id)
+}
+
+// mergeAccountMetadata applies the metadata merge patch, a nil patch clears the metadata.
+// The merged object is checked against the limits again, it can outgrow both documents
+func mergeAccountMetadata(metadata *string, patch *string) (*string, error) {
+	if patch == nil {
+		return nil, nil
+	}
+	var target []byte
+	if metadata != nil {
+		target = []byte(*metadata)
+	}
+	merged, err := jsonUtil.MergePatch(target, []byte(*patch))
+	if err != nil || !metadataValidationUtil.IsValidMetadata(merged) {
+		return nil, errors.New(accountModuleDto.AccountMetadataErrorMessage("Metadata"))
+	}
+	value := string(merged)
+	return &value, nil
+}
+
+func deleteAccount(c *gin.Context, id int64) error {
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		return database.UpdateAccountWithDeleted(tx, auditContext.Get(c), entities.AuditActionDelete, account, account.MarkDeleted())
+	}, database.DefaultTxOptions)
+}
+
+func restoreAccount(c *gin.Context, id int64) (*entities.Account, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdWithDeletedForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		if !account.IsDeleted() {
+			return errorHelpers.RespondConflictError(c, "Account is not deleted")
+		}
+		return database.UpdateAccountWithDeleted(tx, auditContext.Get(c), entities.AuditActionRestore, account, account.Restore())
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getAccountById(c, id)
+}
+
+func purgeAccount(c *gin.Context, id int64) error {
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdWithDeletedForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		if !account.IsDeleted() {
+			return errorHelpers.RespondConflictError(c, "Account must be deleted before purge")
+		}
+		return database.PurgeAccount(tx, auditContext.Get(c), account)
+	}, database.DefaultTxOptions)
+}
+
+// attachAccountTag links the tag to the account, attaching an already attached tag is not an error
+func attachAccountTag(c *gin.Context, id int64, tagId int64) (*entities.Account, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		if database.GetTagByIdForUpdate(tx, tagId) == nil {
+			return errorHelpers.RespondNotFoundError(c, "Tag not found")
+		}
+		return database.AttachAccountTag(tx, auditContext.Get(c), account.Id, tagId)
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getAccountById(c, id)
+}
+
+func detachAccountTag(c *gin.Context, id int64, tagId int64) (*entities.Account, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		account := database.GetAccountByIdForUpdate(tx, id)
+		if account == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		return database.DetachAccountTag(tx, auditContext.Get(c), account.Id, tagId)
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getAccountById(c, id)
 }
@@ -0,0 +1,23 @@
+package accountModule
+
+import (
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	timeUtil "go-gin-test-job/src/utils/time"
+)
+
+// getAccountStats runs every aggregate with the same filter, the accounts which balance was not checked
+// within staleAfter seconds are counted as not refreshed by the cron. The top accounts are ranked per chain
+func getAccountStats(dto accountModuleDto.GetAccountStatsRequestDto) accountModuleDto.GetAccountStatsResponseDto {
+	filter := dto.CreateAccountFilter()
+	statusStats := database.GetAccountsStatusStats(filter)
+	chainStats := database.GetAccountsChainStats(filter)
+	rankBuckets := database.GetAccountsRankHistogram(filter, dto.RankBucketSize)
+	topAccounts := make(map[entities.AccountChain][]*entities.Account)
+	for _, stats := range chainStats {
+		topAccounts[stats.Chain] = database.GetAccountsTopByBalance(filter, stats.Chain, dto.Top)
+	}
+	countStats := database.GetAccountsCountStats(filter, timeUtil.GetUnixTime()-dto.StaleAfter)
+	return accountModuleDto.CreateGetAccountStatsResponseDto(statusStats, chainStats, rankBuckets, dto.RankBucketSize, topAccounts, countStats, dto.StaleAfter)
+}
@@ -0,0 +1,25 @@
+package accountModule
+
+import (
+	"go-gin-test-job/src/database"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getAccountTransactions reads one row more than requested to know if there is a next page
+func getAccountTransactions(c *gin.Context, id int64, dto accountModuleDto.GetAccountTransactionsRequestDto, cursorValues []interface{}) (accountModuleDto.GetAccountTransactionsResponseDto, error) {
+	account, err := getAccountById(c, id)
+	if err != nil {
+		return accountModuleDto.GetAccountTransactionsResponseDto{}, err
+	}
+	transactions := database.GetTransactions(dto.CreateTransactionFilter(account.Id), cursorValues, dto.Count+1)
+	if len(transactions) <= dto.Count {
+		return accountModuleDto.CreateGetAccountTransactionsResponseDto(transactions, nil), nil
+	}
+	transactions = transactions[:dto.Count]
+	lastItem := transactions[dto.Count-1]
+	nextCursor := orderUtil.EncodeCursor(dto.GetOrderParams(), []interface{}{lastItem.Time, lastItem.Id})
+	return accountModuleDto.CreateGetAccountTransactionsResponseDto(transactions, &nextCursor), nil
+}
@@ -0,0 +1,57 @@
+package accountModuleDto
+
+import (
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+)
+
+type AccountBalanceHistoryDto struct {
+	Id         int64  `json:"id" example:"1"`
+	OldBalance string `json:"old_balance" example:"0.5"`
+	NewBalance string `json:"new_balance" example:"0.75"`
+	Delta      string `json:"delta" example:"0.25"`
+	Source     string `json:"source" example:"bitcore"`
+	CreatedAt  int64  `json:"created_at" example:"1600000000"`
+	// BucketStart is set for the bucketed history, the row is the last change of the bucket
+	BucketStart *int64 `json:"bucket_start,omitempty" example:"1599955200"`
+}
+
+func CreateAccountBalanceHistoryDto(history *entities.AccountBalanceHistory) AccountBalanceHistoryDto {
+	return AccountBalanceHistoryDto{
+		Id:         history.Id,
+		OldBalance: history.OldBalance.String(),
+		NewBalance: history.NewBalance.String(),
+		Delta:      history.Delta.String(),
+		Source:     history.Source,
+		CreatedAt:  history.CreatedAt,
+	}
+}
+
+type GetAccountBalanceHistoryResponseDto struct {
+	List []AccountBalanceHistoryDto `json:"list"`
+	// NextCursor is set when there are more rows after the list, pass it as cursor to get them
+	NextCursor *string `json:"nextCursor" example:"eyJvIjoiZGF5IERFU0MiLCJ2IjpbIjE3MDAwMDAwMDAiXX0"`
+}
+
+func CreateGetAccountBalanceHistoryResponseDto(history []*entities.AccountBalanceHistory, nextCursor *string) GetAccountBalanceHistoryResponseDto {
+	var dto GetAccountBalanceHistoryResponseDto
+	dto.NextCursor = nextCursor
+	dto.List = make([]AccountBalanceHistoryDto, 0)
+	for _, item := range history {
+		dto.List = append(dto.List, CreateAccountBalanceHistoryDto(item))
+	}
+	return dto
+}
+
+func CreateGetAccountBalanceHistoryBucketsResponseDto(rows []*database.AccountBalanceHistoryBucketRow, nextCursor *string) GetAccountBalanceHistoryResponseDto {
+	var dto GetAccountBalanceHistoryResponseDto
+	dto.NextCursor = nextCursor
+	dto.List = make([]AccountBalanceHistoryDto, 0)
+	for _, row := range rows {
+		itemDto := CreateAccountBalanceHistoryDto(&row.AccountBalanceHistory)
+		bucketStart := row.BucketStart
+		itemDto.BucketStart = &bucketStart
+		dto.List = append(dto.List, itemDto)
+	}
+	return dto
+}
@@ -1,32 +1,141 @@
 package accountModuleDto
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"go-gin-test-job/src/database/entities"
+	"slices"
+	"strings"
 )
 
+// AccountDto Balance is in Currency, the currency of the chain. UnconfirmedBalance is the mempool change of Balance,
+// TotalBalance is their sum.
+// Metadata is the JSON object of the integration data, null when there is none
 type AccountDto struct {
-	Id        int64  `json:"id" example:"1"`
-	Address   string `json:"address" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
-	Name      string `json:"name" example:"John Doe"`
-	Rank      uint8  `json:"rank" example:"50"`
-	Memo      string `json:"memo" example:"Some memo text"`
-	Balance   string `json:"balance" example:"12.1234"`
-	Status    string `json:"status" example:"On"`
-	Search    string `json:"search" example:"some text"`
-	CreatedAt int64  `json:"created_at" example:"1600000000000"`
-	UpdatedAt int64  `json:"updated_at" example:"1600000000000"`
+	Id                 int64           `json:"id" example:"1"`
+	Chain              string          `json:"chain" example:"BTC"`
+	Network            string          `json:"network" example:"mainnet"`
+	Address            string          `json:"address" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
+	AddressType        string          `json:"address_type" example:"p2pkh"`
+	Name               string          `json:"name" example:"John Doe"`
+	Rank               uint8           `json:"rank" example:"50"`
+	Memo               string          `json:"memo" example:"Some memo text"`
+	Metadata           json.RawMessage `json:"metadata" swaggertype:"object"`
+	Balance            string          `json:"balance" example:"12.1234"`
+	UnconfirmedBalance string          `json:"unconfirmed_balance" example:"-0.5"`
+	TotalBalance       string          `json:"total_balance" example:"11.6234"`
+	Currency           string          `json:"currency" example:"BTC"`
+	Status             string          `json:"status" example:"Active"`
+	StatusReason       string          `json:"status_reason" example:"Provider rejects the address"`
+	ErrorCount         uint            `json:"error_count" example:"0"`
+	CreatedAt          int64           `json:"created_at" example:"1600000000000"`
+	UpdatedAt          int64           `json:"updated_at" example:"1600000000000"`
+	// Tags are tag names ordered by name
+	Tags []string `json:"tags" example:"cold,exchange"`
+	// Score is the full-text relevance, it is set only for the relevance sort
+	Score *float64 `json:"score,omitempty" example:"0.9"`
+	// fields limits the marshalled fields, all fields are marshalled when it is nil
+	fields []string
+}
+
+// accountDtoJson has the fields of AccountDto without its MarshalJSON
+type accountDtoJson AccountDto
+
+// WithFields returns the dto marshalled with the given fields only, in the dto field order
+func (dto AccountDto) WithFields(fields []string) AccountDto {
+	dto.fields = fields
+	return dto
+}
+
+// MarshalJSON writes the selected fields only, score is kept whenever it is set
+func (dto AccountDto) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(accountDtoJson(dto))
+	if err != nil || dto.fields == nil {
+		return data, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	writeField := func(field string) {
+		value, exists := values[field]
+		if !exists {
+			return
+		}
+		if buffer.Len() > 1 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	for _, field := range AccountFieldList {
+		if slices.Contains(dto.fields, field) {
+			writeField(field)
+		}
+	}
+	writeField("score")
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
 }
 
 func CreateAccountDto(account *entities.Account) AccountDto {
 	return AccountDto{
-		Id:        account.Id,
-		Address:   account.Address,
-		Name:      account.Name,
-		Rank:      account.Rank,
-		Memo:      account.Memo,
-		Balance:   account.Balance.String(),
-		Status:    string(account.Status),
-		CreatedAt: account.CreatedAt,
-		UpdatedAt: account.UpdatedAt,
+		Id:                 account.Id,
+		Chain:              string(account.Chain),
+		Network:            string(account.Network),
+		Address:            account.Address,
+		AddressType:        string(account.AddressType),
+		Name:               account.Name,
+		Rank:               account.Rank,
+		Memo:               account.Memo,
+		Metadata:           getAccountDtoMetadata(account.Metadata),
+		Balance:            account.Balance.String(),
+		UnconfirmedBalance: account.UnconfirmedBalance.String(),
+		TotalBalance:       account.TotalBalance.String(),
+		Currency:           account.Chain.GetCurrency(),
+		Status:             string(account.Status),
+		StatusReason:       account.StatusReason,
+		ErrorCount:         account.ErrorCount,
+		CreatedAt:          account.CreatedAt,
+		UpdatedAt:          account.UpdatedAt,
+		Tags:               CreateAccountTagNames(account.Tags),
+	}
+}
+
+func getAccountDtoMetadata(metadata *string) json.RawMessage {
+	if metadata == nil {
+		return nil
+	}
+	return json.RawMessage(*metadata)
+}
+
+func CreateAccountTagNames(tags []*entities.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// CreateAccountETag builds the entity tag used for optimistic concurrency on account writes, the version
+// changes with every write, the updated_at seconds may not
+func CreateAccountETag(account *entities.Account) string {
+	return fmt.Sprintf("\"%d-%d\"", account.Id, account.Version)
+}
+
+// IsAccountETagMatch checks an If-Match header value against the current account entity tag
+func IsAccountETagMatch(account *entities.Account, ifMatch string) bool {
+	etag := CreateAccountETag(account)
+	for _, value := range strings.Split(ifMatch, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || value == etag {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,154 @@
+package accountModuleDto
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// AccountExportFieldList is AccountFieldList without tags, the export streams the account rows only
+var AccountExportFieldList = func() []string {
+	fields := make([]string, 0, len(AccountFieldList))
+	for _, field := range AccountFieldList {
+		if field != "tags" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}()
+
+// AccountExportWriter writes exported accounts one by one, Flush must be called at the end
+type AccountExportWriter interface {
+	WriteHeader() error
+	Write(account AccountDto) error
+	Flush() error
+}
+
+func NewAccountExportWriter(format AccountExportFormat, writer io.Writer, fields []string) AccountExportWriter {
+	switch format {
+	case AccountExportFormatTsv:
+		return newCsvAccountExportWriter(writer, fields, '\t')
+	case AccountExportFormatNdjson:
+		return newNdjsonAccountExportWriter(writer, fields)
+	}
+	return newCsvAccountExportWriter(writer, fields, ',')
+}
+
+func getAccountExportValue(account AccountDto, field string) interface{} {
+	switch field {
+	case "id":
+		return account.Id
+	case "chain":
+		return account.Chain
+	case "network":
+		return account.Network
+	case "address":
+		return account.Address
+	case "address_type":
+		return account.AddressType
+	case "name":
+		return account.Name
+	case "rank":
+		return account.Rank
+	case "memo":
+		return account.Memo
+	case "balance":
+		return account.Balance
+	case "unconfirmed_balance":
+		return account.UnconfirmedBalance
+	case "total_balance":
+		return account.TotalBalance
+	case "currency":
+		return account.Currency
+	case "metadata":
+		return account.Metadata
+	case "status":
+		return account.Status
+	case "status_reason":
+		return account.StatusReason
+	case "error_count":
+		return account.ErrorCount
+	case "created_at":
+		return account.CreatedAt
+	case "updated_at":
+		return account.UpdatedAt
+	}
+	return nil
+}
+
+///// CSV and TSV
+
+type csvAccountExportWriter struct {
+	writer *csv.Writer
+	fields []string
+	record []string
+}
+
+func newCsvAccountExportWriter(writer io.Writer, fields []string, comma rune) *csvAccountExportWriter {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = comma
+	return &csvAccountExportWriter{writer: csvWriter, fields: fields, record: make([]string, len(fields))}
+}
+
+func (w *csvAccountExportWriter) WriteHeader() error {
+	return w.writer.Write(w.fields)
+}
+
+func (w *csvAccountExportWriter) Write(account AccountDto) error {
+	for index, field := range w.fields {
+		value := getAccountExportValue(account, field)
+		// JSON is written as text, fmt would print its bytes
+		if metadata, isJson := value.(json.RawMessage); isJson {
+			w.record[index] = string(metadata)
+			continue
+		}
+		w.record[index] = fmt.Sprint(value)
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvAccountExportWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+///// NDJSON
+
+type ndjsonAccountExportWriter struct {
+	writer *bufio.Writer
+	fields []string
+}
+
+func newNdjsonAccountExportWriter(writer io.Writer, fields []string) *ndjsonAccountExportWriter {
+	return &ndjsonAccountExportWriter{writer: bufio.NewWriter(writer), fields: fields}
+}
+
+func (w *ndjsonAccountExportWriter) WriteHeader() error {
+	return nil
+}
+
+// Write keeps the requested field order, which a map based object would lose
+func (w *ndjsonAccountExportWriter) Write(account AccountDto) error {
+	_ = w.writer.WriteByte('{')
+	for index, field := range w.fields {
+		if index > 0 {
+			_ = w.writer.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		value, err := json.Marshal(getAccountExportValue(account, field))
+		if err != nil {
+			return err
+		}
+		_, _ = w.writer.Write(key)
+		_ = w.writer.WriteByte(':')
+		_, _ = w.writer.Write(value)
+	}
+	_, err := w.writer.WriteString("}\n")
+	return err
+}
+
+func (w *ndjsonAccountExportWriter) Flush() error {
+	return w.writer.Flush()
+}
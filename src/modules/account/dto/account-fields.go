@@ -0,0 +1,121 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	orderUtil "go-gin-test-job/src/utils/order"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// AccountFieldList is the fields whitelist, the json names of AccountDto without score,
+// which is not a stored field and is set only by the relevance sort
+var AccountFieldList = func() []string {
+	fields := make([]string, 0)
+	dtoType := reflect.TypeOf(AccountDto{})
+	for index := 0; index < dtoType.NumField(); index++ {
+		name := strings.Split(dtoType.Field(index).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "score" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}()
+
+// GetAccountFieldsRequestDto is the fields param of the single account requests
+type GetAccountFieldsRequestDto struct {
+	Fields string `form:"fields" json:"fields" validate:"omitempty,max=255" example:"id,address,balance"`
+}
+
+var getAccountFieldsRequestDtoValidator *validator.Validate
+
+func init() {
+	getAccountFieldsRequestDtoValidator = validator.New()
+}
+
+func validateGetAccountFieldsRequestDto(dto *GetAccountFieldsRequestDto) error {
+	return getAccountFieldsRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAccountFieldsRequestDto is the Gin version of handling the request
+func CreateGetAccountFieldsRequestDto(c *gin.Context) (GetAccountFieldsRequestDto, error) {
+	var dto GetAccountFieldsRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessages.DefaultQueryParseErrorMessage())
+	}
+	// Validate the DTO
+	if err := validateGetAccountFieldsRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAccountFieldsRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func GetAccountFieldsRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Fields" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
+
+// GetAccountFieldsSecure parses the comma-separated field list against availableFields,
+// it returns nil for an empty list, which means all fields
+func GetAccountFieldsSecure(c *gin.Context, data string, availableFields []string) ([]string, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, nil
+	}
+	fields := make([]string, 0)
+	requestedFields := make(map[string]bool)
+	for _, field := range strings.Split(data, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || requestedFields[field] {
+			continue
+		}
+		if !slices.Contains(availableFields, field) {
+			return nil, errorHelpers.RespondBadRequestError(c, GetAccountFieldsErrorMessage(field, availableFields))
+		}
+		requestedFields[field] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// GetAccountFieldsErrorMessage names the requested field which is not in availableFields
+func GetAccountFieldsErrorMessage(field string, availableFields []string) string {
+	return fmt.Sprintf("Fields must be a list of the next values: %s. Unknown field %s", strings.Join(availableFields, ","), field)
+}
+
+// GetAccountQueryFields adds the fields the response is built from to the requested ones: id and the order fields,
+// which the tags, the ETag and the next page cursor need. nil stays nil, all fields are read then
+func GetAccountQueryFields(fields []string, orderParams []orderUtil.OrderParam, requiredFields []string) []string {
+	if fields == nil {
+		return nil
+	}
+	queryFields := make([]string, 0, len(fields)+len(requiredFields)+len(orderParams)+1)
+	queryFields = append(queryFields, fields...)
+	addField := func(field string) {
+		if !slices.Contains(queryFields, field) {
+			queryFields = append(queryFields, field)
+		}
+	}
+	addField("id")
+	for _, field := range requiredFields {
+		addField(field)
+	}
+	for _, orderParam := range orderParams {
+		addField(orderParam.Field)
+	}
+	return queryFields
+}
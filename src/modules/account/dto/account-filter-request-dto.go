@@ -0,0 +1,125 @@
+package accountModuleDto
+
+import (
+	"go-gin-test-job/src/common/validations"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+)
+
+// AccountFilterRequestDto is the set of list filters shared by the account list, export and stats requests
+type AccountFilterRequestDto struct {
+	Status      string `form:"status" json:"status" validate:"omitempty,AccountStatusListValidation" example:"Pending,Active"`
+	Search      string `form:"search" json:"search" validate:"omitempty,max=255" example:"John"`
+	BalanceMin  string `form:"balanceMin" json:"balanceMin" validate:"omitempty,AccountBalanceBoundValidation" example:"0.001"`
+	BalanceMax  string `form:"balanceMax" json:"balanceMax" validate:"omitempty,AccountBalanceBoundValidation" example:"10.5"`
+	BalanceKind string `form:"balanceKind" json:"balanceKind" validate:"omitempty,oneof=confirmed unconfirmed total" enums:"confirmed,unconfirmed,total" example:"total"`
+	RankMin     *int   `form:"rankMin" json:"rankMin" validate:"omitnil,min=0,max=100" example:"10"`
+	RankMax     *int   `form:"rankMax" json:"rankMax" validate:"omitnil,min=0,max=100" example:"90"`
+	CreatedFrom *int64 `form:"createdFrom" json:"createdFrom" validate:"omitnil,min=0" example:"1600000000"`
+	CreatedTo   *int64 `form:"createdTo" json:"createdTo" validate:"omitnil,min=0" example:"1700000000"`
+	UpdatedFrom *int64 `form:"updatedFrom" json:"updatedFrom" validate:"omitnil,min=0" example:"1600000000"`
+	UpdatedTo   *int64 `form:"updatedTo" json:"updatedTo" validate:"omitnil,min=0" example:"1700000000"`
+	Chain       string `form:"chain" json:"chain" validate:"omitempty,AccountChainValidation" enums:"BTC,LTC,BCH,DOGE" example:"BTC"`
+	Network     string `form:"network" json:"network" validate:"omitempty,AccountNetworkValidation" enums:"mainnet,testnet,signet,regtest" example:"mainnet"`
+	AddressType string `form:"addressType" json:"addressType" validate:"omitempty,oneof=p2pkh p2sh p2wpkh p2wsh p2tr bech32" enums:"p2pkh,p2sh,p2wpkh,p2wsh,p2tr,bech32" example:"p2pkh"`
+	Tags        string `form:"tags" json:"tags" validate:"omitempty,max=1024,TagNameListValidation" example:"exchange,cold"`
+	TagMode     string `form:"tagMode" json:"tagMode" validate:"omitempty,oneof=any all" enums:"any,all" example:"any"`
+	// MetaFilters are the meta.<path>=value params, they are read by CreateAccountMetaFilters
+	MetaFilters []database.AccountMetaFilter `form:"-" json:"-" swaggerignore:"true"`
+}
+
+func registerAccountFilterValidations(v *validator.Validate) {
+	_ = v.RegisterValidation("AccountStatusListValidation", validations.AccountStatusListValidation)
+	_ = v.RegisterValidation("AccountBalanceBoundValidation", validations.AccountBalanceBoundValidation)
+	_ = v.RegisterValidation("AccountChainValidation", validations.AccountChainValidation)
+	_ = v.RegisterValidation("AccountNetworkValidation", validations.AccountNetworkValidation)
+	_ = v.RegisterValidation("TagNameListValidation", validations.TagNameListValidation)
+	v.RegisterStructValidation(accountFilterRangeStructValidation, AccountFilterRequestDto{})
+}
+
+// accountFilterRangeStructValidation reports a min bound greater than its max bound as an ltefield error
+func accountFilterRangeStructValidation(sl validator.StructLevel) {
+	dto := sl.Current().Interface().(AccountFilterRequestDto)
+	if dto.BalanceMin != "" && dto.BalanceMax != "" {
+		balanceMin, errMin := decimal.NewFromString(dto.BalanceMin)
+		balanceMax, errMax := decimal.NewFromString(dto.BalanceMax)
+		if errMin == nil && errMax == nil && balanceMin.GreaterThan(balanceMax) {
+			sl.ReportError(dto.BalanceMin, "BalanceMin", "BalanceMin", "ltefield", "BalanceMax")
+		}
+	}
+	if dto.RankMin != nil && dto.RankMax != nil && *dto.RankMin > *dto.RankMax {
+		sl.ReportError(dto.RankMin, "RankMin", "RankMin", "ltefield", "RankMax")
+	}
+	if dto.CreatedFrom != nil && dto.CreatedTo != nil && *dto.CreatedFrom > *dto.CreatedTo {
+		sl.ReportError(dto.CreatedFrom, "CreatedFrom", "CreatedFrom", "ltefield", "CreatedTo")
+	}
+	if dto.UpdatedFrom != nil && dto.UpdatedTo != nil && *dto.UpdatedFrom > *dto.UpdatedTo {
+		sl.ReportError(dto.UpdatedFrom, "UpdatedFrom", "UpdatedFrom", "ltefield", "UpdatedTo")
+	}
+}
+
+// GetStatusList splits the comma-separated status filter
+func (dto *AccountFilterRequestDto) GetStatusList() []entities.AccountStatus {
+	statuses := make([]entities.AccountStatus, 0)
+	for _, status := range strings.Split(dto.Status, ",") {
+		status = strings.Trim(strings.TrimSpace(status), "\"")
+		if status != "" {
+			statuses = append(statuses, entities.AccountStatus(status))
+		}
+	}
+	return statuses
+}
+
+// GetTagList splits the comma-separated tag filter, duplicates are dropped
+func (dto *AccountFilterRequestDto) GetTagList() []string {
+	tags := make([]string, 0)
+	existingTags := make(map[string]bool)
+	for _, tag := range strings.Split(dto.Tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !existingTags[tag] {
+			existingTags[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// CreateAccountFilter converts the validated request filters to the repository filter
+func (dto *AccountFilterRequestDto) CreateAccountFilter() database.AccountFilter {
+	filter := database.AccountFilter{
+		Statuses:    dto.GetStatusList(),
+		Search:      dto.Search,
+		BalanceKind: entities.AccountBalanceKind(dto.BalanceKind),
+		CreatedFrom: dto.CreatedFrom,
+		CreatedTo:   dto.CreatedTo,
+		UpdatedFrom: dto.UpdatedFrom,
+		UpdatedTo:   dto.UpdatedTo,
+		Chain:       entities.AccountChain(dto.Chain),
+		Network:     entities.AccountNetwork(dto.Network),
+		AddressType: entities.AccountAddressType(dto.AddressType),
+		Tags:        dto.GetTagList(),
+		TagMode:     database.AccountTagMode(dto.TagMode),
+		MetaFilters: dto.MetaFilters,
+	}
+	if dto.BalanceMin != "" {
+		balanceMin := decimal.RequireFromString(dto.BalanceMin)
+		filter.BalanceMin = &balanceMin
+	}
+	if dto.BalanceMax != "" {
+		balanceMax := decimal.RequireFromString(dto.BalanceMax)
+		filter.BalanceMax = &balanceMax
+	}
+	if dto.RankMin != nil {
+		rankMin := uint8(*dto.RankMin)
+		filter.RankMin = &rankMin
+	}
+	if dto.RankMax != nil {
+		rankMax := uint8(*dto.RankMax)
+		filter.RankMax = &rankMax
+	}
+	return filter
+}
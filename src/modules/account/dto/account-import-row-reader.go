@@ -0,0 +1,178 @@
+package accountModuleDto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/database/entities"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Max length of a single NDJSON line
+const accountImportMaxLineSize = 1024 * 1024
+
+const accountImportRowParseErrorMessage = "Row format is wrong"
+
+var AccountImportColumnList = []string{"chain", "network", "address", "name", "rank", "memo", "status", "metadata"}
+
+// AccountImportRow is a single parsed row of an import file.
+// ErrorMessage is set when the row can not be parsed into the DTO
+type AccountImportRow struct {
+	Line         int
+	Dto          PostCreateAccountRequestDto
+	ErrorMessage string
+}
+
+// AccountImportRowReader reads import rows one by one, it returns io.EOF after the last row.
+// A file which can not be read any further returns *AccountImportReadError
+type AccountImportRowReader interface {
+	Read() (*AccountImportRow, error)
+}
+
+// AccountImportReadError is returned when the file breaks off at Line, the rows before it were read
+type AccountImportReadError struct {
+	Line int
+	Err  error
+}
+
+func (e *AccountImportReadError) Error() string {
+	return fmt.Sprintf("Read import file error at line %d. %s", e.Line, e.Err.Error())
+}
+
+func (e *AccountImportReadError) Unwrap() error {
+	return e.Err
+}
+
+func NewAccountImportRowReader(format AccountImportFormat, reader io.Reader) (AccountImportRowReader, error) {
+	switch format {
+	case AccountImportFormatCsv:
+		return newCsvAccountImportRowReader(reader)
+	case AccountImportFormatNdjson:
+		return newNdjsonAccountImportRowReader(reader), nil
+	}
+	return nil, fmt.Errorf("Format must be one of the next values: %s,%s", AccountImportFormatCsv, AccountImportFormatNdjson)
+}
+
+///// CSV
+
+type csvAccountImportRowReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func newCsvAccountImportRowReader(reader io.Reader) (*csvAccountImportRowReader, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.ReuseRecord = true
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("File is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CSV header. %s", err.Error())
+	}
+	columns := make(map[string]int)
+	for index, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !isAccountImportColumn(column) {
+			return nil, fmt.Errorf("Unknown column %s, available columns: %s", column, strings.Join(AccountImportColumnList, ","))
+		}
+		columns[column] = index
+	}
+	if _, exists := columns["address"]; !exists {
+		return nil, fmt.Errorf("Column address is required")
+	}
+	line, _ := csvReader.FieldPos(0)
+	return &csvAccountImportRowReader{reader: csvReader, columns: columns, line: line}, nil
+}
+
+func (r *csvAccountImportRowReader) Read() (*AccountImportRow, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		parseError, isParseError := err.(*csv.ParseError)
+		if !isParseError {
+			return nil, &AccountImportReadError{Line: r.line + 1, Err: err}
+		}
+		r.line = parseError.Line
+		return &AccountImportRow{Line: parseError.StartLine, ErrorMessage: accountImportRowParseErrorMessage}, nil
+	}
+	r.line, _ = r.reader.FieldPos(len(record) - 1)
+	line, _ := r.reader.FieldPos(0)
+	row := &AccountImportRow{Line: line}
+	value := func(column string) string {
+		index, exists := r.columns[column]
+		if !exists || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+	row.Dto.Chain = entities.AccountChain(value("chain"))
+	row.Dto.Network = entities.AccountNetwork(value("network"))
+	row.Dto.Address = value("address")
+	row.Dto.Name = value("name")
+	row.Dto.Memo = value("memo")
+	row.Dto.Status = entities.AccountStatus(value("status"))
+	// The metadata cell holds the JSON object, an empty cell leaves it out
+	if metadata := value("metadata"); metadata != "" {
+		row.Dto.Metadata = json.RawMessage(metadata)
+	}
+	if rankValue := value("rank"); rankValue != "" {
+		rank, err := strconv.ParseUint(rankValue, 10, 8)
+		if err != nil {
+			row.ErrorMessage = errorMessages.DefaultFieldErrorMessage("Rank")
+			return row, nil
+		}
+		row.Dto.Rank = uint8(rank)
+	}
+	return row, nil
+}
+
+func isAccountImportColumn(column string) bool {
+	for _, availableColumn := range AccountImportColumnList {
+		if column == availableColumn {
+			return true
+		}
+	}
+	return false
+}
+
+///// NDJSON
+
+type ndjsonAccountImportRowReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNdjsonAccountImportRowReader(reader io.Reader) *ndjsonAccountImportRowReader {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), accountImportMaxLineSize)
+	return &ndjsonAccountImportRowReader{scanner: scanner}
+}
+
+func (r *ndjsonAccountImportRowReader) Read() (*AccountImportRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		row := &AccountImportRow{Line: r.line}
+		if err := json.Unmarshal(data, &row.Dto); err != nil {
+			row.ErrorMessage = accountImportRowParseErrorMessage
+		}
+		return row, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, &AccountImportReadError{Line: r.line + 1, Err: err}
+	}
+	return nil, io.EOF
+}
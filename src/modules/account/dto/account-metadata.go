@@ -0,0 +1,90 @@
+package accountModuleDto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-gin-test-job/src/database"
+	metadataValidationUtil "go-gin-test-job/src/utils/metadata-validation"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// AccountMetaFilterPrefix is the query param prefix of the metadata filters, meta.crm.id=42 matches {"crm": {"id": 42}}
+const AccountMetaFilterPrefix = "meta."
+
+// MAX_ACCOUNT_META_FILTERS limits the JSON conditions of one query
+const MAX_ACCOUNT_META_FILTERS = 5
+
+const MAX_ACCOUNT_META_FILTER_VALUE_LENGTH = 255
+
+// AccountMetadataErrorMessage describes the metadata limits
+func AccountMetadataErrorMessage(field string) string {
+	return fmt.Sprintf(
+		"%s must be a JSON object up to %d bytes and %d levels deep",
+		field, metadataValidationUtil.MAX_METADATA_SIZE, metadataValidationUtil.MAX_METADATA_DEPTH,
+	)
+}
+
+// getAccountMetadata returns the metadata JSON to store, nil when it is omitted or null
+func getAccountMetadata(metadata json.RawMessage) *string {
+	trimmed := bytes.TrimSpace(metadata)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	value := string(trimmed)
+	return &value
+}
+
+// IsAccountMetadataEqual compares the metadata JSON by value, MySQL does not keep the formatting and the key order
+func IsAccountMetadataEqual(metadata *string, otherMetadata *string) bool {
+	if metadata == nil || otherMetadata == nil {
+		return metadata == nil && otherMetadata == nil
+	}
+	var value, otherValue interface{}
+	if json.Unmarshal([]byte(*metadata), &value) != nil || json.Unmarshal([]byte(*otherMetadata), &otherValue) != nil {
+		return *metadata == *otherMetadata
+	}
+	return reflect.DeepEqual(value, otherValue)
+}
+
+func getAccountMetadataString(metadata *string) string {
+	if metadata == nil {
+		return ""
+	}
+	return *metadata
+}
+
+// CreateAccountMetaFilters reads the meta.<path>=value query params, their keys are dynamic, so the query binding skips them.
+// It returns the error message of the first invalid param, empty when all are valid
+func CreateAccountMetaFilters(query url.Values) ([]database.AccountMetaFilter, string) {
+	keys := make([]string, 0)
+	for key := range query {
+		if strings.HasPrefix(key, AccountMetaFilterPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	// Sort to report the errors and to build the conditions in a stable order
+	sort.Strings(keys)
+	if len(keys) > MAX_ACCOUNT_META_FILTERS {
+		return nil, fmt.Sprintf("Meta filters count must be less than or equal %d", MAX_ACCOUNT_META_FILTERS)
+	}
+	filters := make([]database.AccountMetaFilter, 0, len(keys))
+	for _, key := range keys {
+		path := strings.TrimPrefix(key, AccountMetaFilterPrefix)
+		if !metadataValidationUtil.IsValidMetadataPath(path) {
+			return nil, fmt.Sprintf("%s path must be up to %d dot-separated names of letters, digits and underscores", key, metadataValidationUtil.MAX_METADATA_DEPTH)
+		}
+		values := query[key]
+		if len(values) != 1 {
+			return nil, fmt.Sprintf("%s must be set once", key)
+		}
+		if len(values[0]) > MAX_ACCOUNT_META_FILTER_VALUE_LENGTH {
+			return nil, fmt.Sprintf("%s must be shorter than or equal to %d characters", key, MAX_ACCOUNT_META_FILTER_VALUE_LENGTH)
+		}
+		filters = append(filters, database.AccountMetaFilter{Path: path, Value: values[0]})
+	}
+	return filters, ""
+}
@@ -0,0 +1,62 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AccountTagRequestDto struct {
+	Id    int64 `uri:"id" json:"id" validate:"min=1" example:"1"`
+	TagId int64 `uri:"tagId" json:"tagId" validate:"min=1" example:"1"`
+}
+
+var accountTagRequestDtoValidator *validator.Validate
+
+func init() {
+	accountTagRequestDtoValidator = validator.New()
+}
+
+func validateAccountTagRequestDto(dto *AccountTagRequestDto) error {
+	return accountTagRequestDtoValidator.Struct(dto)
+}
+
+// CreateAccountTagRequestDto is the Gin version of handling the request
+func CreateAccountTagRequestDto(c *gin.Context) (AccountTagRequestDto, error) {
+	var dto AccountTagRequestDto
+	// Parse uri params into DTO
+	if err := c.ShouldBindUri(&dto); err != nil {
+		errorMessage := AccountTagRequestDtoUriParseErrorMessage(c, err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validateAccountTagRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := AccountTagRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// AccountTagRequestDtoUriParseErrorMessage names the path param that failed, the bind error does not tell it
+func AccountTagRequestDtoUriParseErrorMessage(c *gin.Context, err error) string {
+	if _, parseErr := strconv.ParseInt(c.Param("id"), 10, 64); parseErr != nil {
+		return errorMessages.DefaultFieldErrorMessage("Id")
+	}
+	return errorMessages.DefaultFieldErrorMessage("TagId")
+}
+
+func AccountTagRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if (err.Field() == "Id" || err.Field() == "TagId") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
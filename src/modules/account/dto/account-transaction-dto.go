@@ -0,0 +1,48 @@
+package accountModuleDto
+
+import (
+	"go-gin-test-job/src/database/entities"
+)
+
+// AccountTransactionDto Amount is the net change of the account balance, negative for the out direction.
+// BlockHeight is null while the transaction is in the mempool, Time is the time it was first seen then.
+// Confirmations are not updated any more once they reach the settled count, 6 by default
+type AccountTransactionDto struct {
+	Id            int64  `json:"id" example:"1"`
+	Txid          string `json:"txid" example:"f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"`
+	BlockHeight   *int64 `json:"block_height" example:"170"`
+	Time          int64  `json:"time" example:"1231731025"`
+	Amount        string `json:"amount" example:"-10"`
+	Fee           string `json:"fee" example:"0"`
+	Confirmations uint   `json:"confirmations" example:"6"`
+	Direction     string `json:"direction" example:"out"`
+}
+
+func CreateAccountTransactionDto(transaction *entities.Transaction) AccountTransactionDto {
+	return AccountTransactionDto{
+		Id:            transaction.Id,
+		Txid:          transaction.Txid,
+		BlockHeight:   transaction.BlockHeight,
+		Time:          transaction.Time,
+		Amount:        transaction.Amount.String(),
+		Fee:           transaction.Fee.String(),
+		Confirmations: transaction.Confirmations,
+		Direction:     string(transaction.GetDirection()),
+	}
+}
+
+type GetAccountTransactionsResponseDto struct {
+	List []AccountTransactionDto `json:"list"`
+	// NextCursor is set when there are more rows after the list, pass it as cursor to get them
+	NextCursor *string `json:"nextCursor" example:"eyJvIjoidGltZSBERVNDLGlkIERFU0MiLCJ2IjpbMTcwMDAwMDAwMCw0Ml19"`
+}
+
+func CreateGetAccountTransactionsResponseDto(transactions []*entities.Transaction, nextCursor *string) GetAccountTransactionsResponseDto {
+	var dto GetAccountTransactionsResponseDto
+	dto.NextCursor = nextCursor
+	dto.List = make([]AccountTransactionDto, 0)
+	for _, transaction := range transactions {
+		dto.List = append(dto.List, CreateAccountTransactionDto(transaction))
+	}
+	return dto
+}
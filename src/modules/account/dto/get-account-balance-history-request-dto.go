@@ -0,0 +1,118 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/database"
+	orderUtil "go-gin-test-job/src/utils/order"
+	stringUtil "go-gin-test-job/src/utils/string"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const DEFAULT_ACCOUNT_BALANCE_HISTORY_COUNT = 100
+
+type GetAccountBalanceHistoryRequestDto struct {
+	From   *int64 `form:"from" json:"from" validate:"omitnil,min=0" example:"1600000000"`
+	To     *int64 `form:"to" json:"to" validate:"omitnil,min=0" example:"1700000000"`
+	Count  int    `form:"count" json:"count" validate:"min=1,max=1000" default:"100" example:"20"`
+	Bucket string `form:"bucket" json:"bucket" validate:"omitempty,oneof=hour day week" enums:"hour,day,week" example:"day"`
+	Cursor string `form:"cursor" json:"cursor" validate:"omitempty,max=1024" example:"eyJvIjoiZGF5IERFU0MiLCJ2IjpbIjE3MDAwMDAwMDAiXX0"`
+}
+
+var getAccountBalanceHistoryRequestDtoValidator *validator.Validate
+
+func init() {
+	getAccountBalanceHistoryRequestDtoValidator = validator.New()
+	getAccountBalanceHistoryRequestDtoValidator.RegisterStructValidation(getAccountBalanceHistoryRequestDtoStructValidation, GetAccountBalanceHistoryRequestDto{})
+}
+
+// getAccountBalanceHistoryRequestDtoStructValidation reports a range start after its end as an ltefield error
+func getAccountBalanceHistoryRequestDtoStructValidation(sl validator.StructLevel) {
+	dto := sl.Current().Interface().(GetAccountBalanceHistoryRequestDto)
+	if dto.From != nil && dto.To != nil && *dto.From > *dto.To {
+		sl.ReportError(dto.From, "From", "From", "ltefield", "To")
+	}
+}
+
+func getAccountBalanceHistoryRequestDtoDefaultValues(dto *GetAccountBalanceHistoryRequestDto) {
+	if dto.Count == 0 {
+		dto.Count = DEFAULT_ACCOUNT_BALANCE_HISTORY_COUNT
+	}
+}
+
+func validateGetAccountBalanceHistoryRequestDto(dto *GetAccountBalanceHistoryRequestDto) error {
+	return getAccountBalanceHistoryRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAccountBalanceHistoryRequestDto is the Gin version of handling the request
+func CreateGetAccountBalanceHistoryRequestDto(c *gin.Context) (GetAccountBalanceHistoryRequestDto, error) {
+	var dto GetAccountBalanceHistoryRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := GetAccountBalanceHistoryRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Set default values
+	getAccountBalanceHistoryRequestDtoDefaultValues(&dto)
+	// Validate the DTO
+	if err := validateGetAccountBalanceHistoryRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAccountBalanceHistoryRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// GetOrderParams returns the fixed newest first order, the cursor is bound to it and so to the bucket
+func (dto *GetAccountBalanceHistoryRequestDto) GetOrderParams() []orderUtil.OrderParam {
+	if dto.Bucket != "" {
+		return []orderUtil.OrderParam{{Field: dto.Bucket, Direction: "DESC"}}
+	}
+	return []orderUtil.OrderParam{{Field: "created_at", Direction: "DESC"}, {Field: "id", Direction: "DESC"}}
+}
+
+func (dto *GetAccountBalanceHistoryRequestDto) CreateAccountBalanceHistoryFilter(accountId int64) database.AccountBalanceHistoryFilter {
+	return database.AccountBalanceHistoryFilter{
+		AccountId:   accountId,
+		CreatedFrom: dto.From,
+		CreatedTo:   dto.To,
+	}
+}
+
+func GetAccountBalanceHistoryRequestDtoQueryParseErrorMessage(err error) string {
+	var errorMessage string
+	if stringUtil.CaseInsensitiveContains(err.Error(), "\"from\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".from") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("from")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"to\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".to") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("to")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"count\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".count") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("count")
+	} else {
+		errorMessage = errorMessages.DefaultQueryParseErrorMessage()
+	}
+	return errorMessage
+}
+
+func GetAccountBalanceHistoryRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if (err.Field() == "From" || err.Field() == "To") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be a unix timestamp greater than or equal %s", err.Field(), err.Param())
+	} else if err.Tag() == "ltefield" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Count" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Count" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Bucket" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), "hour,day,week")
+	} else if err.Field() == "Cursor" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -0,0 +1,85 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+	"go-gin-test-job/src/database/entities"
+	addressValidationUtil "go-gin-test-job/src/utils/address-validation"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// GetAccountByAddressRequestDto Chain is BTC and Network is mainnet when they are omitted
+type GetAccountByAddressRequestDto struct {
+	Chain   entities.AccountChain   `form:"chain" json:"chain" validate:"AccountChainValidation" enums:"BTC,LTC,BCH,DOGE" example:"BTC"`
+	Network entities.AccountNetwork `form:"network" json:"network" validate:"AccountNetworkValidation,AccountChainNetworkValidation" enums:"mainnet,testnet,signet,regtest" example:"mainnet"`
+	Address string                  `uri:"address" json:"address" validate:"AccountAddressValidation" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
+}
+
+var getAccountByAddressRequestDtoValidator *validator.Validate
+
+func init() {
+	getAccountByAddressRequestDtoValidator = validator.New()
+	_ = getAccountByAddressRequestDtoValidator.RegisterValidation("AccountChainValidation", validations.AccountChainValidation)
+	_ = getAccountByAddressRequestDtoValidator.RegisterValidation("AccountNetworkValidation", validations.AccountNetworkValidation)
+	_ = getAccountByAddressRequestDtoValidator.RegisterValidation("AccountChainNetworkValidation", validations.AccountChainNetworkValidation)
+	_ = getAccountByAddressRequestDtoValidator.RegisterValidation("AccountAddressValidation", validations.AccountAddressValidation)
+}
+
+func validateGetAccountByAddressRequestDto(dto *GetAccountByAddressRequestDto) error {
+	return getAccountByAddressRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAccountByAddressRequestDto is the Gin version of handling the request
+func CreateGetAccountByAddressRequestDto(c *gin.Context) (GetAccountByAddressRequestDto, error) {
+	var dto GetAccountByAddressRequestDto
+	// Parse uri params into DTO
+	if err := c.ShouldBindUri(&dto); err != nil {
+		errorMessage := GetAccountByAddressRequestDtoUriParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessages.DefaultQueryParseErrorMessage())
+	}
+	if dto.Chain == "" {
+		dto.Chain = entities.AccountChainBtc
+	}
+	if dto.Network == "" {
+		dto.Network = entities.AccountNetworkMainnet
+	}
+	// Validate the DTO
+	if err := validateGetAccountByAddressRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAccountByAddressRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	// The valid address is looked up in its stored form
+	dto.Address = addressValidationUtil.NormalizeAddress(addressValidationUtil.Chain(dto.Chain), addressValidationUtil.Network(dto.Network), dto.Address)
+	return dto, nil
+}
+
+func GetAccountByAddressRequestDtoUriParseErrorMessage(err error) string {
+	return errorMessages.DefaultFieldErrorMessage("Address")
+}
+
+func GetAccountByAddressRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Chain" && err.Tag() == "AccountChainValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountChainList, ","))
+	} else if err.Field() == "Network" && err.Tag() == "AccountNetworkValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountNetworkList, ","))
+	} else if err.Field() == "Network" && err.Tag() == "AccountChainNetworkValidation" {
+		errorMessage = fmt.Sprintf("%s %v is not supported by the chain", err.Field(), err.Value())
+	} else if err.Field() == "Address" && err.Tag() == "AccountAddressValidation" {
+		errorMessage = fmt.Sprintf("%s format is wrong", err.Field())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
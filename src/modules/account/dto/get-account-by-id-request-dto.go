@@ -0,0 +1,56 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type GetAccountByIdRequestDto struct {
+	Id int64 `uri:"id" json:"id" validate:"min=1" example:"1"`
+}
+
+var getAccountByIdRequestDtoValidator *validator.Validate
+
+func init() {
+	getAccountByIdRequestDtoValidator = validator.New()
+}
+
+func validateGetAccountByIdRequestDto(dto *GetAccountByIdRequestDto) error {
+	return getAccountByIdRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAccountByIdRequestDto is the Gin version of handling the request
+func CreateGetAccountByIdRequestDto(c *gin.Context) (GetAccountByIdRequestDto, error) {
+	var dto GetAccountByIdRequestDto
+	// Parse uri params into DTO
+	if err := c.ShouldBindUri(&dto); err != nil {
+		errorMessage := GetAccountByIdRequestDtoUriParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validateGetAccountByIdRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAccountByIdRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func GetAccountByIdRequestDtoUriParseErrorMessage(err error) string {
+	return errorMessages.DefaultFieldErrorMessage("Id")
+}
+
+func GetAccountByIdRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Id" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	errorHelpers "go-gin-test-job/src/common/error-helpers"
 	errorMessages "go-gin-test-job/src/common/error-messages"
-	"go-gin-test-job/src/common/validations"
 	"go-gin-test-job/src/database/entities"
+	orderUtil "go-gin-test-job/src/utils/order"
 	stringUtil "go-gin-test-job/src/utils/string"
 	"strings"
 
@@ -15,13 +15,64 @@ import (
 
 const DEFAULT_ACCOUNT_COUNT = 100
 const DEFAULT_ACCOUNT_OFFSET = 0
+const ACCOUNT_SORT_RELEVANCE = "relevance"
 
 var GetAvailableAccountSortField = map[string]string{
-	"id":         "account.id",
-	"updated_at": "account.updated_at",
-	"address":    "account.address",
-	"name":       "account.name",
-	"rank":       "account.rank",
+	"id":                  "account.id",
+	"updated_at":          "account.updated_at",
+	"created_at":          "account.created_at",
+	"address":             "account.address",
+	"name":                "account.name",
+	"rank":                "account.rank",
+	"balance":             "account.balance",
+	"unconfirmed_balance": "account.unconfirmed_balance",
+	"total_balance":       "account.total_balance",
+	"status":              "account.status",
+}
+
+// GetAccountSortValues returns the values of the order fields, they are stored in the next page cursor
+func GetAccountSortValues(account *entities.Account, orderParams []orderUtil.OrderParam) []interface{} {
+	values := make([]interface{}, 0, len(orderParams))
+	for _, orderParam := range orderParams {
+		var value interface{}
+		switch orderParam.Field {
+		case "id":
+			value = account.Id
+		case "updated_at":
+			value = account.UpdatedAt
+		case "created_at":
+			value = account.CreatedAt
+		case "balance":
+			value = account.Balance.String()
+		case "unconfirmed_balance":
+			value = account.UnconfirmedBalance.String()
+		case "total_balance":
+			value = account.TotalBalance.String()
+		case "status":
+			value = string(account.Status)
+		case "address":
+			value = account.Address
+		case "name":
+			value = account.Name
+		case "rank":
+			value = account.Rank
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// GetAccountBalanceKindOrderParams sorts the balance field by the balance of the kind
+func GetAccountBalanceKindOrderParams(orderParams []orderUtil.OrderParam, balanceKind string) []orderUtil.OrderParam {
+	balanceField := entities.AccountBalanceKind(balanceKind).GetField()
+	kindOrderParams := make([]orderUtil.OrderParam, 0, len(orderParams))
+	for _, orderParam := range orderParams {
+		if orderParam.Field == "balance" {
+			orderParam.Field = balanceField
+		}
+		kindOrderParams = append(kindOrderParams, orderParam)
+	}
+	return kindOrderParams
 }
 
 var GetAvailableAccountSortFieldList = func() []string {
@@ -33,18 +84,29 @@ var GetAvailableAccountSortFieldList = func() []string {
 }()
 
 type GetAccountRequestDto struct {
-	Offset  int                    `form:"offset" json:"offset" validate:"min=0" default:"0" example:"5"`
-	Count   int                    `form:"count" json:"count" validate:"min=1,max=100" default:"100" example:"20"`
-	Status  entities.AccountStatus `form:"status" json:"status" validate:"omitempty,AccountStatusValidation" example:"On"`
-	OrderBy string                 `form:"orderBy" json:"orderBy" validate:"omitempty,max=255" example:"id ASC"`
-	Search  string                 `form:"search" json:"search" validate:"omitempty,max=255" example:"John"`
+	Offset int `form:"offset" json:"offset" validate:"min=0" default:"0" example:"5"`
+	Count  int `form:"count" json:"count" validate:"min=1,max=100" default:"100" example:"20"`
+	AccountFilterRequestDto
+	OrderBy string `form:"orderBy" json:"orderBy" validate:"omitempty,max=255" example:"id ASC"`
+	Sort    string `form:"sort" json:"sort" validate:"omitempty,oneof=relevance" enums:"relevance" example:"relevance"`
+	Cursor  string `form:"cursor" json:"cursor" validate:"omitempty,max=1024,excluded_with=Offset,excluded_with=Sort" example:"eyJvIjoiaWQgQVNDIiwidiI6WyI0Il19"`
+	Fields  string `form:"fields" json:"fields" validate:"omitempty,max=255" example:"id,address,balance"`
 }
 
 var getAccountRequestDtoValidator *validator.Validate
 
 func init() {
 	getAccountRequestDtoValidator = validator.New()
-	_ = getAccountRequestDtoValidator.RegisterValidation("AccountStatusValidation", validations.AccountStatusValidation)
+	registerAccountFilterValidations(getAccountRequestDtoValidator)
+	getAccountRequestDtoValidator.RegisterStructValidation(getAccountRequestDtoStructValidation, GetAccountRequestDto{})
+}
+
+// getAccountRequestDtoStructValidation reports relevance sort without search, there is nothing to score
+func getAccountRequestDtoStructValidation(sl validator.StructLevel) {
+	dto := sl.Current().Interface().(GetAccountRequestDto)
+	if dto.Sort == ACCOUNT_SORT_RELEVANCE && strings.TrimSpace(dto.Search) == "" {
+		sl.ReportError(dto.Sort, "Sort", "Sort", "required_with", "Search")
+	}
 }
 
 func getAccountRequestDtoDefaultValues(dto *GetAccountRequestDto) {
@@ -65,6 +127,12 @@ func CreateGetAccountRequestDto(c *gin.Context) (GetAccountRequestDto, error) {
 		errorMessage := GetAccountRequestDtoQueryParseErrorMessage(err)
 		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
 	}
+	// Parse the metadata filters
+	metaFilters, errorMessage := CreateAccountMetaFilters(c.Request.URL.Query())
+	if errorMessage != "" {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	dto.MetaFilters = metaFilters
 	// Set default values
 	getAccountRequestDtoDefaultValues(&dto)
 	// Validate the DTO
@@ -74,7 +142,6 @@ func CreateGetAccountRequestDto(c *gin.Context) (GetAccountRequestDto, error) {
 			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
 		}
 	}
-	dto.Status = entities.AccountStatus(strings.Trim(string(dto.Status), "\""))
 	return dto, nil
 }
 
@@ -98,10 +165,46 @@ func GetAccountRequestDtoValidateErrorMessage(err validator.FieldError) string {
 		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
 	} else if err.Field() == "Offset" && err.Tag() == "min" {
 		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
-	} else if err.Field() == "Status" && err.Tag() == "AccountStatusValidation" {
+	} else if err.Field() == "Status" && err.Tag() == "AccountStatusListValidation" {
 		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountStatusList, ","))
+	} else if err.Field() == "Search" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else if err.Field() == "BalanceKind" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountBalanceKindList, ","))
+	} else if (err.Field() == "BalanceMin" || err.Field() == "BalanceMax") && err.Tag() == "AccountBalanceBoundValidation" {
+		errorMessage = fmt.Sprintf("%s must be a decimal number, non-negative for the confirmed balance", err.Field())
+	} else if (err.Field() == "RankMin" || err.Field() == "RankMax") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else if (err.Field() == "RankMin" || err.Field() == "RankMax") && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if (err.Field() == "CreatedFrom" || err.Field() == "CreatedTo" || err.Field() == "UpdatedFrom" || err.Field() == "UpdatedTo") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be a unix timestamp greater than or equal %s", err.Field(), err.Param())
+	} else if err.Tag() == "ltefield" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Chain" && err.Tag() == "AccountChainValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountChainList, ","))
+	} else if err.Field() == "Network" && err.Tag() == "AccountNetworkValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountNetworkList, ","))
+	} else if err.Field() == "AddressType" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountAddressTypeList, ","))
+	} else if err.Field() == "Tags" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else if err.Field() == "Tags" && err.Tag() == "TagNameListValidation" {
+		errorMessage = fmt.Sprintf("%s must be a comma-separated list of tag names", err.Field())
+	} else if err.Field() == "TagMode" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), "any,all")
 	} else if err.Field() == "OrderBy" && err.Tag() == "max" {
 		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else if err.Field() == "Sort" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), ACCOUNT_SORT_RELEVANCE)
+	} else if err.Field() == "Sort" && err.Tag() == "required_with" {
+		errorMessage = fmt.Sprintf("%s %s requires %s", err.Field(), ACCOUNT_SORT_RELEVANCE, err.Param())
+	} else if err.Field() == "Cursor" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else if err.Field() == "Cursor" && err.Tag() == "excluded_with" {
+		errorMessage = fmt.Sprintf("%s can not be used together with %s", err.Field(), err.Param())
+	} else if err.Field() == "Fields" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
 	} else {
 		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
 	}
@@ -1,6 +1,7 @@
 package accountModuleDto
 
 import (
+	"go-gin-test-job/src/database"
 	"go-gin-test-job/src/database/entities"
 )
 
@@ -9,16 +10,34 @@ type GetAccountResponseDto struct {
 	Count  int          `json:"count"`
 	Total  int64        `json:"total"`
 	List   []AccountDto `json:"list"`
+	// NextCursor is set when there are more rows after the list, pass it as cursor to get them
+	NextCursor *string `json:"nextCursor" example:"eyJvIjoiaWQgQVNDIiwidiI6WyI0Il19"`
 }
 
-func CreateGetAccountResponseDto(offset int, count int, total int64, accounts []*entities.Account) GetAccountResponseDto {
+func CreateGetAccountResponseDto(offset int, count int, total int64, accounts []*entities.Account, nextCursor *string, fields []string) GetAccountResponseDto {
 	var dto GetAccountResponseDto
 	dto.Offset = offset
 	dto.Count = count
 	dto.Total = total
+	dto.NextCursor = nextCursor
 	dto.List = make([]AccountDto, 0)
 	for _, account := range accounts {
-		dto.List = append(dto.List, CreateAccountDto(account))
+		dto.List = append(dto.List, CreateAccountDto(account).WithFields(fields))
+	}
+	return dto
+}
+
+func CreateGetAccountByRelevanceResponseDto(offset int, count int, total int64, accounts []*database.AccountWithScore, fields []string) GetAccountResponseDto {
+	var dto GetAccountResponseDto
+	dto.Offset = offset
+	dto.Count = count
+	dto.Total = total
+	dto.List = make([]AccountDto, 0)
+	for _, account := range accounts {
+		accountDto := CreateAccountDto(&account.Account).WithFields(fields)
+		score := account.Score
+		accountDto.Score = &score
+		dto.List = append(dto.List, accountDto)
 	}
 	return dto
 }
@@ -0,0 +1,99 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	stringUtil "go-gin-test-job/src/utils/string"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const DEFAULT_ACCOUNT_STATS_RANK_BUCKET_SIZE = 10
+const DEFAULT_ACCOUNT_STATS_TOP = 10
+const DEFAULT_ACCOUNT_STATS_STALE_AFTER = 3600
+
+// GetAccountStatsRequestDto has the filters of GetAccountRequestDto and the stats options
+type GetAccountStatsRequestDto struct {
+	AccountFilterRequestDto
+	RankBucketSize int   `form:"rankBucketSize" json:"rankBucketSize" validate:"min=1,max=100" default:"10" example:"25"`
+	Top            int   `form:"top" json:"top" validate:"min=1,max=100" default:"10" example:"5"`
+	StaleAfter     int64 `form:"staleAfter" json:"staleAfter" validate:"min=1" default:"3600" example:"600"`
+}
+
+var getAccountStatsRequestDtoValidator *validator.Validate
+
+func init() {
+	getAccountStatsRequestDtoValidator = validator.New()
+	registerAccountFilterValidations(getAccountStatsRequestDtoValidator)
+}
+
+func getAccountStatsRequestDtoDefaultValues(dto *GetAccountStatsRequestDto) {
+	if dto.RankBucketSize == 0 {
+		dto.RankBucketSize = DEFAULT_ACCOUNT_STATS_RANK_BUCKET_SIZE
+	}
+	if dto.Top == 0 {
+		dto.Top = DEFAULT_ACCOUNT_STATS_TOP
+	}
+	if dto.StaleAfter == 0 {
+		dto.StaleAfter = DEFAULT_ACCOUNT_STATS_STALE_AFTER
+	}
+}
+
+func validateGetAccountStatsRequestDto(dto *GetAccountStatsRequestDto) error {
+	return getAccountStatsRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAccountStatsRequestDto is the Gin version of handling the request
+func CreateGetAccountStatsRequestDto(c *gin.Context) (GetAccountStatsRequestDto, error) {
+	var dto GetAccountStatsRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := GetAccountStatsRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Parse the metadata filters
+	metaFilters, errorMessage := CreateAccountMetaFilters(c.Request.URL.Query())
+	if errorMessage != "" {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	dto.MetaFilters = metaFilters
+	// Set default values
+	getAccountStatsRequestDtoDefaultValues(&dto)
+	// Validate the DTO
+	if err := validateGetAccountStatsRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAccountStatsRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func GetAccountStatsRequestDtoQueryParseErrorMessage(err error) string {
+	var errorMessage string
+	if stringUtil.CaseInsensitiveContains(err.Error(), "\"rankBucketSize\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".rankBucketSize") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("rankBucketSize")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"top\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".top") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("top")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"staleAfter\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".staleAfter") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("staleAfter")
+	} else {
+		errorMessage = errorMessages.DefaultQueryParseErrorMessage()
+	}
+	return errorMessage
+}
+
+func GetAccountStatsRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if (err.Field() == "RankBucketSize" || err.Field() == "Top" || err.Field() == "StaleAfter") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else if (err.Field() == "RankBucketSize" || err.Field() == "Top") && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else {
+		// Filters are shared with the account list request
+		errorMessage = GetAccountRequestDtoValidateErrorMessage(err)
+	}
+	return errorMessage
+}
@@ -0,0 +1,122 @@
+package accountModuleDto
+
+import (
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	rankValidationUtil "go-gin-test-job/src/utils/rank-validation"
+)
+
+// AccountStatusStatsDto Balance is in Currency, the currency of the chain
+type AccountStatusStatsDto struct {
+	Status   entities.AccountStatus `json:"status" example:"Active"`
+	Chain    entities.AccountChain  `json:"chain" example:"BTC"`
+	Currency string                 `json:"currency" example:"BTC"`
+	Count    int64                  `json:"count" example:"2"`
+	Balance  string                 `json:"balance" example:"0.96281062"`
+}
+
+// AccountChainStatsDto Balance is in Currency, the currency of the chain
+type AccountChainStatsDto struct {
+	Chain    entities.AccountChain `json:"chain" example:"BTC"`
+	Currency string                `json:"currency" example:"BTC"`
+	Count    int64                 `json:"count" example:"2"`
+	Balance  string                `json:"balance" example:"0.96281062"`
+}
+
+// AccountChainTopDto is the accounts of the chain with the highest balance, the highest first
+type AccountChainTopDto struct {
+	Chain    entities.AccountChain `json:"chain" example:"BTC"`
+	Currency string                `json:"currency" example:"BTC"`
+	Accounts []AccountDto          `json:"accounts"`
+}
+
+// AccountRankBucketDto counts the accounts with rank from From to To, both inclusive
+type AccountRankBucketDto struct {
+	From  int   `json:"from" example:"1"`
+	To    int   `json:"to" example:"10"`
+	Count int64 `json:"count" example:"1"`
+}
+
+type AccountStaleStatsDto struct {
+	StaleAfter int64 `json:"staleAfter" example:"3600"`
+	Count      int64 `json:"count" example:"1"`
+}
+
+// GetAccountStatsResponseDto the balances are summed per chain only, the chains have different currencies
+type GetAccountStatsResponseDto struct {
+	Count            int64                   `json:"count" example:"4"`
+	ByStatus         []AccountStatusStatsDto `json:"byStatus"`
+	ByChain          []AccountChainStatsDto  `json:"byChain"`
+	RankBucketSize   int                     `json:"rankBucketSize" example:"10"`
+	RankHistogram    []AccountRankBucketDto  `json:"rankHistogram"`
+	TopByBalance     []AccountChainTopDto    `json:"topByBalance"`
+	ZeroBalanceCount int64                   `json:"zeroBalanceCount" example:"1"`
+	Stale            AccountStaleStatsDto    `json:"stale"`
+}
+
+func CreateGetAccountStatsResponseDto(
+	statusStats []*database.AccountStatusStats,
+	chainStats []*database.AccountChainStats,
+	rankBuckets []*database.AccountRankBucket,
+	rankBucketSize int,
+	topAccounts map[entities.AccountChain][]*entities.Account,
+	countStats database.AccountCountStats,
+	staleAfter int64,
+) GetAccountStatsResponseDto {
+	var dto GetAccountStatsResponseDto
+	dto.ByStatus = make([]AccountStatusStatsDto, 0)
+	for _, stats := range statusStats {
+		dto.Count += stats.Count
+		dto.ByStatus = append(dto.ByStatus, AccountStatusStatsDto{
+			Status:   stats.Status,
+			Chain:    stats.Chain,
+			Currency: stats.Chain.GetCurrency(),
+			Count:    stats.Count,
+			Balance:  stats.Balance.String(),
+		})
+	}
+	dto.ByChain = make([]AccountChainStatsDto, 0)
+	dto.TopByBalance = make([]AccountChainTopDto, 0)
+	for _, stats := range chainStats {
+		dto.ByChain = append(dto.ByChain, AccountChainStatsDto{
+			Chain:    stats.Chain,
+			Currency: stats.Chain.GetCurrency(),
+			Count:    stats.Count,
+			Balance:  stats.Balance.String(),
+		})
+		chainTopDto := AccountChainTopDto{
+			Chain:    stats.Chain,
+			Currency: stats.Chain.GetCurrency(),
+			Accounts: make([]AccountDto, 0),
+		}
+		for _, account := range topAccounts[stats.Chain] {
+			chainTopDto.Accounts = append(chainTopDto.Accounts, CreateAccountDto(account))
+		}
+		dto.TopByBalance = append(dto.TopByBalance, chainTopDto)
+	}
+	dto.RankBucketSize = rankBucketSize
+	dto.RankHistogram = CreateAccountRankHistogramDto(rankBuckets, rankBucketSize)
+	dto.ZeroBalanceCount = countStats.ZeroBalanceCount
+	dto.Stale = AccountStaleStatsDto{
+		StaleAfter: staleAfter,
+		Count:      countStats.StaleCount,
+	}
+	return dto
+}
+
+// CreateAccountRankHistogramDto covers the whole rank range, the buckets without accounts have zero count
+func CreateAccountRankHistogramDto(rankBuckets []*database.AccountRankBucket, rankBucketSize int) []AccountRankBucketDto {
+	counts := make(map[int]int64)
+	for _, rankBucket := range rankBuckets {
+		counts[rankBucket.Bucket] = rankBucket.Count
+	}
+	histogram := make([]AccountRankBucketDto, 0)
+	for bucket := 0; bucket*rankBucketSize < rankValidationUtil.MAX_RANK; bucket++ {
+		histogram = append(histogram, AccountRankBucketDto{
+			From:  bucket*rankBucketSize + rankValidationUtil.MIN_RANK,
+			To:    min((bucket+1)*rankBucketSize, rankValidationUtil.MAX_RANK),
+			Count: counts[bucket],
+		})
+	}
+	return histogram
+}
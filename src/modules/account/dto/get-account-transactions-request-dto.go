@@ -0,0 +1,97 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	orderUtil "go-gin-test-job/src/utils/order"
+	stringUtil "go-gin-test-job/src/utils/string"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const DEFAULT_ACCOUNT_TRANSACTIONS_COUNT = 100
+
+type GetAccountTransactionsRequestDto struct {
+	Direction string `form:"direction" json:"direction" validate:"omitempty,oneof=in out" enums:"in,out" example:"in"`
+	Count     int    `form:"count" json:"count" validate:"min=1,max=1000" default:"100" example:"20"`
+	Cursor    string `form:"cursor" json:"cursor" validate:"omitempty,max=1024" example:"eyJvIjoidGltZSBERVNDLGlkIERFU0MiLCJ2IjpbMTcwMDAwMDAwMCw0Ml19"`
+}
+
+var getAccountTransactionsRequestDtoValidator *validator.Validate
+
+func init() {
+	getAccountTransactionsRequestDtoValidator = validator.New()
+}
+
+func getAccountTransactionsRequestDtoDefaultValues(dto *GetAccountTransactionsRequestDto) {
+	if dto.Count == 0 {
+		dto.Count = DEFAULT_ACCOUNT_TRANSACTIONS_COUNT
+	}
+}
+
+func validateGetAccountTransactionsRequestDto(dto *GetAccountTransactionsRequestDto) error {
+	return getAccountTransactionsRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAccountTransactionsRequestDto is the Gin version of handling the request
+func CreateGetAccountTransactionsRequestDto(c *gin.Context) (GetAccountTransactionsRequestDto, error) {
+	var dto GetAccountTransactionsRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := GetAccountTransactionsRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Set default values
+	getAccountTransactionsRequestDtoDefaultValues(&dto)
+	// Validate the DTO
+	if err := validateGetAccountTransactionsRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAccountTransactionsRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// GetOrderParams returns the fixed newest first order the cursor is bound to
+func (dto *GetAccountTransactionsRequestDto) GetOrderParams() []orderUtil.OrderParam {
+	return []orderUtil.OrderParam{{Field: "time", Direction: "DESC"}, {Field: "id", Direction: "DESC"}}
+}
+
+func (dto *GetAccountTransactionsRequestDto) CreateTransactionFilter(accountId int64) database.TransactionFilter {
+	return database.TransactionFilter{
+		AccountId: accountId,
+		Direction: entities.TransactionDirection(dto.Direction),
+	}
+}
+
+func GetAccountTransactionsRequestDtoQueryParseErrorMessage(err error) string {
+	var errorMessage string
+	if stringUtil.CaseInsensitiveContains(err.Error(), "\"count\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".count") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("count")
+	} else {
+		errorMessage = errorMessages.DefaultQueryParseErrorMessage()
+	}
+	return errorMessage
+}
+
+func GetAccountTransactionsRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Direction" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.TransactionDirectionList, ","))
+	} else if err.Field() == "Count" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Count" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Cursor" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
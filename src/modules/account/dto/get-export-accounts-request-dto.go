@@ -0,0 +1,125 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AccountExportFormat string
+
+const (
+	AccountExportFormatCsv    AccountExportFormat = "csv"
+	AccountExportFormatTsv    AccountExportFormat = "tsv"
+	AccountExportFormatNdjson AccountExportFormat = "ndjson"
+)
+
+var AccountExportFormatContentType = map[AccountExportFormat]string{
+	AccountExportFormatCsv:    "text/csv",
+	AccountExportFormatTsv:    "text/tab-separated-values",
+	AccountExportFormatNdjson: "application/x-ndjson",
+}
+
+// GetExportAccountsRequestDto has the filters of GetAccountRequestDto without paging
+type GetExportAccountsRequestDto struct {
+	AccountFilterRequestDto
+	OrderBy string              `form:"orderBy" json:"orderBy" validate:"omitempty,max=255" example:"id ASC"`
+	Format  AccountExportFormat `form:"format" json:"format" validate:"omitempty,oneof=csv tsv ndjson" enums:"csv,tsv,ndjson" example:"csv"`
+	Fields  string              `form:"fields" json:"fields" validate:"omitempty,max=255" example:"id,address,balance"`
+}
+
+var getExportAccountsRequestDtoValidator *validator.Validate
+
+func init() {
+	getExportAccountsRequestDtoValidator = validator.New()
+	registerAccountFilterValidations(getExportAccountsRequestDtoValidator)
+}
+
+func getExportAccountsRequestDtoDefaultValues(c *gin.Context, dto *GetExportAccountsRequestDto) {
+	if dto.Format == "" {
+		dto.Format = getAccountExportFormatFromAccept(c.GetHeader("Accept"))
+	}
+}
+
+func validateGetExportAccountsRequestDto(dto *GetExportAccountsRequestDto) error {
+	return getExportAccountsRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetExportAccountsRequestDto is the Gin version of handling the request
+func CreateGetExportAccountsRequestDto(c *gin.Context) (GetExportAccountsRequestDto, error) {
+	var dto GetExportAccountsRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := GetExportAccountsRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Parse the metadata filters
+	metaFilters, errorMessage := CreateAccountMetaFilters(c.Request.URL.Query())
+	if errorMessage != "" {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	dto.MetaFilters = metaFilters
+	// Set default values
+	getExportAccountsRequestDtoDefaultValues(c, &dto)
+	// Validate the DTO
+	if err := validateGetExportAccountsRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetExportAccountsRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// getAccountExportFormatFromAccept picks the first supported media type of the Accept header, csv by default
+func getAccountExportFormatFromAccept(accept string) AccountExportFormat {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		for format, contentType := range AccountExportFormatContentType {
+			if mediaType == contentType {
+				return format
+			}
+		}
+		if mediaType == "application/ndjson" {
+			return AccountExportFormatNdjson
+		}
+	}
+	return AccountExportFormatCsv
+}
+
+// GetAccountExportFieldsSecure parses the comma-separated field list against the export whitelist, all fields by default
+func GetAccountExportFieldsSecure(c *gin.Context, data string) ([]string, error) {
+	fields, err := GetAccountFieldsSecure(c, data, AccountExportFieldList)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return AccountExportFieldList, nil
+	}
+	return fields, nil
+}
+
+func GetExportAccountsRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func GetExportAccountsRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Format" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), "csv,tsv,ndjson")
+	} else if err.Field() == "Fields" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else {
+		// Filters and orderBy are shared with the account list request
+		errorMessage = GetAccountRequestDtoValidateErrorMessage(err)
+	}
+	return errorMessage
+}
@@ -0,0 +1,103 @@
+package accountModuleDto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+	"go-gin-test-job/src/database/entities"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PatchUpdateAccountRequestDto follows JSON merge-patch semantics (RFC 7396):
+// omitted fields stay unchanged, "memo": null clears the memo. Metadata is merged into the stored object
+// the same way, "metadata": null clears it
+type PatchUpdateAccountRequestDto struct {
+	Name          *string                 `json:"name" validate:"omitnil,AccountNameValidation" example:"John Doe"`
+	Rank          *uint8                  `json:"rank" validate:"omitnil,AccountRankValidation" example:"50"`
+	Memo          *string                 `json:"memo" example:"Some memo text"`
+	Status        *entities.AccountStatus `json:"status" validate:"omitnil,AccountStatusValidation" enums:"Pending,Active,Suspended,Archived,Error" example:"Active"`
+	Metadata      json.RawMessage         `json:"metadata" validate:"omitempty,AccountMetadataValidation" swaggertype:"object"`
+	IsMemoSet     bool                    `json:"-" swaggerignore:"true"`
+	IsMetadataSet bool                    `json:"-" swaggerignore:"true"`
+}
+
+// GetMetadataPatch returns the metadata merge patch, nil when the metadata is cleared with null
+func (dto *PatchUpdateAccountRequestDto) GetMetadataPatch() *string {
+	return getAccountMetadata(dto.Metadata)
+}
+
+// Fields which can not be cleared with an explicit null
+var patchUpdateAccountNotNullFields = []string{"name", "rank", "status"}
+
+var patchUpdateAccountRequestDtoValidator *validator.Validate
+
+func init() {
+	patchUpdateAccountRequestDtoValidator = validator.New()
+	_ = patchUpdateAccountRequestDtoValidator.RegisterValidation("AccountStatusValidation", validations.AccountStatusValidation)
+	_ = patchUpdateAccountRequestDtoValidator.RegisterValidation("AccountRankValidation", validations.AccountRankValidation)
+	_ = patchUpdateAccountRequestDtoValidator.RegisterValidation("AccountNameValidation", validations.AccountNameValidation)
+	_ = patchUpdateAccountRequestDtoValidator.RegisterValidation("AccountMetadataValidation", validations.AccountMetadataValidation)
+}
+
+func validatePatchUpdateAccountRequestDto(dto *PatchUpdateAccountRequestDto) error {
+	return patchUpdateAccountRequestDtoValidator.Struct(dto)
+}
+
+// CreatePatchUpdateAccountRequestDto is the Gin version for handling the request
+func CreatePatchUpdateAccountRequestDto(c *gin.Context) (PatchUpdateAccountRequestDto, error) {
+	var dto PatchUpdateAccountRequestDto
+	body, err := c.GetRawData()
+	if err != nil {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessages.DefaultQueryParseErrorMessage())
+	}
+	// Collect the present keys to tell an omitted field from an explicit null
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return dto, errorHelpers.RespondBadRequestError(c, PatchUpdateAccountRequestDtoQueryParseErrorMessage(err))
+	}
+	for _, field := range patchUpdateAccountNotNullFields {
+		if value, exists := fields[field]; exists && bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			return dto, errorHelpers.RespondBadRequestError(c, fmt.Sprintf("%s must not be null", field))
+		}
+	}
+	// Parse body params into DTO
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return dto, errorHelpers.RespondBadRequestError(c, PatchUpdateAccountRequestDtoQueryParseErrorMessage(err))
+	}
+	_, dto.IsMemoSet = fields["memo"]
+	_, dto.IsMetadataSet = fields["metadata"]
+	// Validate the DTO
+	if err := validatePatchUpdateAccountRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PatchUpdateAccountRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func PatchUpdateAccountRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PatchUpdateAccountRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Status" && err.Tag() == "AccountStatusValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountStatusList, ","))
+	} else if err.Field() == "Rank" && err.Tag() == "AccountRankValidation" {
+		errorMessage = fmt.Sprintf("%s must be between 0 and 100", err.Field())
+	} else if err.Field() == "Name" && err.Tag() == "AccountNameValidation" {
+		errorMessage = fmt.Sprintf("%s must be between 1 and 255 characters", err.Field())
+	} else if err.Field() == "Metadata" && err.Tag() == "AccountMetadataValidation" {
+		errorMessage = AccountMetadataErrorMessage(err.Field())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -1,33 +1,54 @@
 package accountModuleDto
 
 import (
+	"encoding/json"
 	"fmt"
 	errorHelpers "go-gin-test-job/src/common/error-helpers"
 	errorMessages "go-gin-test-job/src/common/error-messages"
 	"go-gin-test-job/src/common/validations"
 	"go-gin-test-job/src/database/entities"
+	addressValidationUtil "go-gin-test-job/src/utils/address-validation"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
+// PostCreateAccountRequestDto Chain is BTC and Network is mainnet when they are omitted, the address is validated
+// for the chain network. Metadata is a JSON object of the integration data like a CRM id
 type PostCreateAccountRequestDto struct {
-	Address string                 `json:"address" validate:"AccountAddressValidation" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
-	Name    string                 `json:"name" validate:"AccountNameValidation" example:"John Doe"`
-	Rank    uint8                  `json:"rank" validate:"AccountRankValidation" example:"50"`
-	Memo    string                 `json:"memo" example:"Some memo text"`
-	Status  entities.AccountStatus `json:"status" validate:"AccountStatusValidation" enums:"On,Off" example:"On"`
+	Chain    entities.AccountChain   `json:"chain" validate:"AccountChainValidation" enums:"BTC,LTC,BCH,DOGE" example:"BTC"`
+	Network  entities.AccountNetwork `json:"network" validate:"AccountNetworkValidation,AccountChainNetworkValidation" enums:"mainnet,testnet,signet,regtest" example:"mainnet"`
+	Address  string                  `json:"address" validate:"AccountAddressValidation" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
+	Name     string                  `json:"name" validate:"AccountNameValidation" example:"John Doe"`
+	Rank     uint8                   `json:"rank" validate:"AccountRankValidation" example:"50"`
+	Memo     string                  `json:"memo" example:"Some memo text"`
+	Metadata json.RawMessage         `json:"metadata" validate:"omitempty,AccountMetadataValidation" swaggertype:"object"`
+	Status   entities.AccountStatus  `json:"status" validate:"AccountInitialStatusValidation" enums:"Pending,Active,Suspended" example:"Pending"`
 }
 
 var postCreateAccountRequestDtoValidator *validator.Validate
 
 func init() {
 	postCreateAccountRequestDtoValidator = validator.New()
+	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountChainValidation", validations.AccountChainValidation)
+	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountNetworkValidation", validations.AccountNetworkValidation)
+	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountChainNetworkValidation", validations.AccountChainNetworkValidation)
 	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountAddressValidation", validations.AccountAddressValidation)
-	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountStatusValidation", validations.AccountStatusValidation)
+	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountInitialStatusValidation", validations.AccountInitialStatusValidation)
 	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountRankValidation", validations.AccountRankValidation)
 	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountNameValidation", validations.AccountNameValidation)
+	_ = postCreateAccountRequestDtoValidator.RegisterValidation("AccountMetadataValidation", validations.AccountMetadataValidation)
+}
+
+// IsMetadataSet tells if the metadata was sent, null included
+func (dto *PostCreateAccountRequestDto) IsMetadataSet() bool {
+	return len(dto.Metadata) > 0
+}
+
+// GetMetadata returns the metadata JSON to store, nil when it is omitted or null
+func (dto *PostCreateAccountRequestDto) GetMetadata() *string {
+	return getAccountMetadata(dto.Metadata)
 }
 
 func validatePostCreateAccountRequestDto(dto *PostCreateAccountRequestDto) error {
@@ -43,13 +64,28 @@ func CreatePostCreateAccountRequestDto(c *gin.Context) (PostCreateAccountRequest
 		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
 	}
 	// Validate the DTO
-	if err := validatePostCreateAccountRequestDto(&dto); err != nil {
+	if errorMessage := GetPostCreateAccountRequestDtoErrorMessage(&dto); errorMessage != "" {
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	return dto, nil
+}
+
+// GetPostCreateAccountRequestDtoErrorMessage validates the DTO and returns the first error message, empty when valid.
+// The address is validated as it was sent and is normalized for the storage once it is valid
+func GetPostCreateAccountRequestDtoErrorMessage(dto *PostCreateAccountRequestDto) string {
+	if dto.Chain == "" {
+		dto.Chain = entities.AccountChainBtc
+	}
+	if dto.Network == "" {
+		dto.Network = entities.AccountNetworkMainnet
+	}
+	if err := validatePostCreateAccountRequestDto(dto); err != nil {
 		for _, err := range err.(validator.ValidationErrors) {
-			errorMessage := PostCreateAccountRequestDtoValidateErrorMessage(err)
-			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+			return PostCreateAccountRequestDtoValidateErrorMessage(err)
 		}
 	}
-	return dto, nil
+	dto.Address = addressValidationUtil.NormalizeAddress(addressValidationUtil.Chain(dto.Chain), addressValidationUtil.Network(dto.Network), dto.Address)
+	return ""
 }
 
 func PostCreateAccountRequestDtoQueryParseErrorMessage(err error) string {
@@ -58,14 +94,22 @@ func PostCreateAccountRequestDtoQueryParseErrorMessage(err error) string {
 
 func PostCreateAccountRequestDtoValidateErrorMessage(err validator.FieldError) string {
 	var errorMessage string
-	if err.Field() == "Address" && err.Tag() == "AccountAddressValidation" {
+	if err.Field() == "Chain" && err.Tag() == "AccountChainValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountChainList, ","))
+	} else if err.Field() == "Network" && err.Tag() == "AccountNetworkValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountNetworkList, ","))
+	} else if err.Field() == "Network" && err.Tag() == "AccountChainNetworkValidation" {
+		errorMessage = fmt.Sprintf("%s %v is not supported by the chain", err.Field(), err.Value())
+	} else if err.Field() == "Address" && err.Tag() == "AccountAddressValidation" {
 		errorMessage = fmt.Sprintf("%s format is wrong", err.Field())
-	} else if err.Field() == "Status" && err.Tag() == "AccountStatusValidation" {
-		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountStatusList, ","))
+	} else if err.Field() == "Status" && err.Tag() == "AccountInitialStatusValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountInitialStatusList, ","))
 	} else if err.Field() == "Rank" && err.Tag() == "AccountRankValidation" {
 		errorMessage = fmt.Sprintf("%s must be between 0 and 100", err.Field())
 	} else if err.Field() == "Name" && err.Tag() == "AccountNameValidation" {
 		errorMessage = fmt.Sprintf("%s must be between 1 and 255 characters", err.Field())
+	} else if err.Field() == "Metadata" && err.Tag() == "AccountMetadataValidation" {
+		errorMessage = AccountMetadataErrorMessage(err.Field())
 	} else {
 		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
 	}
@@ -0,0 +1,82 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AccountBulkMode string
+
+const (
+	// AccountBulkModeAtomic creates all items in a single transaction or none of them
+	AccountBulkModeAtomic AccountBulkMode = "atomic"
+	// AccountBulkModeBestEffort creates every item which can be created
+	AccountBulkModeBestEffort AccountBulkMode = "bestEffort"
+)
+
+type PostCreateAccountsBulkRequestDto struct {
+	Mode  AccountBulkMode               `json:"mode" validate:"oneof=atomic bestEffort" enums:"atomic,bestEffort" default:"atomic" example:"atomic"`
+	Items []PostCreateAccountRequestDto `json:"items" validate:"min=1"`
+}
+
+var postCreateAccountsBulkRequestDtoValidator *validator.Validate
+
+func init() {
+	postCreateAccountsBulkRequestDtoValidator = validator.New()
+}
+
+func postCreateAccountsBulkRequestDtoDefaultValues(dto *PostCreateAccountsBulkRequestDto) {
+	if dto.Mode == "" {
+		dto.Mode = AccountBulkModeAtomic
+	}
+}
+
+func validatePostCreateAccountsBulkRequestDto(dto *PostCreateAccountsBulkRequestDto) error {
+	return postCreateAccountsBulkRequestDtoValidator.Struct(dto)
+}
+
+// CreatePostCreateAccountsBulkRequestDto is the Gin version for handling the request.
+// Items are validated one by one by the service so every item gets its own result
+func CreatePostCreateAccountsBulkRequestDto(c *gin.Context) (PostCreateAccountsBulkRequestDto, error) {
+	var dto PostCreateAccountsBulkRequestDto
+	// Parse body params into DTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		errorMessage := PostCreateAccountsBulkRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Set default values
+	postCreateAccountsBulkRequestDtoDefaultValues(&dto)
+	// Validate the DTO
+	if err := validatePostCreateAccountsBulkRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PostCreateAccountsBulkRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	if len(dto.Items) > config.AppConfig.BulkAccountMax {
+		errorMessage := fmt.Sprintf("Items must contain at most %d elements", config.AppConfig.BulkAccountMax)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	return dto, nil
+}
+
+func PostCreateAccountsBulkRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PostCreateAccountsBulkRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Mode" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), "atomic,bestEffort")
+	} else if err.Field() == "Items" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must contain at least %s element", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -0,0 +1,43 @@
+package accountModuleDto
+
+type AccountBulkItemStatus string
+
+const (
+	AccountBulkItemStatusCreated  AccountBulkItemStatus = "created"
+	AccountBulkItemStatusConflict AccountBulkItemStatus = "conflict"
+	AccountBulkItemStatusInvalid  AccountBulkItemStatus = "invalid"
+	// AccountBulkItemStatusSkipped marks valid items rolled back because another item of an atomic request failed
+	AccountBulkItemStatusSkipped AccountBulkItemStatus = "skipped"
+)
+
+type PostCreateAccountsBulkItemResultDto struct {
+	Index   int                   `json:"index" example:"0"`
+	Address string                `json:"address" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
+	Status  AccountBulkItemStatus `json:"status" enums:"created,conflict,invalid,skipped" example:"created"`
+	Message string                `json:"message,omitempty" example:"Address already exists"`
+	Account *AccountDto           `json:"account,omitempty"`
+}
+
+type PostCreateAccountsBulkResponseDto struct {
+	Mode    AccountBulkMode                       `json:"mode" example:"atomic"`
+	Total   int                                   `json:"total" example:"2"`
+	Created int                                   `json:"created" example:"1"`
+	Failed  int                                   `json:"failed" example:"1"`
+	Items   []PostCreateAccountsBulkItemResultDto `json:"items"`
+}
+
+func CreatePostCreateAccountsBulkResponseDto(mode AccountBulkMode, items []PostCreateAccountsBulkItemResultDto) PostCreateAccountsBulkResponseDto {
+	var dto PostCreateAccountsBulkResponseDto
+	dto.Mode = mode
+	dto.Total = len(items)
+	dto.Items = items
+	for _, item := range items {
+		switch item.Status {
+		case AccountBulkItemStatusCreated:
+			dto.Created++
+		case AccountBulkItemStatusConflict, AccountBulkItemStatusInvalid:
+			dto.Failed++
+		}
+	}
+	return dto
+}
@@ -0,0 +1,140 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	stringUtil "go-gin-test-job/src/utils/string"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AccountImportFormat string
+
+const (
+	AccountImportFormatCsv    AccountImportFormat = "csv"
+	AccountImportFormatNdjson AccountImportFormat = "ndjson"
+)
+
+type AccountImportOnExisting string
+
+const (
+	AccountImportOnExistingSkip   AccountImportOnExisting = "skip"
+	AccountImportOnExistingUpdate AccountImportOnExisting = "update"
+)
+
+type PostImportAccountsRequestDto struct {
+	Format     AccountImportFormat     `form:"format" json:"format" validate:"omitempty,oneof=csv ndjson" enums:"csv,ndjson" example:"csv"`
+	DryRun     bool                    `form:"dryRun" json:"dryRun" example:"true"`
+	OnExisting AccountImportOnExisting `form:"onExisting" json:"onExisting" validate:"oneof=skip update" enums:"skip,update" default:"skip" example:"skip"`
+}
+
+var postImportAccountsRequestDtoValidator *validator.Validate
+
+func init() {
+	postImportAccountsRequestDtoValidator = validator.New()
+}
+
+func postImportAccountsRequestDtoDefaultValues(dto *PostImportAccountsRequestDto) {
+	if dto.OnExisting == "" {
+		dto.OnExisting = AccountImportOnExistingSkip
+	}
+}
+
+func validatePostImportAccountsRequestDto(dto *PostImportAccountsRequestDto) error {
+	return postImportAccountsRequestDtoValidator.Struct(dto)
+}
+
+// CreatePostImportAccountsRequestDto is the Gin version of handling the request
+func CreatePostImportAccountsRequestDto(c *gin.Context) (PostImportAccountsRequestDto, error) {
+	var dto PostImportAccountsRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := PostImportAccountsRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Set default values
+	postImportAccountsRequestDtoDefaultValues(&dto)
+	// Validate the DTO
+	if err := validatePostImportAccountsRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PostImportAccountsRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// GetAccountImportFile returns the streamed import file. Multipart requests are read from the "file" part,
+// any other request body is the file itself
+func GetAccountImportFile(c *gin.Context) (io.Reader, string, string, error) {
+	contentType := c.GetHeader("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "multipart/form-data" {
+		return c.Request.Body, contentType, "", nil
+	}
+	multipartReader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, "", "", errorHelpers.RespondBadRequestError(c, errorMessages.DefaultQueryParseErrorMessage())
+	}
+	for {
+		part, err := multipartReader.NextPart()
+		if err == io.EOF {
+			return nil, "", "", errorHelpers.RespondBadRequestError(c, "File is required")
+		}
+		if err != nil {
+			return nil, "", "", errorHelpers.RespondBadRequestError(c, errorMessages.DefaultQueryParseErrorMessage())
+		}
+		if part.FormName() == "file" {
+			return part, part.Header.Get("Content-Type"), part.FileName(), nil
+		}
+	}
+}
+
+// GetAccountImportFormat resolves the file format from the explicit value, the content type or the file name
+func GetAccountImportFormat(format AccountImportFormat, contentType string, fileName string) AccountImportFormat {
+	if format != "" {
+		return format
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return AccountImportFormatCsv
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return AccountImportFormatNdjson
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return AccountImportFormatCsv
+	case ".ndjson", ".jsonl":
+		return AccountImportFormatNdjson
+	}
+	return ""
+}
+
+func PostImportAccountsRequestDtoQueryParseErrorMessage(err error) string {
+	var errorMessage string
+	if stringUtil.CaseInsensitiveContains(err.Error(), "dryRun") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("dryRun")
+	} else {
+		errorMessage = errorMessages.DefaultQueryParseErrorMessage()
+	}
+	return errorMessage
+}
+
+func PostImportAccountsRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Format" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), "csv,ndjson")
+	} else if err.Field() == "OnExisting" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), "skip,update")
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
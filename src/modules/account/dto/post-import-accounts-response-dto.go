@@ -0,0 +1,101 @@
+package accountModuleDto
+
+import (
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database/entities"
+	"strconv"
+)
+
+type AccountImportRowResult string
+
+const (
+	AccountImportRowResultCreated  AccountImportRowResult = "created"
+	AccountImportRowResultUpdated  AccountImportRowResult = "updated"
+	AccountImportRowResultSkipped  AccountImportRowResult = "skipped"
+	AccountImportRowResultRejected AccountImportRowResult = "rejected"
+)
+
+type AccountFieldChangeDto struct {
+	From string `json:"from" example:"Old name"`
+	To   string `json:"to" example:"New name"`
+}
+
+type PostImportAccountsRowResultDto struct {
+	Line    int                              `json:"line" example:"2"`
+	Address string                           `json:"address" example:"1JzfdUygUFk2M6KS3ngFMGRsy5vsH4N37a"`
+	Result  AccountImportRowResult           `json:"result" enums:"created,updated,skipped,rejected" example:"created"`
+	Message string                           `json:"message,omitempty" example:"Address format is wrong"`
+	Changes map[string]AccountFieldChangeDto `json:"changes,omitempty"`
+}
+
+type PostImportAccountsResponseDto struct {
+	DryRun     bool                    `json:"dryRun" example:"true"`
+	OnExisting AccountImportOnExisting `json:"onExisting" example:"skip"`
+	Total      int                     `json:"total" example:"3"`
+	Created    int                     `json:"created" example:"1"`
+	Updated    int                     `json:"updated" example:"0"`
+	Skipped    int                     `json:"skipped" example:"1"`
+	Rejected   int                     `json:"rejected" example:"1"`
+	// Rows lists up to IMPORT_REPORT_ROW_MAX results in the file order, RowsTruncated is set when there were more
+	Rows          []PostImportAccountsRowResultDto `json:"rows"`
+	RowsTruncated bool                             `json:"rowsTruncated" example:"false"`
+	// Aborted is set when the file could not be read up to the end, the rows before AbortedLine are in the report
+	Aborted        bool   `json:"aborted" example:"false"`
+	AbortedLine    int    `json:"abortedLine,omitempty" example:"1200"`
+	AbortedMessage string `json:"abortedMessage,omitempty" example:"Read import file error at line 1200. bufio.Scanner: token too long"`
+}
+
+func CreatePostImportAccountsResponseDto(dto PostImportAccountsRequestDto) *PostImportAccountsResponseDto {
+	return &PostImportAccountsResponseDto{
+		DryRun:     dto.DryRun,
+		OnExisting: dto.OnExisting,
+		Rows:       make([]PostImportAccountsRowResultDto, 0),
+	}
+}
+
+func (dto *PostImportAccountsResponseDto) AddRow(row PostImportAccountsRowResultDto) {
+	dto.Total++
+	switch row.Result {
+	case AccountImportRowResultCreated:
+		dto.Created++
+	case AccountImportRowResultUpdated:
+		dto.Updated++
+	case AccountImportRowResultSkipped:
+		dto.Skipped++
+	case AccountImportRowResultRejected:
+		dto.Rejected++
+	}
+	if len(dto.Rows) >= config.AppConfig.ImportReportRowMax {
+		dto.RowsTruncated = true
+		return
+	}
+	dto.Rows = append(dto.Rows, row)
+}
+
+func (dto *PostImportAccountsResponseDto) Abort(line int, message string) {
+	dto.Aborted = true
+	dto.AbortedLine = line
+	dto.AbortedMessage = message
+}
+
+// CreateAccountImportChanges lists the fields of the account which differ from the imported row
+func CreateAccountImportChanges(account *entities.Account, row PostCreateAccountRequestDto) map[string]AccountFieldChangeDto {
+	changes := make(map[string]AccountFieldChangeDto)
+	if account.Name != row.Name {
+		changes["name"] = AccountFieldChangeDto{From: account.Name, To: row.Name}
+	}
+	if account.Rank != row.Rank {
+		changes["rank"] = AccountFieldChangeDto{From: strconv.Itoa(int(account.Rank)), To: strconv.Itoa(int(row.Rank))}
+	}
+	if account.Memo != row.Memo {
+		changes["memo"] = AccountFieldChangeDto{From: account.Memo, To: row.Memo}
+	}
+	if account.Status != row.Status {
+		changes["status"] = AccountFieldChangeDto{From: string(account.Status), To: string(row.Status)}
+	}
+	// The metadata is replaced only when the row has it
+	if row.IsMetadataSet() && !IsAccountMetadataEqual(account.Metadata, row.GetMetadata()) {
+		changes["metadata"] = AccountFieldChangeDto{From: getAccountMetadataString(account.Metadata), To: getAccountMetadataString(row.GetMetadata())}
+	}
+	return changes
+}
@@ -0,0 +1,68 @@
+package accountModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+	"go-gin-test-job/src/database/entities"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PostTransitionAccountRequestDto moves the account to Status, Reason is stored with the status
+type PostTransitionAccountRequestDto struct {
+	Status entities.AccountStatus `json:"status" validate:"AccountStatusValidation" enums:"Pending,Active,Suspended,Archived,Error" example:"Suspended"`
+	Reason string                 `json:"reason" validate:"NotEmpty,max=255" example:"Owner request"`
+}
+
+var postTransitionAccountRequestDtoValidator *validator.Validate
+
+func init() {
+	postTransitionAccountRequestDtoValidator = validator.New()
+	_ = postTransitionAccountRequestDtoValidator.RegisterValidation("AccountStatusValidation", validations.AccountStatusValidation)
+	_ = postTransitionAccountRequestDtoValidator.RegisterValidation("NotEmpty", validations.NotEmpty)
+}
+
+func validatePostTransitionAccountRequestDto(dto *PostTransitionAccountRequestDto) error {
+	return postTransitionAccountRequestDtoValidator.Struct(dto)
+}
+
+// CreatePostTransitionAccountRequestDto is the Gin version for handling the request
+func CreatePostTransitionAccountRequestDto(c *gin.Context) (PostTransitionAccountRequestDto, error) {
+	var dto PostTransitionAccountRequestDto
+	// Parse body params into DTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		errorMessage := PostTransitionAccountRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	dto.Reason = strings.TrimSpace(dto.Reason)
+	// Validate the DTO
+	if err := validatePostTransitionAccountRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PostTransitionAccountRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func PostTransitionAccountRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PostTransitionAccountRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Status" && err.Tag() == "AccountStatusValidation" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AccountStatusList, ","))
+	} else if err.Field() == "Reason" && err.Tag() == "NotEmpty" {
+		errorMessage = fmt.Sprintf("%s must not be empty", err.Field())
+	} else if err.Field() == "Reason" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
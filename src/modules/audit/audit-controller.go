@@ -0,0 +1,42 @@
+package auditModule
+
+import (
+	auditModuleDto "go-gin-test-job/src/modules/audit/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAuditLogs Get audit log
+// @Summary Get audit log
+// @Description Get the account changes, the newest first. Every entry has the action, the actor (api key identity or cron),
+// @Description the X-Request-ID of the request and the changed fields before and after. Entries are never updated or deleted.
+// @Tags Audit
+// @Accept json
+// @Produce json
+// @Param accountId query int false "Account id" minimum(1)
+// @Param actor query string false "Actor" example(admin)
+// @Param action query string false "Action" Enums("create", "update", "delete", "restore", "purge", "tag_attach", "tag_detach", "transition")
+// @Param from query int false "Created at from, inclusive unix time" minimum(0)
+// @Param to query int false "Created at to, inclusive unix time" minimum(0)
+// @Param count query int false "Number of rows" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "nextCursor of the previous page"
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} auditModuleDto.GetAuditLogsResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Router /audit [get]
+func GetAuditLogs(c *gin.Context) {
+	dto, err := auditModuleDto.CreateGetAuditLogsRequestDto(c)
+	if err != nil {
+		return
+	}
+	var cursorValues []interface{}
+	if dto.Cursor != "" {
+		cursorValues, err = orderUtil.GetCursorValuesSecure(c, dto.Cursor, dto.GetOrderParams())
+		if err != nil {
+			return
+		}
+	}
+	c.JSON(200, getAuditLogs(dto, cursorValues))
+}
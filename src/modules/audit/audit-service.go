@@ -0,0 +1,18 @@
+package auditModule
+
+import (
+	"go-gin-test-job/src/database"
+	auditModuleDto "go-gin-test-job/src/modules/audit/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+)
+
+// getAuditLogs reads one row more than requested to know if there is a next page
+func getAuditLogs(dto auditModuleDto.GetAuditLogsRequestDto, cursorValues []interface{}) auditModuleDto.GetAuditLogsResponseDto {
+	auditLogs := database.GetAuditLogs(dto.CreateAuditLogFilter(), cursorValues, dto.Count+1)
+	if len(auditLogs) <= dto.Count {
+		return auditModuleDto.CreateGetAuditLogsResponseDto(auditLogs, nil)
+	}
+	auditLogs = auditLogs[:dto.Count]
+	nextCursor := orderUtil.EncodeCursor(dto.GetOrderParams(), []interface{}{auditLogs[dto.Count-1].Id})
+	return auditModuleDto.CreateGetAuditLogsResponseDto(auditLogs, &nextCursor)
+}
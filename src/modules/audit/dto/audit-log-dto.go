@@ -0,0 +1,54 @@
+package auditModuleDto
+
+import (
+	"encoding/json"
+	"go-gin-test-job/src/database/entities"
+)
+
+type AuditLogDto struct {
+	Id        int64  `json:"id" example:"1"`
+	AccountId int64  `json:"account_id" example:"1"`
+	Action    string `json:"action" example:"update"`
+	Actor     string `json:"actor" example:"admin"`
+	RequestId string `json:"request_id" example:"3b241101-e2bb-4255-8caf-4136c566a962"`
+	// Before and After hold the changed fields only, they are null for a create and a purge respectively
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt int64           `json:"created_at" example:"1600000000"`
+}
+
+func createAuditLogJson(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*value)
+}
+
+func CreateAuditLogDto(auditLog *entities.AuditLog) AuditLogDto {
+	return AuditLogDto{
+		Id:        auditLog.Id,
+		AccountId: auditLog.AccountId,
+		Action:    string(auditLog.Action),
+		Actor:     auditLog.Actor,
+		RequestId: auditLog.RequestId,
+		Before:    createAuditLogJson(auditLog.Before),
+		After:     createAuditLogJson(auditLog.After),
+		CreatedAt: auditLog.CreatedAt,
+	}
+}
+
+type GetAuditLogsResponseDto struct {
+	List []AuditLogDto `json:"list"`
+	// NextCursor is set when there are more rows after the list, pass it as cursor to get them
+	NextCursor *string `json:"nextCursor" example:"eyJvIjoiaWQgREVTQyIsInYiOlsiMTAiXX0"`
+}
+
+func CreateGetAuditLogsResponseDto(auditLogs []*entities.AuditLog, nextCursor *string) GetAuditLogsResponseDto {
+	var dto GetAuditLogsResponseDto
+	dto.NextCursor = nextCursor
+	dto.List = make([]AuditLogDto, 0)
+	for _, auditLog := range auditLogs {
+		dto.List = append(dto.List, CreateAuditLogDto(auditLog))
+	}
+	return dto
+}
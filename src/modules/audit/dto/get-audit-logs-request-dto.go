@@ -0,0 +1,127 @@
+package auditModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	orderUtil "go-gin-test-job/src/utils/order"
+	stringUtil "go-gin-test-job/src/utils/string"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const DEFAULT_AUDIT_LOGS_COUNT = 100
+
+type GetAuditLogsRequestDto struct {
+	AccountId *int64 `form:"accountId" json:"accountId" validate:"omitnil,min=1" example:"1"`
+	Actor     string `form:"actor" json:"actor" validate:"omitempty,max=64" example:"admin"`
+	Action    string `form:"action" json:"action" validate:"omitempty,oneof=create update delete restore purge tag_attach tag_detach transition" enums:"create,update,delete,restore,purge,tag_attach,tag_detach,transition" example:"update"`
+	From      *int64 `form:"from" json:"from" validate:"omitnil,min=0" example:"1600000000"`
+	To        *int64 `form:"to" json:"to" validate:"omitnil,min=0" example:"1700000000"`
+	Count     int    `form:"count" json:"count" validate:"min=1,max=1000" default:"100" example:"20"`
+	Cursor    string `form:"cursor" json:"cursor" validate:"omitempty,max=1024" example:"eyJvIjoiaWQgREVTQyIsInYiOlsiMTAiXX0"`
+}
+
+var getAuditLogsRequestDtoValidator *validator.Validate
+
+func init() {
+	getAuditLogsRequestDtoValidator = validator.New()
+	getAuditLogsRequestDtoValidator.RegisterStructValidation(getAuditLogsRequestDtoStructValidation, GetAuditLogsRequestDto{})
+}
+
+// getAuditLogsRequestDtoStructValidation reports a range start after its end as an ltefield error
+func getAuditLogsRequestDtoStructValidation(sl validator.StructLevel) {
+	dto := sl.Current().Interface().(GetAuditLogsRequestDto)
+	if dto.From != nil && dto.To != nil && *dto.From > *dto.To {
+		sl.ReportError(dto.From, "From", "From", "ltefield", "To")
+	}
+}
+
+func getAuditLogsRequestDtoDefaultValues(dto *GetAuditLogsRequestDto) {
+	if dto.Count == 0 {
+		dto.Count = DEFAULT_AUDIT_LOGS_COUNT
+	}
+}
+
+func validateGetAuditLogsRequestDto(dto *GetAuditLogsRequestDto) error {
+	return getAuditLogsRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetAuditLogsRequestDto is the Gin version of handling the request
+func CreateGetAuditLogsRequestDto(c *gin.Context) (GetAuditLogsRequestDto, error) {
+	var dto GetAuditLogsRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := GetAuditLogsRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Set default values
+	getAuditLogsRequestDtoDefaultValues(&dto)
+	// Validate the DTO
+	if err := validateGetAuditLogsRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetAuditLogsRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// GetOrderParams returns the fixed newest first order the cursor is bound to
+func (dto *GetAuditLogsRequestDto) GetOrderParams() []orderUtil.OrderParam {
+	return []orderUtil.OrderParam{{Field: "id", Direction: "DESC"}}
+}
+
+func (dto *GetAuditLogsRequestDto) CreateAuditLogFilter() database.AuditLogFilter {
+	return database.AuditLogFilter{
+		AccountId:   dto.AccountId,
+		Actor:       dto.Actor,
+		Action:      entities.AuditAction(dto.Action),
+		CreatedFrom: dto.From,
+		CreatedTo:   dto.To,
+	}
+}
+
+func GetAuditLogsRequestDtoQueryParseErrorMessage(err error) string {
+	var errorMessage string
+	if stringUtil.CaseInsensitiveContains(err.Error(), "\"accountId\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".accountId") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("accountId")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"from\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".from") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("from")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"to\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".to") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("to")
+	} else if stringUtil.CaseInsensitiveContains(err.Error(), "\"count\"") || stringUtil.CaseInsensitiveContains(err.Error(), ".count") {
+		errorMessage = errorMessages.DefaultFieldErrorMessage("count")
+	} else {
+		errorMessage = errorMessages.DefaultQueryParseErrorMessage()
+	}
+	return errorMessage
+}
+
+func GetAuditLogsRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "AccountId" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Actor" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else if err.Field() == "Action" && err.Tag() == "oneof" {
+		errorMessage = fmt.Sprintf("%s must be one of the next values: %s", err.Field(), strings.Join(entities.AuditActionList, ","))
+	} else if (err.Field() == "From" || err.Field() == "To") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be a unix timestamp greater than or equal %s", err.Field(), err.Param())
+	} else if err.Tag() == "ltefield" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Count" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Count" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be less than or equal %s", err.Field(), err.Param())
+	} else if err.Field() == "Cursor" && err.Tag() == "max" {
+		errorMessage = fmt.Sprintf("%s must be shorter than or equal to %s characters", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -0,0 +1,100 @@
+package blockchain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"go-gin-test-job/src/database/entities"
+	currencyUtil "go-gin-test-job/src/utils/currency"
+	"net/http"
+)
+
+// bitcoindInvalidAddressCode is RPC_INVALID_ADDRESS_OR_KEY, the error of a descriptor with an invalid address
+const bitcoindInvalidAddressCode = -5
+
+type BitcoindRpcRequest struct {
+	JsonRpc string        `json:"jsonrpc"`
+	Id      string        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type BitcoindRpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// BitcoindScanTxOutSetResult TotalAmount is in the currency, not in the base units
+type BitcoindScanTxOutSetResult struct {
+	Success     bool            `json:"success"`
+	TotalAmount decimal.Decimal `json:"total_amount"`
+}
+
+type BitcoindScanTxOutSetResponse struct {
+	Result *BitcoindScanTxOutSetResult `json:"result"`
+	Error  *BitcoindRpcError           `json:"error"`
+}
+
+// BitcoindProvider reads the balances with the scantxoutset JSON-RPC of a full node, the node needs no wallet and no
+// address index. The UTXO set holds the confirmed outputs only, so the unconfirmed balance is always zero
+type BitcoindProvider struct {
+	urls map[string]string
+}
+
+func NewBitcoindProvider(urls map[string]string) *BitcoindProvider {
+	return &BitcoindProvider{urls: urls}
+}
+
+func (p *BitcoindProvider) Name() string {
+	return ProviderBitcoind
+}
+
+func (p *BitcoindProvider) IsConfigured(chain entities.AccountChain, network entities.AccountNetwork) bool {
+	_, err := getChainNetworkUrl(p.urls, chain, network)
+	return err == nil
+}
+
+// GetAddressBalance scans the UTXO set for the addr() descriptor of the address. The RPC errors come with the
+// status 500, so the body is read whatever the status is
+func (p *BitcoindProvider) GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (AddressBalance, error) {
+	balance := AddressBalance{Source: p.Name()}
+	rpcUrl, err := getChainNetworkUrl(p.urls, chain, network)
+	if err != nil {
+		return balance, err
+	}
+	body, err := json.Marshal(BitcoindRpcRequest{
+		JsonRpc: "1.0",
+		Id:      "balance",
+		Method:  "scantxoutset",
+		Params:  []interface{}{"start", []string{fmt.Sprintf("addr(%s)", address)}},
+	})
+	if err != nil {
+		return balance, err
+	}
+	// The URL user info is sent as the basic auth of the RPC user
+	response, err := newHttpClient().Post(rpcUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return balance, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return balance, fmt.Errorf("Provider response status %d", response.StatusCode)
+	}
+	var responseData BitcoindScanTxOutSetResponse
+	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
+		return balance, fmt.Errorf("Provider response status %d. %w", response.StatusCode, err)
+	}
+	if responseData.Error != nil && responseData.Error.Code == bitcoindInvalidAddressCode {
+		return balance, fmt.Errorf("%w. %s", ErrAddressRejected, responseData.Error.Message)
+	}
+	if responseData.Error != nil {
+		return balance, fmt.Errorf("Provider error %d. %s", responseData.Error.Code, responseData.Error.Message)
+	}
+	if responseData.Result == nil || !responseData.Result.Success {
+		return balance, errors.New("Provider scan is not completed")
+	}
+	balance.Confirmed = responseData.Result.TotalAmount.Round(currencyUtil.GetPrecision(chain.GetCurrency()))
+	return balance, nil
+}
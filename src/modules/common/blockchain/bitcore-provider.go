@@ -0,0 +1,208 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-gin-test-job/src/database/entities"
+	currencyUtil "go-gin-test-job/src/utils/currency"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// BitcoreBalanceResponse is in the base units, Balance is Confirmed plus Unconfirmed
+type BitcoreBalanceResponse struct {
+	Confirmed   int64 `json:"confirmed"`
+	Unconfirmed int64 `json:"unconfirmed"`
+	Balance     int64 `json:"balance"`
+}
+
+// BitcoreCoin is an output of the address, SpentTxid is empty while it is unspent. Value is in the base units
+type BitcoreCoin struct {
+	MintTxid  string `json:"mintTxid"`
+	SpentTxid string `json:"spentTxid"`
+	Address   string `json:"address"`
+	Value     int64  `json:"value"`
+}
+
+// BitcoreTransactionCoinsResponse is the coins the transaction spent and the coins it created
+type BitcoreTransactionCoinsResponse struct {
+	Inputs  []BitcoreCoin `json:"inputs"`
+	Outputs []BitcoreCoin `json:"outputs"`
+}
+
+// BitcoreTransactionResponse BlockHeight is negative for the mempool transactions, Fee is in the base units
+type BitcoreTransactionResponse struct {
+	Txid          string `json:"txid"`
+	BlockHeight   int64  `json:"blockHeight"`
+	BlockTime     string `json:"blockTime"`
+	Fee           int64  `json:"fee"`
+	Confirmations int64  `json:"confirmations"`
+}
+
+// BitcoreProvider reads the balances from the Bitcore REST API, the URLs end with /api/<CHAIN>/<network>
+type BitcoreProvider struct {
+	urls map[string]string
+}
+
+func NewBitcoreProvider(urls map[string]string) *BitcoreProvider {
+	return &BitcoreProvider{urls: urls}
+}
+
+func (p *BitcoreProvider) Name() string {
+	return ProviderBitcore
+}
+
+func (p *BitcoreProvider) IsConfigured(chain entities.AccountChain, network entities.AccountNetwork) bool {
+	_, err := getChainNetworkUrl(p.urls, chain, network)
+	return err == nil
+}
+
+// GetAddressBalance reads /address/:address/balance, Bitcore takes the CashAddr addresses without the prefix
+func (p *BitcoreProvider) GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (AddressBalance, error) {
+	balance := AddressBalance{Source: p.Name()}
+	externalUrl, err := getChainNetworkUrl(p.urls, chain, network)
+	if err != nil {
+		return balance, err
+	}
+	if _, payload, hasPrefix := strings.Cut(address, ":"); hasPrefix {
+		address = payload
+	}
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/address/%s/balance", externalUrl, address))
+	if err != nil {
+		return balance, err
+	}
+	defer response.Body.Close()
+	if err := checkResponseStatus(response); err != nil {
+		return balance, err
+	}
+	var responseData BitcoreBalanceResponse
+	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
+		return balance, err
+	}
+	balance.Confirmed = currencyUtil.FromBaseUnits(responseData.Confirmed, chain.GetCurrency())
+	balance.Unconfirmed = currencyUtil.FromBaseUnits(responseData.Unconfirmed, chain.GetCurrency())
+	return balance, nil
+}
+
+// GetAddressTransactions reads the address coins from /address/:address/txs and nets them per transaction,
+// a coin adds its value to the transaction which created it and takes it from the one which spent it.
+// The page is limited to count coins, a full page may miss the mempool transactions and the other coins of the
+// listed transactions, so the amounts are then netted from /tx/:txid/coins. The block, the time and the fee of
+// every transaction except the settled ones are read from /tx/:txid
+func (p *BitcoreProvider) GetAddressTransactions(chain entities.AccountChain, network entities.AccountNetwork, address string, count int, settledTxids map[string]bool) ([]AddressTransaction, bool, error) {
+	externalUrl, err := getChainNetworkUrl(p.urls, chain, network)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, payload, hasPrefix := strings.Cut(address, ":"); hasPrefix {
+		address = payload
+	}
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/address/%s/txs?limit=%d", externalUrl, address, count))
+	if err != nil {
+		return nil, false, err
+	}
+	defer response.Body.Close()
+	if err := checkResponseStatus(response); err != nil {
+		return nil, false, err
+	}
+	var coins []BitcoreCoin
+	if err := json.NewDecoder(response.Body).Decode(&coins); err != nil {
+		return nil, false, err
+	}
+	isComplete := len(coins) < count
+	txids := make([]string, 0)
+	amounts := make(map[string]int64)
+	addAmount := func(txid string, amount int64) {
+		if _, exists := amounts[txid]; !exists {
+			txids = append(txids, txid)
+		}
+		amounts[txid] += amount
+	}
+	for _, coin := range coins {
+		addAmount(coin.MintTxid, coin.Value)
+		if coin.SpentTxid != "" {
+			addAmount(coin.SpentTxid, -coin.Value)
+		}
+	}
+	if len(txids) > count {
+		txids = txids[:count]
+	}
+	currency := chain.GetCurrency()
+	transactions := make([]AddressTransaction, 0, len(txids))
+	for _, txid := range txids {
+		if settledTxids[txid] {
+			transactions = append(transactions, AddressTransaction{Txid: txid, IsSettled: true})
+			continue
+		}
+		transactionData, err := p.getTransaction(externalUrl, txid)
+		if err != nil {
+			return nil, false, err
+		}
+		amount := amounts[txid]
+		if !isComplete {
+			if amount, err = p.getTransactionAmount(externalUrl, txid, address); err != nil {
+				return nil, false, err
+			}
+		}
+		transaction := AddressTransaction{
+			Txid:   txid,
+			Amount: currencyUtil.FromBaseUnits(amount, currency),
+			Fee:    currencyUtil.FromBaseUnits(transactionData.Fee, currency),
+		}
+		if transactionData.BlockHeight >= 0 {
+			blockHeight := transactionData.BlockHeight
+			transaction.BlockHeight = &blockHeight
+			blockTime, err := time.Parse(time.RFC3339, transactionData.BlockTime)
+			if err != nil {
+				return nil, false, fmt.Errorf("Provider transaction %s block time is wrong. %w", txid, err)
+			}
+			transaction.Time = blockTime.Unix()
+			transaction.Confirmations = uint(max(transactionData.Confirmations, 0))
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, isComplete, nil
+}
+
+func (p *BitcoreProvider) getTransaction(externalUrl string, txid string) (BitcoreTransactionResponse, error) {
+	var responseData BitcoreTransactionResponse
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/tx/%s", externalUrl, txid))
+	if err != nil {
+		return responseData, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return responseData, fmt.Errorf("Provider transaction %s response status %d", txid, response.StatusCode)
+	}
+	err = json.NewDecoder(response.Body).Decode(&responseData)
+	return responseData, err
+}
+
+// getTransactionAmount nets the address coins of the transaction from /tx/:txid/coins
+func (p *BitcoreProvider) getTransactionAmount(externalUrl string, txid string, address string) (int64, error) {
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/tx/%s/coins", externalUrl, txid))
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Provider transaction %s coins response status %d", txid, response.StatusCode)
+	}
+	var responseData BitcoreTransactionCoinsResponse
+	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
+		return 0, err
+	}
+	var amount int64
+	for _, coin := range responseData.Outputs {
+		if coin.Address == address {
+			amount += coin.Value
+		}
+	}
+	for _, coin := range responseData.Inputs {
+		if coin.Address == address {
+			amount -= coin.Value
+		}
+	}
+	return amount, nil
+}
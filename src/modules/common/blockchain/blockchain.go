@@ -1,36 +1,112 @@
 package blockchain
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
 	"go-gin-test-job/src/config"
-	currencyUtil "go-gin-test-job/src/utils/currency"
+	"go-gin-test-job/src/database/entities"
 	timeUtil "go-gin-test-job/src/utils/time"
 	"net/http"
 )
 
-var externalUrl = "https://api.bitcore.io/api/BTC/mainnet"
+// The provider names, they are the balance history sources too
+const (
+	ProviderBitcore  = "bitcore"
+	ProviderEsplora  = "esplora"
+	ProviderBitcoind = "bitcoind"
+)
+
+// AddressBalance is the balance in the chain currency, Unconfirmed is the mempool change of the confirmed balance.
+// Source is the name of the provider which returned it
+type AddressBalance struct {
+	Confirmed   decimal.Decimal
+	Unconfirmed decimal.Decimal
+	Source      string
+}
 
-type BlockchainBalanceResponse struct {
-	Confirmed int64 `json:"confirmed"`
+// Total returns the balance with the mempool transactions
+func (b AddressBalance) Total() decimal.Decimal {
+	return b.Confirmed.Add(b.Unconfirmed)
+}
+
+// BalanceProvider reads the address balances of the chain networks it has a URL for
+type BalanceProvider interface {
+	Name() string
+	IsConfigured(chain entities.AccountChain, network entities.AccountNetwork) bool
+	GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (AddressBalance, error)
+}
+
+// ErrAddressRejected is returned when the provider answers with a client error for the address
+var ErrAddressRejected = errors.New("Address is rejected by the provider")
+
+// ErrNotConfigured is returned when the provider has no URL for the chain network
+var ErrNotConfigured = errors.New("Provider URL is not configured")
+
+// GetBalanceProvider creates the configured providers in the config order, with failover when there are several of them.
+// The provider names are checked when the config is loaded
+func GetBalanceProvider() BalanceProvider {
+	providers := make([]BalanceProvider, 0, len(config.AppConfig.BlockchainProviders))
+	for _, name := range config.AppConfig.BlockchainProviders {
+		switch name {
+		case ProviderBitcore:
+			providers = append(providers, NewBitcoreProvider(config.AppConfig.BlockchainUrls))
+		case ProviderEsplora:
+			providers = append(providers, NewEsploraProvider(config.AppConfig.EsploraUrls))
+		case ProviderBitcoind:
+			providers = append(providers, NewBitcoindProvider(config.AppConfig.BitcoindUrls))
+		}
+	}
+	if len(providers) == 1 {
+		return providers[0]
+	}
+	return NewFailoverProvider(providers)
 }
 
-func GetAddressBalance(address string) (decimal.Decimal, error) {
-	balance := decimal.NewFromInt(0)
-	url := fmt.Sprintf("%s/address/%s/balance", externalUrl, address)
-	client := &http.Client{
+// GetChainNetworks returns the chain networks with a configured provider URL
+func GetChainNetworks() []entities.AccountChainNetwork {
+	provider := GetBalanceProvider()
+	chainNetworks := make([]entities.AccountChainNetwork, 0)
+	for _, chain := range entities.AccountChainList {
+		for _, network := range entities.AccountNetworkList {
+			if provider.IsConfigured(entities.AccountChain(chain), entities.AccountNetwork(network)) {
+				chainNetworks = append(chainNetworks, entities.AccountChainNetwork{
+					Chain:   entities.AccountChain(chain),
+					Network: entities.AccountNetwork(network),
+				})
+			}
+		}
+	}
+	return chainNetworks
+}
+
+// GetAddressBalance reads the balance from the configured providers
+func GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (AddressBalance, error) {
+	return GetBalanceProvider().GetAddressBalance(chain, network, address)
+}
+
+// getChainNetworkUrl returns the provider URL of the chain network, ErrNotConfigured when there is none
+func getChainNetworkUrl(urls map[string]string, chain entities.AccountChain, network entities.AccountNetwork) (string, error) {
+	url := urls[config.GetBlockchainUrlKey(string(chain), string(network))]
+	if url == "" {
+		return "", fmt.Errorf("%w. Chain %s network %s", ErrNotConfigured, chain, network)
+	}
+	return url, nil
+}
+
+func newHttpClient() *http.Client {
+	return &http.Client{
 		Timeout: timeUtil.DurationSeconds(config.AppConfig.RequestTimeoutSec),
 	}
-	response, err := client.Get(url)
-	if err != nil {
-		return balance, err
+}
+
+// checkResponseStatus treats the client errors except rate limiting as the address rejection
+func checkResponseStatus(response *http.Response) error {
+	if response.StatusCode >= 400 && response.StatusCode < 500 && response.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w. Status %d", ErrAddressRejected, response.StatusCode)
 	}
-	defer response.Body.Close()
-	var responseData BlockchainBalanceResponse
-	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
-		return balance, err
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Provider response status %d", response.StatusCode)
 	}
-	balance = currencyUtil.FromSatoshi(responseData.Confirmed)
-	return balance, nil
+	return nil
 }
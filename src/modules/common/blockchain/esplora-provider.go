@@ -0,0 +1,182 @@
+package blockchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-gin-test-job/src/database/entities"
+	currencyUtil "go-gin-test-job/src/utils/currency"
+	"net/http"
+)
+
+// The Esplora /address/:address/txs pages, up to 50 mempool transactions and the first 25 confirmed ones
+const (
+	esploraMempoolPageSize = 50
+	esploraChainPageSize   = 25
+)
+
+// EsploraAddressStats is the funded and spent sums of the address outputs in the base units
+type EsploraAddressStats struct {
+	FundedTxoSum int64 `json:"funded_txo_sum"`
+	SpentTxoSum  int64 `json:"spent_txo_sum"`
+}
+
+// EsploraTransactionStatus BlockHeight and BlockTime are set for the confirmed transactions only
+type EsploraTransactionStatus struct {
+	Confirmed   bool  `json:"confirmed"`
+	BlockHeight int64 `json:"block_height"`
+	BlockTime   int64 `json:"block_time"`
+}
+
+type EsploraTransactionOutput struct {
+	ScriptPubKeyAddress string `json:"scriptpubkey_address"`
+	Value               int64  `json:"value"`
+}
+
+// EsploraTransactionInput Prevout is nil for the coinbase input
+type EsploraTransactionInput struct {
+	Prevout *EsploraTransactionOutput `json:"prevout"`
+}
+
+// EsploraTransaction values are in the base units
+type EsploraTransaction struct {
+	Txid   string                     `json:"txid"`
+	Fee    int64                      `json:"fee"`
+	Status EsploraTransactionStatus   `json:"status"`
+	Vin    []EsploraTransactionInput  `json:"vin"`
+	Vout   []EsploraTransactionOutput `json:"vout"`
+}
+
+type EsploraAddressResponse struct {
+	ChainStats   EsploraAddressStats `json:"chain_stats"`
+	MempoolStats EsploraAddressStats `json:"mempool_stats"`
+}
+
+// EsploraProvider reads the balances from the Esplora REST API of Blockstream or mempool.space
+type EsploraProvider struct {
+	urls map[string]string
+}
+
+func NewEsploraProvider(urls map[string]string) *EsploraProvider {
+	return &EsploraProvider{urls: urls}
+}
+
+func (p *EsploraProvider) Name() string {
+	return ProviderEsplora
+}
+
+func (p *EsploraProvider) IsConfigured(chain entities.AccountChain, network entities.AccountNetwork) bool {
+	_, err := getChainNetworkUrl(p.urls, chain, network)
+	return err == nil
+}
+
+// GetAddressBalance reads /address/:address, the confirmed balance is the funded minus the spent sum of chain_stats,
+// the unconfirmed balance is the same of mempool_stats
+func (p *EsploraProvider) GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (AddressBalance, error) {
+	balance := AddressBalance{Source: p.Name()}
+	externalUrl, err := getChainNetworkUrl(p.urls, chain, network)
+	if err != nil {
+		return balance, err
+	}
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/address/%s", externalUrl, address))
+	if err != nil {
+		return balance, err
+	}
+	defer response.Body.Close()
+	if err := checkResponseStatus(response); err != nil {
+		return balance, err
+	}
+	var responseData EsploraAddressResponse
+	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
+		return balance, err
+	}
+	confirmed := responseData.ChainStats.FundedTxoSum - responseData.ChainStats.SpentTxoSum
+	unconfirmed := responseData.MempoolStats.FundedTxoSum - responseData.MempoolStats.SpentTxoSum
+	balance.Confirmed = currencyUtil.FromBaseUnits(confirmed, chain.GetCurrency())
+	balance.Unconfirmed = currencyUtil.FromBaseUnits(unconfirmed, chain.GetCurrency())
+	return balance, nil
+}
+
+// GetAddressTransactions reads /address/:address/txs, the mempool transactions and the latest confirmed ones.
+// The amount is the address outputs minus the address inputs, the confirmations are counted from /blocks/tip/height.
+// The list is complete when neither of the Esplora pages is full and the count does not cut it
+func (p *EsploraProvider) GetAddressTransactions(chain entities.AccountChain, network entities.AccountNetwork, address string, count int, settledTxids map[string]bool) ([]AddressTransaction, bool, error) {
+	externalUrl, err := getChainNetworkUrl(p.urls, chain, network)
+	if err != nil {
+		return nil, false, err
+	}
+	tipHeight, err := p.getTipHeight(externalUrl)
+	if err != nil {
+		return nil, false, err
+	}
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/address/%s/txs", externalUrl, address))
+	if err != nil {
+		return nil, false, err
+	}
+	defer response.Body.Close()
+	if err := checkResponseStatus(response); err != nil {
+		return nil, false, err
+	}
+	var responseData []EsploraTransaction
+	if err := json.NewDecoder(response.Body).Decode(&responseData); err != nil {
+		return nil, false, err
+	}
+	mempoolCount := 0
+	for _, esploraTransaction := range responseData {
+		if !esploraTransaction.Status.Confirmed {
+			mempoolCount++
+		}
+	}
+	isComplete := len(responseData) <= count && mempoolCount < esploraMempoolPageSize && len(responseData)-mempoolCount < esploraChainPageSize
+	if len(responseData) > count {
+		responseData = responseData[:count]
+	}
+	currency := chain.GetCurrency()
+	transactions := make([]AddressTransaction, 0, len(responseData))
+	for _, esploraTransaction := range responseData {
+		if settledTxids[esploraTransaction.Txid] {
+			transactions = append(transactions, AddressTransaction{Txid: esploraTransaction.Txid, IsSettled: true})
+			continue
+		}
+		var amount int64
+		for _, output := range esploraTransaction.Vout {
+			if output.ScriptPubKeyAddress == address {
+				amount += output.Value
+			}
+		}
+		for _, input := range esploraTransaction.Vin {
+			if input.Prevout != nil && input.Prevout.ScriptPubKeyAddress == address {
+				amount -= input.Prevout.Value
+			}
+		}
+		transaction := AddressTransaction{
+			Txid:   esploraTransaction.Txid,
+			Amount: currencyUtil.FromBaseUnits(amount, currency),
+			Fee:    currencyUtil.FromBaseUnits(esploraTransaction.Fee, currency),
+		}
+		if esploraTransaction.Status.Confirmed {
+			blockHeight := esploraTransaction.Status.BlockHeight
+			transaction.BlockHeight = &blockHeight
+			transaction.Time = esploraTransaction.Status.BlockTime
+			transaction.Confirmations = getConfirmations(&blockHeight, tipHeight)
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, isComplete, nil
+}
+
+// getTipHeight reads the height of the last block, the response is a plain number
+func (p *EsploraProvider) getTipHeight(externalUrl string) (int64, error) {
+	response, err := newHttpClient().Get(fmt.Sprintf("%s/blocks/tip/height", externalUrl))
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Provider response status %d", response.StatusCode)
+	}
+	var tipHeight int64
+	if err := json.NewDecoder(response.Body).Decode(&tipHeight); err != nil {
+		return 0, err
+	}
+	return tipHeight, nil
+}
@@ -0,0 +1,79 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/logger"
+	"strings"
+)
+
+// FailoverProvider asks the providers in order until one returns the balance
+type FailoverProvider struct {
+	providers []BalanceProvider
+}
+
+func NewFailoverProvider(providers []BalanceProvider) *FailoverProvider {
+	return &FailoverProvider{providers: providers}
+}
+
+func (p *FailoverProvider) Name() string {
+	names := make([]string, 0, len(p.providers))
+	for _, provider := range p.providers {
+		names = append(names, provider.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+func (p *FailoverProvider) IsConfigured(chain entities.AccountChain, network entities.AccountNetwork) bool {
+	for _, provider := range p.providers {
+		if provider.IsConfigured(chain, network) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAddressBalance skips the providers without a URL for the chain network. The address rejection is returned
+// right away, the address format is the same for every provider, so the next one would reject it too
+func (p *FailoverProvider) GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (AddressBalance, error) {
+	providerErrors := make([]error, 0, len(p.providers))
+	for _, provider := range p.providers {
+		if !provider.IsConfigured(chain, network) {
+			continue
+		}
+		balance, err := provider.GetAddressBalance(chain, network, address)
+		if err == nil || errors.Is(err, ErrAddressRejected) {
+			return balance, err
+		}
+		logger.Logger.Warn().Msg(fmt.Sprintf("Provider %s address %s balance error, trying the next provider. %s", provider.Name(), address, err.Error()))
+		providerErrors = append(providerErrors, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+	if len(providerErrors) == 0 {
+		return AddressBalance{Source: p.Name()}, fmt.Errorf("%w. Chain %s network %s", ErrNotConfigured, chain, network)
+	}
+	return AddressBalance{Source: p.Name()}, errors.Join(providerErrors...)
+}
+
+// GetAddressTransactions asks the configured providers which read the transactions in order, like GetAddressBalance
+func (p *FailoverProvider) GetAddressTransactions(chain entities.AccountChain, network entities.AccountNetwork, address string, count int, settledTxids map[string]bool) ([]AddressTransaction, bool, error) {
+	providerErrors := make([]error, 0, len(p.providers))
+	isSupported := false
+	for _, provider := range p.providers {
+		transactionProvider, isTransactionProvider := provider.(TransactionProvider)
+		if !isTransactionProvider || !provider.IsConfigured(chain, network) {
+			continue
+		}
+		isSupported = true
+		transactions, isComplete, err := transactionProvider.GetAddressTransactions(chain, network, address, count, settledTxids)
+		if err == nil || errors.Is(err, ErrAddressRejected) {
+			return transactions, isComplete, err
+		}
+		logger.Logger.Warn().Msg(fmt.Sprintf("Provider %s address %s transactions error, trying the next provider. %s", provider.Name(), address, err.Error()))
+		providerErrors = append(providerErrors, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+	if !isSupported {
+		return nil, false, fmt.Errorf("%w. Chain %s network %s", ErrTransactionsNotSupported, chain, network)
+	}
+	return nil, false, errors.Join(providerErrors...)
+}
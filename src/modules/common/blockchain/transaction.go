@@ -0,0 +1,50 @@
+package blockchain
+
+import (
+	"errors"
+	"go-gin-test-job/src/database/entities"
+
+	"github.com/shopspring/decimal"
+)
+
+// AddressTransaction is a transaction of the address in the chain currency. Amount is the net change of the address
+// balance, Fee is the fee of the whole transaction. BlockHeight is nil and Time is zero for the mempool transactions.
+// IsSettled is set for the settled txids of the request, only Txid is set then
+type AddressTransaction struct {
+	Txid          string
+	BlockHeight   *int64
+	Time          int64
+	Amount        decimal.Decimal
+	Fee           decimal.Decimal
+	Confirmations uint
+	IsSettled     bool
+}
+
+// TransactionProvider reads up to count of the latest address transactions, the newest first. The details of
+// settledTxids, the transactions already stored with enough confirmations, are not read. isComplete is set when
+// the provider listed every transaction of the address, a cut list may miss the mempool ones. The providers
+// without an address index can not implement it
+type TransactionProvider interface {
+	BalanceProvider
+	GetAddressTransactions(chain entities.AccountChain, network entities.AccountNetwork, address string, count int, settledTxids map[string]bool) (transactions []AddressTransaction, isComplete bool, err error)
+}
+
+// ErrTransactionsNotSupported is returned when none of the configured providers reads the address transactions
+var ErrTransactionsNotSupported = errors.New("Provider does not support the address transactions")
+
+// GetAddressTransactions reads the transactions from the configured providers
+func GetAddressTransactions(chain entities.AccountChain, network entities.AccountNetwork, address string, count int, settledTxids map[string]bool) ([]AddressTransaction, bool, error) {
+	provider, isTransactionProvider := GetBalanceProvider().(TransactionProvider)
+	if !isTransactionProvider {
+		return nil, false, ErrTransactionsNotSupported
+	}
+	return provider.GetAddressTransactions(chain, network, address, count, settledTxids)
+}
+
+// getConfirmations counts the block of the transaction and the blocks after it up to the tip
+func getConfirmations(blockHeight *int64, tipHeight int64) uint {
+	if blockHeight == nil || tipHeight < *blockHeight {
+		return 0
+	}
+	return uint(tipHeight - *blockHeight + 1)
+}
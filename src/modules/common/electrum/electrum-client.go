@@ -0,0 +1,297 @@
+package electrum
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ClientName and ProtocolVersion are sent with server.version, 1.4 has the scripthash methods
+const (
+	ClientName      = "go-gin-test-job"
+	ProtocolVersion = "1.4"
+)
+
+// BalanceSource is the balance history source of the subscription updates
+const BalanceSource = "electrum"
+
+// maxLineSize limits a single JSON-RPC message
+const maxLineSize = 1024 * 1024
+
+var ErrClosed = errors.New("Electrum connection is closed")
+
+type RpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RpcError) Error() string {
+	return fmt.Sprintf("Electrum error %d. %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	Id     uint64        `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// rpcMessage is a response when Id is set and a notification when Method is set
+type rpcMessage struct {
+	Id     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RpcError       `json:"error"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// ScripthashBalance is the balance in the base units, Unconfirmed may be negative when the mempool spends confirmed outputs
+type ScripthashBalance struct {
+	Confirmed   int64 `json:"confirmed"`
+	Unconfirmed int64 `json:"unconfirmed"`
+}
+
+// Notification is a blockchain.scripthash.subscribe notification, Status is empty when the scripthash has no history
+type Notification struct {
+	Scripthash string
+	Status     string
+}
+
+// Client is a connection to an Electrum server speaking newline-delimited JSON-RPC.
+// The calls may be made from several goroutines, the notifications are read from Notifications
+type Client struct {
+	conn       net.Conn
+	timeout    time.Duration
+	writeMutex sync.Mutex
+	mutex      sync.Mutex
+	nextId     uint64
+	pending    map[uint64]chan *rpcMessage
+	// queuedStatuses are the last statuses of the scripthashes not yet delivered to Notifications, a newer status
+	// replaces the queued one, so the connection reading never waits for the notifications reader
+	queuedStatuses     map[string]string
+	notificationSignal chan struct{}
+	notifications      chan Notification
+	done               chan struct{}
+	err                error
+}
+
+// Dial connects to the server URL, tcp://host:port or tls://host:port, the timeout applies to the connection and to every call
+func Dial(serverUrl string, timeout time.Duration) (*Client, error) {
+	parsedUrl, err := url.Parse(serverUrl)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch parsedUrl.Scheme {
+	case "tcp":
+		conn, err = dialer.Dial("tcp", parsedUrl.Host)
+	case "tls", "ssl":
+		conn, err = tls.DialWithDialer(dialer, "tcp", parsedUrl.Host, &tls.Config{ServerName: parsedUrl.Hostname()})
+	default:
+		return nil, fmt.Errorf("Electrum URL scheme must be tcp or tls, got %s", parsedUrl.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{
+		conn:               conn,
+		timeout:            timeout,
+		pending:            make(map[uint64]chan *rpcMessage),
+		queuedStatuses:     make(map[string]string),
+		notificationSignal: make(chan struct{}, 1),
+		notifications:      make(chan Notification),
+		done:               make(chan struct{}),
+	}
+	go client.readLoop()
+	go client.notifyLoop()
+	return client, nil
+}
+
+// Notifications returns the scripthash status changes, they stop coming when Done is closed.
+// Only the last status of a scripthash is delivered when it changed several times before it was read
+func (c *Client) Notifications() <-chan Notification {
+	return c.notifications
+}
+
+// Done is closed when the connection is closed, Err returns the reason
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+func (c *Client) Close() error {
+	c.closeWithError(ErrClosed)
+	return nil
+}
+
+// ServerVersion negotiates the protocol version, it must be the first call of the connection
+func (c *Client) ServerVersion() ([]string, error) {
+	var version []string
+	err := c.Call("server.version", []interface{}{ClientName, ProtocolVersion}, &version)
+	return version, err
+}
+
+func (c *Client) Ping() error {
+	return c.Call("server.ping", []interface{}{}, nil)
+}
+
+func (c *Client) GetBalance(scripthash string) (ScripthashBalance, error) {
+	var balance ScripthashBalance
+	err := c.Call("blockchain.scripthash.get_balance", []interface{}{scripthash}, &balance)
+	return balance, err
+}
+
+// Subscribe returns the current status of the scripthash, the later changes come as notifications
+func (c *Client) Subscribe(scripthash string) (string, error) {
+	var status *string
+	if err := c.Call("blockchain.scripthash.subscribe", []interface{}{scripthash}, &status); err != nil {
+		return "", err
+	}
+	if status == nil {
+		return "", nil
+	}
+	return *status, nil
+}
+
+// Call sends the request and decodes the result into result unless it is nil
+func (c *Client) Call(method string, params []interface{}, result interface{}) error {
+	c.mutex.Lock()
+	if c.err != nil {
+		c.mutex.Unlock()
+		return c.err
+	}
+	c.nextId++
+	id := c.nextId
+	responseChannel := make(chan *rpcMessage, 1)
+	c.pending[id] = responseChannel
+	c.mutex.Unlock()
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+	}()
+
+	data, err := json.Marshal(rpcRequest{Id: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	c.writeMutex.Lock()
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	_, err = c.conn.Write(append(data, '\n'))
+	c.writeMutex.Unlock()
+	if err != nil {
+		c.closeWithError(err)
+		return err
+	}
+
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case response := <-responseChannel:
+		if response.Error != nil {
+			return response.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(response.Result, result)
+	case <-c.done:
+		return c.Err()
+	case <-timer.C:
+		return fmt.Errorf("Electrum %s call timeout", method)
+	}
+}
+
+// readLoop delivers the responses to the waiting calls and queues the notifications until the connection fails
+func (c *Client) readLoop() {
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var message rpcMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			c.closeWithError(fmt.Errorf("Electrum message format is wrong. %w", err))
+			return
+		}
+		if message.Id != nil {
+			c.mutex.Lock()
+			responseChannel, exists := c.pending[*message.Id]
+			c.mutex.Unlock()
+			if exists {
+				responseChannel <- &message
+			}
+			continue
+		}
+		if message.Method == "blockchain.scripthash.subscribe" {
+			var params []*string
+			if err := json.Unmarshal(message.Params, &params); err != nil || len(params) != 2 || params[0] == nil {
+				continue
+			}
+			status := ""
+			if params[1] != nil {
+				status = *params[1]
+			}
+			c.queueNotification(*params[0], status)
+		}
+	}
+	err := scanner.Err()
+	if err == nil {
+		err = errors.New("Electrum server closed the connection")
+	}
+	c.closeWithError(err)
+}
+
+// queueNotification replaces the queued status of the scripthash and wakes up notifyLoop, it never blocks
+func (c *Client) queueNotification(scripthash string, status string) {
+	c.mutex.Lock()
+	c.queuedStatuses[scripthash] = status
+	c.mutex.Unlock()
+	select {
+	case c.notificationSignal <- struct{}{}:
+	default:
+	}
+}
+
+// notifyLoop delivers the queued statuses to Notifications until the connection is closed
+func (c *Client) notifyLoop() {
+	for {
+		select {
+		case <-c.notificationSignal:
+		case <-c.done:
+			return
+		}
+		c.mutex.Lock()
+		queuedStatuses := c.queuedStatuses
+		c.queuedStatuses = make(map[string]string)
+		c.mutex.Unlock()
+		for scripthash, status := range queuedStatuses {
+			select {
+			case c.notifications <- Notification{Scripthash: scripthash, Status: status}:
+			case <-c.done:
+				return
+			}
+		}
+	}
+}
+
+// closeWithError keeps the first error, the pending calls return it
+func (c *Client) closeWithError(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.done)
+	_ = c.conn.Close()
+}
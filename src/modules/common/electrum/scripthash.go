@@ -0,0 +1,20 @@
+package electrum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go-gin-test-job/src/database/entities"
+	addressValidationUtil "go-gin-test-job/src/utils/address-validation"
+	"slices"
+)
+
+// GetScripthash returns the Electrum scripthash of the address, the SHA-256 of its output script in the reversed byte order
+func GetScripthash(chain entities.AccountChain, network entities.AccountNetwork, address string) (string, error) {
+	script, err := addressValidationUtil.GetScriptPubKey(addressValidationUtil.Chain(chain), addressValidationUtil.Network(network), address)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(script)
+	slices.Reverse(hash[:])
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -2,6 +2,7 @@ package cronModule
 
 import (
 	"github.com/gin-gonic/gin"
+	auditContext "go-gin-test-job/src/common/audit-context"
 	"go-gin-test-job/src/common/dto"
 )
 
@@ -16,6 +17,6 @@ import (
 // @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
 // @Router /cron/account-balance [post]
 func UpdateAccountsBalances(c *gin.Context) {
-	updateAccountsBalances()
+	updateAccountsBalances(auditContext.Get(c))
 	c.JSON(200, dto.CreateSuccessDto())
 }
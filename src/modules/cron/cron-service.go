@@ -1,33 +1,152 @@
 package cronModule
 
 import (
+	"errors"
 	"fmt"
 	"go-gin-test-job/src/config"
 	"go-gin-test-job/src/database"
 	"go-gin-test-job/src/database/entities"
 	"go-gin-test-job/src/logger"
 	"go-gin-test-job/src/modules/common/blockchain"
+	timeUtil "go-gin-test-job/src/utils/time"
+	"maps"
+
+	"gorm.io/gorm"
 )
 
-func updateAccountsBalances() {
-	accounts := database.GetAccountsBatch(config.AppConfig.CronBatchCount)
+const balanceHistoryPruneBatchCount = 1000
+const idempotencyKeyPruneBatchCount = 1000
+
+func updateAccountsBalances(audit database.AuditContext) {
+	accounts := database.GetAccountsBatch(getPollingChainNetworks(), config.AppConfig.CronBatchCount)
 	for _, account := range accounts {
-		if err := updateAccountBalance(account); err != nil {
+		if err := updateAccountBalance(audit, account); err != nil {
 			logger.Logger.Error().Msg(fmt.Sprintf("Update account %d address %s error. %s", account.Id, account.Address, err.Error()))
 		}
 	}
+	pruneAccountBalanceHistory()
+	pruneIdempotencyKeys()
 }
 
-func updateAccountBalance(account *entities.Account) error {
+func updateAccountBalance(audit database.AuditContext, account *entities.Account) error {
 	logger.Logger.Info().Msg(fmt.Sprintf("Update account %d address %s balance", account.Id, account.Address))
-	balance, err := blockchain.GetAddressBalance(account.Address)
+	addressBalance, err := blockchain.GetAddressBalance(account.Chain, account.Network, account.Address)
+	if errors.Is(err, blockchain.ErrAddressRejected) {
+		return errors.Join(err, recordAccountBalanceError(audit, account, err))
+	}
 	if err != nil {
 		return err
 	}
-	logger.Logger.Info().Msg(fmt.Sprintf("Account %d address %s balance - %s", account.Id, account.Address, account.Balance))
-	updateData := account.UpdateBalance(balance)
-	if err := database.UpdateAccount(nil, account, updateData); err != nil {
+	logger.Logger.Info().Msg(fmt.Sprintf("Account %d address %s balance - %s, unconfirmed - %s", account.Id, account.Address, addressBalance.Confirmed, addressBalance.Unconfirmed))
+	if err := saveAccountBalance(audit, account, addressBalance); err != nil {
 		return err
 	}
-	return nil
+	return importAccountTransactions(account)
+}
+
+// getPollingChainNetworks returns the chain networks with a provider URL, except the ones with an Electrum server,
+// their balances come from the subscriptions
+func getPollingChainNetworks() []entities.AccountChainNetwork {
+	chainNetworks := make([]entities.AccountChainNetwork, 0)
+	for _, chainNetwork := range blockchain.GetChainNetworks() {
+		if getElectrumUrl(chainNetwork) == "" {
+			chainNetworks = append(chainNetworks, chainNetwork)
+		}
+	}
+	return chainNetworks
+}
+
+// saveAccountBalance writes the balances with the confirmed balance history, resets the error count and activates a pending account
+func saveAccountBalance(audit database.AuditContext, account *entities.Account, addressBalance blockchain.AddressBalance) error {
+	balance := addressBalance.Confirmed
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		// Compare with the locked row, the balance may have changed since the batch was read
+		lockedAccount := database.GetAccountByIdForUpdate(tx, account.Id)
+		if lockedAccount == nil || !lockedAccount.Status.IsPolling() {
+			return nil
+		}
+		if err := database.UpdateAccountBalanceCheckedAt(tx, lockedAccount, timeUtil.GetUnixTime()); err != nil {
+			return err
+		}
+		if !lockedAccount.Balance.Equal(balance) {
+			history := entities.CreateAccountBalanceHistory(lockedAccount.Id, lockedAccount.Balance, balance, addressBalance.Source, timeUtil.GetUnixTime())
+			if err := database.CreateAccountBalanceHistory(tx, history); err != nil {
+				return err
+			}
+		}
+		// Only a changed field is a write, an unchanged poll keeps the version and the ETag of the account
+		updateData := make(map[string]interface{})
+		if !lockedAccount.Balance.Equal(balance) || !lockedAccount.UnconfirmedBalance.Equal(addressBalance.Unconfirmed) {
+			maps.Copy(updateData, lockedAccount.UpdateBalance(balance, addressBalance.Unconfirmed))
+		}
+		if lockedAccount.ErrorCount > 0 {
+			maps.Copy(updateData, lockedAccount.ResetErrorCount())
+		}
+		if len(updateData) > 0 {
+			if err := database.UpdateAccount(tx, audit, entities.AuditActionUpdate, lockedAccount, updateData); err != nil {
+				return err
+			}
+		}
+		// The first successful check activates a new account
+		if lockedAccount.Status == entities.AccountStatusPending {
+			updateData := lockedAccount.Transition(entities.AccountStatusActive, "Balance checked")
+			return database.UpdateAccount(tx, audit, entities.AuditActionTransition, lockedAccount, updateData)
+		}
+		return nil
+	}, database.DefaultTxOptions)
+}
+
+// recordAccountBalanceError counts the rejected address, the account moves to Error once the count reaches the threshold
+func recordAccountBalanceError(audit database.AuditContext, account *entities.Account, balanceErr error) error {
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		lockedAccount := database.GetAccountByIdForUpdate(tx, account.Id)
+		if lockedAccount == nil || !lockedAccount.Status.IsPolling() {
+			return nil
+		}
+		if err := database.UpdateAccountBalanceCheckedAt(tx, lockedAccount, timeUtil.GetUnixTime()); err != nil {
+			return err
+		}
+		if int(lockedAccount.ErrorCount)+1 < config.AppConfig.AccountErrorThreshold ||
+			!lockedAccount.Status.CanTransitionTo(entities.AccountStatusError) {
+			return database.UpdateAccount(tx, audit, entities.AuditActionUpdate, lockedAccount, lockedAccount.IncrementErrorCount())
+		}
+		updateData := lockedAccount.IncrementErrorCount()
+		maps.Copy(updateData, lockedAccount.Transition(entities.AccountStatusError, balanceErr.Error()))
+		return database.UpdateAccount(tx, audit, entities.AuditActionTransition, lockedAccount, updateData)
+	}, database.DefaultTxOptions)
+}
+
+// pruneAccountBalanceHistory removes the history older than the retention window in batches,
+// so a large backlog does not hold one long delete
+func pruneAccountBalanceHistory() {
+	retentionDays := config.AppConfig.BalanceHistoryRetentionDays
+	if retentionDays <= 0 {
+		return
+	}
+	before := timeUtil.GetUnixTime() - int64(retentionDays)*24*60*60
+	for {
+		deletedCount, err := database.DeleteAccountBalanceHistoryBefore(before, balanceHistoryPruneBatchCount)
+		if err != nil {
+			logger.Logger.Error().Msg(fmt.Sprintf("Prune account balance history error. %s", err.Error()))
+			return
+		}
+		if deletedCount < balanceHistoryPruneBatchCount {
+			return
+		}
+	}
+}
+
+// pruneIdempotencyKeys removes the expired idempotency keys in batches, an expired key is reused anyway
+func pruneIdempotencyKeys() {
+	before := timeUtil.GetUnixTime()
+	for {
+		deletedCount, err := database.DeleteIdempotencyKeysExpiredBefore(before, idempotencyKeyPruneBatchCount)
+		if err != nil {
+			logger.Logger.Error().Msg(fmt.Sprintf("Prune idempotency keys error. %s", err.Error()))
+			return
+		}
+		if deletedCount < idempotencyKeyPruneBatchCount {
+			return
+		}
+	}
 }
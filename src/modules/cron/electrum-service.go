@@ -0,0 +1,230 @@
+package cronModule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/logger"
+	"go-gin-test-job/src/modules/common/blockchain"
+	"go-gin-test-job/src/modules/common/electrum"
+	currencyUtil "go-gin-test-job/src/utils/currency"
+	timeUtil "go-gin-test-job/src/utils/time"
+	"sync"
+	"time"
+)
+
+// The reconnect delay doubles after every failed session up to the maximum
+const (
+	electrumMinReconnectDelay = time.Second
+	electrumMaxReconnectDelay = time.Minute
+)
+
+// electrumPingInterval keeps the idle connection open, the servers close the sessions without requests
+const electrumPingInterval = 5 * time.Minute
+
+// electrumSubscription keeps the balances of the chain network accounts up to date with the scripthash notifications
+type electrumSubscription struct {
+	chainNetwork entities.AccountChainNetwork
+	serverUrl    string
+	audit        database.AuditContext
+	// accounts are the subscribed accounts by their scripthashes
+	accounts map[string]*entities.Account
+	// statuses are the scripthash statuses of the last saved balances by the account ids, they are kept across
+	// the reconnects, so only the changed accounts are read again after the resubscribe
+	statuses map[int64]string
+	// transactionsMutex guards transactionAccounts, the accounts which transactions importTransactionsLoop
+	// imports next by their ids, so the slow provider requests never hold up the notifications
+	transactionsMutex   sync.Mutex
+	transactionAccounts map[int64]*entities.Account
+	transactionsSignal  chan struct{}
+}
+
+// StartElectrumSubscriptions starts the subscriptions of the chain networks with an Electrum server
+func StartElectrumSubscriptions(ctx context.Context) {
+	for _, chain := range entities.AccountChainList {
+		for _, network := range entities.AccountNetworkList {
+			chainNetwork := entities.AccountChainNetwork{
+				Chain:   entities.AccountChain(chain),
+				Network: entities.AccountNetwork(network),
+			}
+			if serverUrl := getElectrumUrl(chainNetwork); serverUrl != "" {
+				go StartElectrumSubscription(ctx, chainNetwork, serverUrl)
+			}
+		}
+	}
+}
+
+// StartElectrumSubscription subscribes to the chain network accounts and reconnects with resubscribe when the
+// connection fails. It returns when the context is done
+func StartElectrumSubscription(ctx context.Context, chainNetwork entities.AccountChainNetwork, serverUrl string) {
+	subscription := &electrumSubscription{
+		chainNetwork: chainNetwork,
+		serverUrl:    serverUrl,
+		audit:        database.AuditContext{Actor: entities.AuditActorElectrum},
+		accounts:     make(map[string]*entities.Account),
+		statuses:     make(map[int64]string),
+
+		transactionAccounts: make(map[int64]*entities.Account),
+		transactionsSignal:  make(chan struct{}, 1),
+	}
+	go subscription.importTransactionsLoop(ctx)
+	reconnectDelay := electrumMinReconnectDelay
+	for {
+		subscribed, err := subscription.run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			reconnectDelay = electrumMinReconnectDelay
+		}
+		logger.Logger.Warn().Msg(fmt.Sprintf("Electrum %s %s session error, reconnect in %s. %s", chainNetwork.Chain, chainNetwork.Network, reconnectDelay, err.Error()))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+		reconnectDelay = min(reconnectDelay*2, electrumMaxReconnectDelay)
+	}
+}
+
+func getElectrumUrl(chainNetwork entities.AccountChainNetwork) string {
+	return config.AppConfig.ElectrumUrls[config.GetBlockchainUrlKey(string(chainNetwork.Chain), string(chainNetwork.Network))]
+}
+
+// run is a single connection session, subscribed is true once all the accounts were subscribed
+func (s *electrumSubscription) run(ctx context.Context) (bool, error) {
+	client, err := electrum.Dial(s.serverUrl, timeUtil.DurationSeconds(config.AppConfig.RequestTimeoutSec))
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	if _, err := client.ServerVersion(); err != nil {
+		return false, err
+	}
+	// The subscriptions belong to the connection, a new connection starts with none
+	subscribed := make(map[string]bool)
+	if err := s.subscribeAccounts(client, subscribed); err != nil {
+		return false, err
+	}
+	logger.Logger.Info().Msg(fmt.Sprintf("Electrum %s %s subscribed to %d accounts", s.chainNetwork.Chain, s.chainNetwork.Network, len(subscribed)))
+
+	refreshTicker := time.NewTicker(timeUtil.DurationSeconds(config.AppConfig.ElectrumRefreshSec))
+	defer refreshTicker.Stop()
+	pingTicker := time.NewTicker(electrumPingInterval)
+	defer pingTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case <-client.Done():
+			return true, client.Err()
+		case notification := <-client.Notifications():
+			s.syncAccountBalance(client, notification.Scripthash, notification.Status)
+		case <-refreshTicker.C:
+			if err := s.subscribeAccounts(client, subscribed); err != nil {
+				return true, err
+			}
+		case <-pingTicker.C:
+			if err := client.Ping(); err != nil {
+				return true, err
+			}
+		}
+	}
+}
+
+// subscribeAccounts reloads the accounts and subscribes to the new ones. The removed accounts stay subscribed until
+// the reconnect, their notifications are ignored
+func (s *electrumSubscription) subscribeAccounts(client *electrum.Client, subscribed map[string]bool) error {
+	accounts := database.GetPollingAccounts(s.chainNetwork)
+	s.accounts = make(map[string]*entities.Account, len(accounts))
+	for _, account := range accounts {
+		scripthash, err := electrum.GetScripthash(account.Chain, account.Network, account.Address)
+		if err != nil {
+			logger.Logger.Error().Msg(fmt.Sprintf("Account %d address %s scripthash error. %s", account.Id, account.Address, err.Error()))
+			continue
+		}
+		s.accounts[scripthash] = account
+		if subscribed[scripthash] {
+			continue
+		}
+		status, err := client.Subscribe(scripthash)
+		// The server error is about the scripthash, the other errors are about the connection
+		var rpcErr *electrum.RpcError
+		if errors.As(err, &rpcErr) {
+			logger.Logger.Error().Msg(fmt.Sprintf("Account %d address %s subscribe error. %s", account.Id, account.Address, err.Error()))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		subscribed[scripthash] = true
+		s.syncAccountBalance(client, scripthash, status)
+	}
+	return nil
+}
+
+// syncAccountBalance reads and saves the balance when the status differs from the status of the last saved balance
+func (s *electrumSubscription) syncAccountBalance(client *electrum.Client, scripthash string, status string) {
+	account, exists := s.accounts[scripthash]
+	if !exists {
+		return
+	}
+	if savedStatus, saved := s.statuses[account.Id]; saved && savedStatus == status {
+		return
+	}
+	balance, err := client.GetBalance(scripthash)
+	if err != nil {
+		logger.Logger.Error().Msg(fmt.Sprintf("Account %d address %s Electrum balance error. %s", account.Id, account.Address, err.Error()))
+		return
+	}
+	addressBalance := blockchain.AddressBalance{
+		Confirmed:   currencyUtil.FromBaseUnits(balance.Confirmed, account.Chain.GetCurrency()),
+		Unconfirmed: currencyUtil.FromBaseUnits(balance.Unconfirmed, account.Chain.GetCurrency()),
+		Source:      electrum.BalanceSource,
+	}
+	logger.Logger.Info().Msg(fmt.Sprintf("Account %d address %s Electrum balance - %s", account.Id, account.Address, addressBalance.Confirmed))
+	if err := saveAccountBalance(s.audit, account, addressBalance); err != nil {
+		logger.Logger.Error().Msg(fmt.Sprintf("Update account %d address %s error. %s", account.Id, account.Address, err.Error()))
+		return
+	}
+	s.statuses[account.Id] = status
+	// The status changes with every new or confirmed transaction of the address
+	s.queueTransactionsImport(account)
+}
+
+// queueTransactionsImport adds the account to the next import of importTransactionsLoop, it never blocks
+func (s *electrumSubscription) queueTransactionsImport(account *entities.Account) {
+	s.transactionsMutex.Lock()
+	s.transactionAccounts[account.Id] = account
+	s.transactionsMutex.Unlock()
+	select {
+	case s.transactionsSignal <- struct{}{}:
+	default:
+	}
+}
+
+// importTransactionsLoop imports the transactions of the queued accounts until the context is done
+func (s *electrumSubscription) importTransactionsLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.transactionsSignal:
+		}
+		s.transactionsMutex.Lock()
+		accounts := s.transactionAccounts
+		s.transactionAccounts = make(map[int64]*entities.Account)
+		s.transactionsMutex.Unlock()
+		for _, account := range accounts {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := importAccountTransactions(account); err != nil {
+				logger.Logger.Error().Msg(fmt.Sprintf("Account %d address %s transactions error. %s", account.Id, account.Address, err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cronModule
+
+import (
+	"errors"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/modules/common/blockchain"
+	timeUtil "go-gin-test-job/src/utils/time"
+
+	"gorm.io/gorm"
+)
+
+// importAccountTransactions stores the latest transactions of the account address. The chain networks without
+// a provider which reads the transactions are skipped. The stored transactions with enough confirmations are not
+// read again, the provider lists them without the details
+func importAccountTransactions(account *entities.Account) error {
+	count := config.AppConfig.TransactionImportCount
+	// The stored rows newer than the listed ones may be mempool transactions, twice the count covers the list
+	settledTxids := database.GetSettledTransactionTxids(account.Id, config.AppConfig.TransactionSettledConfirmations, 2*count)
+	addressTransactions, isComplete, err := blockchain.GetAddressTransactions(account.Chain, account.Network, account.Address, count, settledTxids)
+	if errors.Is(err, blockchain.ErrTransactionsNotSupported) || errors.Is(err, blockchain.ErrNotConfigured) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return saveAccountTransactions(account, addressTransactions, isComplete)
+}
+
+// saveAccountTransactions inserts the new txids and updates the known ones in place, so a mempool transaction
+// becomes confirmed. When the list is complete, the stored mempool transactions the provider does not return
+// any more were replaced or dropped and are removed
+func saveAccountTransactions(account *entities.Account, addressTransactions []blockchain.AddressTransaction, isComplete bool) error {
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		// The account lock keeps the cron and the Electrum subscription from inserting the same txid together
+		lockedAccount := database.GetAccountByIdForUpdate(tx, account.Id)
+		if lockedAccount == nil {
+			return nil
+		}
+		txids := make([]string, 0, len(addressTransactions))
+		for _, addressTransaction := range addressTransactions {
+			txids = append(txids, addressTransaction.Txid)
+		}
+		storedTransactions := make(map[string]*entities.Transaction)
+		for _, transaction := range database.GetTransactionsByTxids(tx, lockedAccount.Id, txids) {
+			storedTransactions[transaction.Txid] = transaction
+		}
+		now := timeUtil.GetUnixTime()
+		newTransactions := make([]*entities.Transaction, 0)
+		newTxids := make(map[string]bool)
+		for _, addressTransaction := range addressTransactions {
+			if addressTransaction.IsSettled {
+				continue
+			}
+			if storedTransaction, exists := storedTransactions[addressTransaction.Txid]; exists {
+				updateData := storedTransaction.UpdateChainData(addressTransaction.BlockHeight, addressTransaction.Time, addressTransaction.Amount, addressTransaction.Fee, addressTransaction.Confirmations)
+				if err := database.UpdateTransaction(tx, storedTransaction, updateData); err != nil {
+					return err
+				}
+				continue
+			}
+			if newTxids[addressTransaction.Txid] {
+				continue
+			}
+			newTxids[addressTransaction.Txid] = true
+			// A mempool transaction has no block time, it is stored with the time it was first seen
+			transactionTime := addressTransaction.Time
+			if addressTransaction.BlockHeight == nil {
+				transactionTime = now
+			}
+			newTransactions = append(newTransactions, entities.CreateTransaction(lockedAccount.Id, addressTransaction.Txid, addressTransaction.BlockHeight, transactionTime, addressTransaction.Amount, addressTransaction.Fee, addressTransaction.Confirmations))
+		}
+		if err := database.CreateTransactions(tx, newTransactions); err != nil {
+			return err
+		}
+		if !isComplete {
+			return nil
+		}
+		return database.DeleteUnconfirmedTransactionsExcept(tx, lockedAccount.Id, txids)
+	}, database.DefaultTxOptions)
+}
@@ -0,0 +1,56 @@
+package portfolioModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type GetPortfolioByIdRequestDto struct {
+	Id int64 `uri:"id" json:"id" validate:"min=1" example:"1"`
+}
+
+var getPortfolioByIdRequestDtoValidator *validator.Validate
+
+func init() {
+	getPortfolioByIdRequestDtoValidator = validator.New()
+}
+
+func validateGetPortfolioByIdRequestDto(dto *GetPortfolioByIdRequestDto) error {
+	return getPortfolioByIdRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetPortfolioByIdRequestDto is the Gin version of handling the request
+func CreateGetPortfolioByIdRequestDto(c *gin.Context) (GetPortfolioByIdRequestDto, error) {
+	var dto GetPortfolioByIdRequestDto
+	// Parse uri params into DTO
+	if err := c.ShouldBindUri(&dto); err != nil {
+		errorMessage := GetPortfolioByIdRequestDtoUriParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validateGetPortfolioByIdRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetPortfolioByIdRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func GetPortfolioByIdRequestDtoUriParseErrorMessage(err error) string {
+	return errorMessages.DefaultFieldErrorMessage("Id")
+}
+
+func GetPortfolioByIdRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Id" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -0,0 +1,27 @@
+package portfolioModuleDto
+
+import (
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetPortfolioSummaryRequestDto struct {
+	IncludeOff bool `form:"includeOff" json:"includeOff" example:"false"`
+}
+
+// CreateGetPortfolioSummaryRequestDto is the Gin version of handling the request
+func CreateGetPortfolioSummaryRequestDto(c *gin.Context) (GetPortfolioSummaryRequestDto, error) {
+	var dto GetPortfolioSummaryRequestDto
+	// Parse query params into DTO
+	if err := c.ShouldBindQuery(&dto); err != nil {
+		errorMessage := GetPortfolioSummaryRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	return dto, nil
+}
+
+func GetPortfolioSummaryRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultFieldErrorMessage("IncludeOff")
+}
@@ -0,0 +1,57 @@
+package portfolioModuleDto
+
+import (
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PatchUpdatePortfolioRequestDto struct {
+	Name string `json:"name" validate:"PortfolioNameValidation" example:"Treasury"`
+}
+
+var patchUpdatePortfolioRequestDtoValidator *validator.Validate
+
+func init() {
+	patchUpdatePortfolioRequestDtoValidator = validator.New()
+	_ = patchUpdatePortfolioRequestDtoValidator.RegisterValidation("PortfolioNameValidation", validations.PortfolioNameValidation)
+}
+
+func validatePatchUpdatePortfolioRequestDto(dto *PatchUpdatePortfolioRequestDto) error {
+	return patchUpdatePortfolioRequestDtoValidator.Struct(dto)
+}
+
+// CreatePatchUpdatePortfolioRequestDto is the Gin version for handling the request
+func CreatePatchUpdatePortfolioRequestDto(c *gin.Context) (PatchUpdatePortfolioRequestDto, error) {
+	var dto PatchUpdatePortfolioRequestDto
+	// Parse body params into DTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		errorMessage := PatchUpdatePortfolioRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validatePatchUpdatePortfolioRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PatchUpdatePortfolioRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func PatchUpdatePortfolioRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PatchUpdatePortfolioRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Name" && err.Tag() == "PortfolioNameValidation" {
+		errorMessage = PortfolioNameErrorMessage(err.Field())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
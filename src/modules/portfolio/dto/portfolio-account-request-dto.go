@@ -0,0 +1,62 @@
+package portfolioModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PortfolioAccountRequestDto struct {
+	Id        int64 `uri:"id" json:"id" validate:"min=1" example:"1"`
+	AccountId int64 `uri:"accountId" json:"accountId" validate:"min=1" example:"1"`
+}
+
+var portfolioAccountRequestDtoValidator *validator.Validate
+
+func init() {
+	portfolioAccountRequestDtoValidator = validator.New()
+}
+
+func validatePortfolioAccountRequestDto(dto *PortfolioAccountRequestDto) error {
+	return portfolioAccountRequestDtoValidator.Struct(dto)
+}
+
+// CreatePortfolioAccountRequestDto is the Gin version of handling the request
+func CreatePortfolioAccountRequestDto(c *gin.Context) (PortfolioAccountRequestDto, error) {
+	var dto PortfolioAccountRequestDto
+	// Parse uri params into DTO
+	if err := c.ShouldBindUri(&dto); err != nil {
+		errorMessage := PortfolioAccountRequestDtoUriParseErrorMessage(c, err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validatePortfolioAccountRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PortfolioAccountRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+// PortfolioAccountRequestDtoUriParseErrorMessage names the path param that failed, the bind error does not tell it
+func PortfolioAccountRequestDtoUriParseErrorMessage(c *gin.Context, err error) string {
+	if _, parseErr := strconv.ParseInt(c.Param("id"), 10, 64); parseErr != nil {
+		return errorMessages.DefaultFieldErrorMessage("Id")
+	}
+	return errorMessages.DefaultFieldErrorMessage("AccountId")
+}
+
+func PortfolioAccountRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if (err.Field() == "Id" || err.Field() == "AccountId") && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
@@ -0,0 +1,90 @@
+package portfolioModuleDto
+
+import (
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+)
+
+type PortfolioDto struct {
+	Id        int64  `json:"id" example:"1"`
+	Name      string `json:"name" example:"Treasury"`
+	CreatedAt int64  `json:"created_at" example:"1600000000000"`
+	UpdatedAt int64  `json:"updated_at" example:"1600000000000"`
+}
+
+func CreatePortfolioDto(portfolio *entities.Portfolio) PortfolioDto {
+	return PortfolioDto{
+		Id:        portfolio.Id,
+		Name:      portfolio.Name,
+		CreatedAt: portfolio.CreatedAt,
+		UpdatedAt: portfolio.UpdatedAt,
+	}
+}
+
+type GetPortfoliosResponseDto struct {
+	List []PortfolioDto `json:"list"`
+}
+
+func CreateGetPortfoliosResponseDto(portfolios []*entities.Portfolio) GetPortfoliosResponseDto {
+	var dto GetPortfoliosResponseDto
+	dto.List = make([]PortfolioDto, 0)
+	for _, portfolio := range portfolios {
+		dto.List = append(dto.List, CreatePortfolioDto(portfolio))
+	}
+	return dto
+}
+
+// PortfolioSummaryDto holds the aggregates of the member accounts, the accounts which balances are not polled
+// (Suspended, Archived, Error) are counted only with IncludeOff. Balances has one entry per chain of the members
+type PortfolioSummaryDto struct {
+	IncludeOff   bool                       `json:"includeOff" example:"false"`
+	AccountCount int64                      `json:"accountCount" example:"2"`
+	Balances     []PortfolioChainBalanceDto `json:"balances"`
+	MaxRank      *uint8                     `json:"maxRank" example:"75"`
+	MinRank      *uint8                     `json:"minRank" example:"50"`
+}
+
+// PortfolioChainBalanceDto Balance is in Currency, the currency of the chain
+type PortfolioChainBalanceDto struct {
+	Chain        entities.AccountChain `json:"chain" example:"BTC"`
+	Currency     string                `json:"currency" example:"BTC"`
+	AccountCount int64                 `json:"accountCount" example:"2"`
+	Balance      string                `json:"balance" example:"0.96281062"`
+}
+
+func CreatePortfolioSummaryDto(summary database.PortfolioSummary, includeOff bool) PortfolioSummaryDto {
+	dto := PortfolioSummaryDto{
+		IncludeOff:   includeOff,
+		AccountCount: summary.AccountCount,
+		Balances:     make([]PortfolioChainBalanceDto, 0),
+		MaxRank:      summary.MaxRank,
+		MinRank:      summary.MinRank,
+	}
+	for _, chainBalance := range summary.Balances {
+		dto.Balances = append(dto.Balances, PortfolioChainBalanceDto{
+			Chain:        chainBalance.Chain,
+			Currency:     chainBalance.Chain.GetCurrency(),
+			AccountCount: chainBalance.AccountCount,
+			Balance:      chainBalance.Balance.String(),
+		})
+	}
+	return dto
+}
+
+type GetPortfolioResponseDto struct {
+	PortfolioDto
+	Accounts []accountModuleDto.AccountDto `json:"accounts"`
+	Summary  PortfolioSummaryDto           `json:"summary"`
+}
+
+func CreateGetPortfolioResponseDto(portfolio *entities.Portfolio, accounts []*entities.Account, summary database.PortfolioSummary, includeOff bool) GetPortfolioResponseDto {
+	var dto GetPortfolioResponseDto
+	dto.PortfolioDto = CreatePortfolioDto(portfolio)
+	dto.Accounts = make([]accountModuleDto.AccountDto, 0)
+	for _, account := range accounts {
+		dto.Accounts = append(dto.Accounts, accountModuleDto.CreateAccountDto(account))
+	}
+	dto.Summary = CreatePortfolioSummaryDto(summary, includeOff)
+	return dto
+}
@@ -0,0 +1,62 @@
+package portfolioModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PostCreatePortfolioRequestDto struct {
+	Name string `json:"name" validate:"PortfolioNameValidation" example:"Treasury"`
+}
+
+var postCreatePortfolioRequestDtoValidator *validator.Validate
+
+func init() {
+	postCreatePortfolioRequestDtoValidator = validator.New()
+	_ = postCreatePortfolioRequestDtoValidator.RegisterValidation("PortfolioNameValidation", validations.PortfolioNameValidation)
+}
+
+func validatePostCreatePortfolioRequestDto(dto *PostCreatePortfolioRequestDto) error {
+	return postCreatePortfolioRequestDtoValidator.Struct(dto)
+}
+
+// CreatePostCreatePortfolioRequestDto is the Gin version for handling the request
+func CreatePostCreatePortfolioRequestDto(c *gin.Context) (PostCreatePortfolioRequestDto, error) {
+	var dto PostCreatePortfolioRequestDto
+	// Parse body params into DTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		errorMessage := PostCreatePortfolioRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validatePostCreatePortfolioRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PostCreatePortfolioRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func PostCreatePortfolioRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PostCreatePortfolioRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Name" && err.Tag() == "PortfolioNameValidation" {
+		errorMessage = PortfolioNameErrorMessage(err.Field())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
+
+func PortfolioNameErrorMessage(field string) string {
+	return fmt.Sprintf("%s must be between 1 and 255 characters", field)
+}
@@ -0,0 +1,189 @@
+package portfolioModule
+
+import (
+	"go-gin-test-job/src/common/dto"
+	portfolioModuleDto "go-gin-test-job/src/modules/portfolio/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPortfolios Get list of portfolios
+// @Summary Get list of portfolios
+// @Description Get all portfolios ordered by name
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} portfolioModuleDto.GetPortfoliosResponseDto
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Router /portfolio [get]
+func GetPortfolios(c *gin.Context) {
+	c.JSON(200, portfolioModuleDto.CreateGetPortfoliosResponseDto(getPortfolios()))
+}
+
+// GetPortfolioById Get portfolio by id
+// @Summary Get portfolio by id
+// @Description Get portfolio with its member accounts and the aggregated account count, max/min rank and confirmed balance of every chain.
+// @Description Aggregates ignore Suspended, Archived and Error accounts unless includeOff=true, the member list always has every account.
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param id path int true "Portfolio id" minimum(1)
+// @Param includeOff query bool false "Include Suspended, Archived and Error accounts in the aggregates" default(false)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} portfolioModuleDto.GetPortfolioResponseDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /portfolio/{id} [get]
+func GetPortfolioById(c *gin.Context) {
+	idDto, err := portfolioModuleDto.CreateGetPortfolioByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	summaryDto, err := portfolioModuleDto.CreateGetPortfolioSummaryRequestDto(c)
+	if err != nil {
+		return
+	}
+	portfolio, err := getPortfolioById(c, idDto.Id)
+	if err != nil {
+		return
+	}
+	accounts := getPortfolioAccounts(portfolio)
+	summary := getPortfolioSummary(portfolio, summaryDto.IncludeOff)
+	c.JSON(200, portfolioModuleDto.CreateGetPortfolioResponseDto(portfolio, accounts, summary, summaryDto.IncludeOff))
+}
+
+// CreatePortfolio Create new portfolio
+// @Summary Create new portfolio
+// @Description Create new empty portfolio, portfolio names are unique
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Retries with the same key get the stored response, it is kept for a day by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Param request body portfolioModuleDto.PostCreatePortfolioRequestDto true "Request body"
+// @Success 200 {object} portfolioModuleDto.PortfolioDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Failure 422 {object} errorHelpers.ResponseUnprocessableEntityErrorHTTP{}
+// @Router /portfolio [post]
+func CreatePortfolio(c *gin.Context) {
+	dto, err := portfolioModuleDto.CreatePostCreatePortfolioRequestDto(c)
+	if err != nil {
+		return
+	}
+	portfolio, err := createPortfolio(c, dto)
+	if err != nil {
+		return
+	}
+	c.JSON(200, portfolioModuleDto.CreatePortfolioDto(portfolio))
+}
+
+// UpdatePortfolio Rename portfolio
+// @Summary Rename portfolio
+// @Description Rename portfolio, the member accounts are kept
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param id path int true "Portfolio id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Param request body portfolioModuleDto.PatchUpdatePortfolioRequestDto true "Request body"
+// @Success 200 {object} portfolioModuleDto.PortfolioDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Router /portfolio/{id} [patch]
+func UpdatePortfolio(c *gin.Context) {
+	idDto, err := portfolioModuleDto.CreateGetPortfolioByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	dto, err := portfolioModuleDto.CreatePatchUpdatePortfolioRequestDto(c)
+	if err != nil {
+		return
+	}
+	portfolio, err := updatePortfolio(c, idDto.Id, dto)
+	if err != nil {
+		return
+	}
+	c.JSON(200, portfolioModuleDto.CreatePortfolioDto(portfolio))
+}
+
+// DeletePortfolio Delete portfolio
+// @Summary Delete portfolio
+// @Description Delete portfolio, the member accounts are not touched
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param id path int true "Portfolio id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} dto.SuccessDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /portfolio/{id} [delete]
+func DeletePortfolio(c *gin.Context) {
+	idDto, err := portfolioModuleDto.CreateGetPortfolioByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	if err := deletePortfolio(c, idDto.Id); err != nil {
+		return
+	}
+	c.JSON(200, dto.CreateSuccessDto())
+}
+
+// AddPortfolioAccount Add account to portfolio
+// @Summary Add account to portfolio
+// @Description Add account to portfolio, adding a member account again is a no-op
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param id path int true "Portfolio id" minimum(1)
+// @Param accountId path int true "Account id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} portfolioModuleDto.PortfolioDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /portfolio/{id}/account/{accountId} [put]
+func AddPortfolioAccount(c *gin.Context) {
+	dto, err := portfolioModuleDto.CreatePortfolioAccountRequestDto(c)
+	if err != nil {
+		return
+	}
+	portfolio, err := addPortfolioAccount(c, dto.Id, dto.AccountId)
+	if err != nil {
+		return
+	}
+	c.JSON(200, portfolioModuleDto.CreatePortfolioDto(portfolio))
+}
+
+// RemovePortfolioAccount Remove account from portfolio
+// @Summary Remove account from portfolio
+// @Description Remove account from portfolio, removing an account that is not a member is a no-op
+// @Tags Portfolio
+// @Accept json
+// @Produce json
+// @Param id path int true "Portfolio id" minimum(1)
+// @Param accountId path int true "Account id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} portfolioModuleDto.PortfolioDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /portfolio/{id}/account/{accountId} [delete]
+func RemovePortfolioAccount(c *gin.Context) {
+	dto, err := portfolioModuleDto.CreatePortfolioAccountRequestDto(c)
+	if err != nil {
+		return
+	}
+	portfolio, err := removePortfolioAccount(c, dto.Id, dto.AccountId)
+	if err != nil {
+		return
+	}
+	c.JSON(200, portfolioModuleDto.CreatePortfolioDto(portfolio))
+}
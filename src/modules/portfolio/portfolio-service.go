@@ -0,0 +1,109 @@
+package portfolioModule
+
+import (
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	portfolioModuleDto "go-gin-test-job/src/modules/portfolio/dto"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func getPortfolios() []*entities.Portfolio {
+	return database.GetPortfolios()
+}
+
+func getPortfolioById(c *gin.Context, id int64) (*entities.Portfolio, error) {
+	portfolio := database.GetPortfolioById(id)
+	if portfolio == nil {
+		return nil, errorHelpers.RespondNotFoundError(c, "Portfolio not found")
+	}
+	return portfolio, nil
+}
+
+func getPortfolioAccounts(portfolio *entities.Portfolio) []*entities.Account {
+	return database.GetPortfolioAccounts(portfolio.Id)
+}
+
+func getPortfolioSummary(portfolio *entities.Portfolio, includeOff bool) database.PortfolioSummary {
+	return database.GetPortfolioSummary(portfolio.Id, includeOff)
+}
+
+func createPortfolio(c *gin.Context, dto portfolioModuleDto.PostCreatePortfolioRequestDto) (*entities.Portfolio, error) {
+	var portfolio *entities.Portfolio
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		if database.IsPortfolioNameExists(tx, dto.Name, 0) {
+			return errorHelpers.RespondConflictError(c, "Portfolio name already exists")
+		}
+		var err error
+		portfolio, err = database.CreatePortfolio(tx, entities.CreatePortfolio(dto.Name))
+		return err
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	// Reload to pick up the timestamps set by the database trigger
+	return getPortfolioById(c, portfolio.Id)
+}
+
+func updatePortfolio(c *gin.Context, id int64, dto portfolioModuleDto.PatchUpdatePortfolioRequestDto) (*entities.Portfolio, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		portfolio := database.GetPortfolioByIdForUpdate(tx, id)
+		if portfolio == nil {
+			return errorHelpers.RespondNotFoundError(c, "Portfolio not found")
+		}
+		if portfolio.Name == dto.Name {
+			return nil
+		}
+		if database.IsPortfolioNameExists(tx, dto.Name, portfolio.Id) {
+			return errorHelpers.RespondConflictError(c, "Portfolio name already exists")
+		}
+		return database.UpdatePortfolio(tx, portfolio, portfolio.UpdateName(dto.Name))
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getPortfolioById(c, id)
+}
+
+func deletePortfolio(c *gin.Context, id int64) error {
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		portfolio := database.GetPortfolioByIdForUpdate(tx, id)
+		if portfolio == nil {
+			return errorHelpers.RespondNotFoundError(c, "Portfolio not found")
+		}
+		return database.DeletePortfolio(tx, portfolio)
+	}, database.DefaultTxOptions)
+}
+
+func addPortfolioAccount(c *gin.Context, id int64, accountId int64) (*entities.Portfolio, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		portfolio := database.GetPortfolioByIdForUpdate(tx, id)
+		if portfolio == nil {
+			return errorHelpers.RespondNotFoundError(c, "Portfolio not found")
+		}
+		if database.GetAccountByIdForUpdate(tx, accountId) == nil {
+			return errorHelpers.RespondNotFoundError(c, "Account not found")
+		}
+		return database.AddPortfolioAccount(tx, portfolio.Id, accountId)
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getPortfolioById(c, id)
+}
+
+func removePortfolioAccount(c *gin.Context, id int64, accountId int64) (*entities.Portfolio, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		portfolio := database.GetPortfolioByIdForUpdate(tx, id)
+		if portfolio == nil {
+			return errorHelpers.RespondNotFoundError(c, "Portfolio not found")
+		}
+		return database.RemovePortfolioAccount(tx, portfolio.Id, accountId)
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getPortfolioById(c, id)
+}
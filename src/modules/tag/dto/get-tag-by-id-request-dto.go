@@ -0,0 +1,56 @@
+package tagModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type GetTagByIdRequestDto struct {
+	Id int64 `uri:"id" json:"id" validate:"min=1" example:"1"`
+}
+
+var getTagByIdRequestDtoValidator *validator.Validate
+
+func init() {
+	getTagByIdRequestDtoValidator = validator.New()
+}
+
+func validateGetTagByIdRequestDto(dto *GetTagByIdRequestDto) error {
+	return getTagByIdRequestDtoValidator.Struct(dto)
+}
+
+// CreateGetTagByIdRequestDto is the Gin version of handling the request
+func CreateGetTagByIdRequestDto(c *gin.Context) (GetTagByIdRequestDto, error) {
+	var dto GetTagByIdRequestDto
+	// Parse uri params into DTO
+	if err := c.ShouldBindUri(&dto); err != nil {
+		errorMessage := GetTagByIdRequestDtoUriParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validateGetTagByIdRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := GetTagByIdRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func GetTagByIdRequestDtoUriParseErrorMessage(err error) string {
+	return errorMessages.DefaultFieldErrorMessage("Id")
+}
+
+func GetTagByIdRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Id" && err.Tag() == "min" {
+		errorMessage = fmt.Sprintf("%s must be greater than or equal %s", err.Field(), err.Param())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
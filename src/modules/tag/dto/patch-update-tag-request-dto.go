@@ -0,0 +1,57 @@
+package tagModuleDto
+
+import (
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PatchUpdateTagRequestDto struct {
+	Name string `json:"name" validate:"TagNameValidation" example:"exchange"`
+}
+
+var patchUpdateTagRequestDtoValidator *validator.Validate
+
+func init() {
+	patchUpdateTagRequestDtoValidator = validator.New()
+	_ = patchUpdateTagRequestDtoValidator.RegisterValidation("TagNameValidation", validations.TagNameValidation)
+}
+
+func validatePatchUpdateTagRequestDto(dto *PatchUpdateTagRequestDto) error {
+	return patchUpdateTagRequestDtoValidator.Struct(dto)
+}
+
+// CreatePatchUpdateTagRequestDto is the Gin version for handling the request
+func CreatePatchUpdateTagRequestDto(c *gin.Context) (PatchUpdateTagRequestDto, error) {
+	var dto PatchUpdateTagRequestDto
+	// Parse body params into DTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		errorMessage := PatchUpdateTagRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validatePatchUpdateTagRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PatchUpdateTagRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func PatchUpdateTagRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PatchUpdateTagRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Name" && err.Tag() == "TagNameValidation" {
+		errorMessage = TagNameErrorMessage(err.Field())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
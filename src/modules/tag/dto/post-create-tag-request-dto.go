@@ -0,0 +1,62 @@
+package tagModuleDto
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	errorMessages "go-gin-test-job/src/common/error-messages"
+	"go-gin-test-job/src/common/validations"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PostCreateTagRequestDto struct {
+	Name string `json:"name" validate:"TagNameValidation" example:"exchange"`
+}
+
+var postCreateTagRequestDtoValidator *validator.Validate
+
+func init() {
+	postCreateTagRequestDtoValidator = validator.New()
+	_ = postCreateTagRequestDtoValidator.RegisterValidation("TagNameValidation", validations.TagNameValidation)
+}
+
+func validatePostCreateTagRequestDto(dto *PostCreateTagRequestDto) error {
+	return postCreateTagRequestDtoValidator.Struct(dto)
+}
+
+// CreatePostCreateTagRequestDto is the Gin version for handling the request
+func CreatePostCreateTagRequestDto(c *gin.Context) (PostCreateTagRequestDto, error) {
+	var dto PostCreateTagRequestDto
+	// Parse body params into DTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		errorMessage := PostCreateTagRequestDtoQueryParseErrorMessage(err)
+		return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+	}
+	// Validate the DTO
+	if err := validatePostCreateTagRequestDto(&dto); err != nil {
+		for _, err := range err.(validator.ValidationErrors) {
+			errorMessage := PostCreateTagRequestDtoValidateErrorMessage(err)
+			return dto, errorHelpers.RespondBadRequestError(c, errorMessage)
+		}
+	}
+	return dto, nil
+}
+
+func PostCreateTagRequestDtoQueryParseErrorMessage(err error) string {
+	return errorMessages.DefaultQueryParseErrorMessage()
+}
+
+func PostCreateTagRequestDtoValidateErrorMessage(err validator.FieldError) string {
+	var errorMessage string
+	if err.Field() == "Name" && err.Tag() == "TagNameValidation" {
+		errorMessage = TagNameErrorMessage(err.Field())
+	} else {
+		errorMessage = errorMessages.DefaultFieldErrorMessage(err.Field())
+	}
+	return errorMessage
+}
+
+func TagNameErrorMessage(field string) string {
+	return fmt.Sprintf("%s must be 1 to 64 characters of lowercase letters, digits, '.', '_' or '-' starting with a letter or digit", field)
+}
@@ -0,0 +1,34 @@
+package tagModuleDto
+
+import (
+	"go-gin-test-job/src/database/entities"
+)
+
+type TagDto struct {
+	Id        int64  `json:"id" example:"1"`
+	Name      string `json:"name" example:"exchange"`
+	CreatedAt int64  `json:"created_at" example:"1600000000000"`
+	UpdatedAt int64  `json:"updated_at" example:"1600000000000"`
+}
+
+func CreateTagDto(tag *entities.Tag) TagDto {
+	return TagDto{
+		Id:        tag.Id,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
+
+type GetTagsResponseDto struct {
+	List []TagDto `json:"list"`
+}
+
+func CreateGetTagsResponseDto(tags []*entities.Tag) GetTagsResponseDto {
+	var dto GetTagsResponseDto
+	dto.List = make([]TagDto, 0)
+	for _, tag := range tags {
+		dto.List = append(dto.List, CreateTagDto(tag))
+	}
+	return dto
+}
@@ -0,0 +1,129 @@
+package tagModule
+
+import (
+	"go-gin-test-job/src/common/dto"
+	tagModuleDto "go-gin-test-job/src/modules/tag/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTags Get list of tags
+// @Summary Get list of tags
+// @Description Get all tags ordered by name
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} tagModuleDto.GetTagsResponseDto
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Router /tag [get]
+func GetTags(c *gin.Context) {
+	c.JSON(200, tagModuleDto.CreateGetTagsResponseDto(getTags()))
+}
+
+// GetTagById Get tag by id
+// @Summary Get tag by id
+// @Description Get single tag by its numeric id
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} tagModuleDto.TagDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /tag/{id} [get]
+func GetTagById(c *gin.Context) {
+	idDto, err := tagModuleDto.CreateGetTagByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	tag, err := getTagById(c, idDto.Id)
+	if err != nil {
+		return
+	}
+	c.JSON(200, tagModuleDto.CreateTagDto(tag))
+}
+
+// CreateTag Create new tag
+// @Summary Create new tag
+// @Description Create new tag. Tag names are unique lowercase slugs like "customer-deposit".
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Retries with the same key get the stored response, it is kept for a day by default"
+// @Param X-API-Key header string true "Admin api key"
+// @Param request body tagModuleDto.PostCreateTagRequestDto true "Request body"
+// @Success 200 {object} tagModuleDto.TagDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Failure 422 {object} errorHelpers.ResponseUnprocessableEntityErrorHTTP{}
+// @Router /tag [post]
+func CreateTag(c *gin.Context) {
+	dto, err := tagModuleDto.CreatePostCreateTagRequestDto(c)
+	if err != nil {
+		return
+	}
+	tag, err := createTag(c, dto)
+	if err != nil {
+		return
+	}
+	c.JSON(200, tagModuleDto.CreateTagDto(tag))
+}
+
+// UpdateTag Rename tag
+// @Summary Rename tag
+// @Description Rename tag, the accounts keep the tag
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Param request body tagModuleDto.PatchUpdateTagRequestDto true "Request body"
+// @Success 200 {object} tagModuleDto.TagDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Failure 409 {object} errorHelpers.ResponseConflictErrorHTTP{}
+// @Router /tag/{id} [patch]
+func UpdateTag(c *gin.Context) {
+	idDto, err := tagModuleDto.CreateGetTagByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	dto, err := tagModuleDto.CreatePatchUpdateTagRequestDto(c)
+	if err != nil {
+		return
+	}
+	tag, err := updateTag(c, idDto.Id, dto)
+	if err != nil {
+		return
+	}
+	c.JSON(200, tagModuleDto.CreateTagDto(tag))
+}
+
+// DeleteTag Delete tag
+// @Summary Delete tag
+// @Description Delete tag and detach it from every account, every detached account gets a tag_detach audit entry
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag id" minimum(1)
+// @Param X-API-Key header string true "Admin api key"
+// @Success 200 {object} dto.SuccessDto
+// @Failure 400 {object} errorHelpers.ResponseBadRequestErrorHTTP{}
+// @Failure 401 {object} errorHelpers.ResponseUnauthorizedErrorHTTP{}
+// @Failure 404 {object} errorHelpers.ResponseNotFoundErrorHTTP{}
+// @Router /tag/{id} [delete]
+func DeleteTag(c *gin.Context) {
+	idDto, err := tagModuleDto.CreateGetTagByIdRequestDto(c)
+	if err != nil {
+		return
+	}
+	if err := deleteTag(c, idDto.Id); err != nil {
+		return
+	}
+	c.JSON(200, dto.CreateSuccessDto())
+}
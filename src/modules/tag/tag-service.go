@@ -0,0 +1,71 @@
+package tagModule
+
+import (
+	auditContext "go-gin-test-job/src/common/audit-context"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	tagModuleDto "go-gin-test-job/src/modules/tag/dto"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func getTags() []*entities.Tag {
+	return database.GetTags()
+}
+
+func getTagById(c *gin.Context, id int64) (*entities.Tag, error) {
+	tag := database.GetTagById(id)
+	if tag == nil {
+		return nil, errorHelpers.RespondNotFoundError(c, "Tag not found")
+	}
+	return tag, nil
+}
+
+func createTag(c *gin.Context, dto tagModuleDto.PostCreateTagRequestDto) (*entities.Tag, error) {
+	var tag *entities.Tag
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		if database.IsTagNameExists(tx, dto.Name, 0) {
+			return errorHelpers.RespondConflictError(c, "Tag name already exists")
+		}
+		var err error
+		tag, err = database.CreateTag(tx, entities.CreateTag(dto.Name))
+		return err
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	// Reload to pick up the timestamps set by the database trigger
+	return getTagById(c, tag.Id)
+}
+
+func updateTag(c *gin.Context, id int64, dto tagModuleDto.PatchUpdateTagRequestDto) (*entities.Tag, error) {
+	transactionError := database.DbConn.Transaction(func(tx *gorm.DB) error {
+		tag := database.GetTagByIdForUpdate(tx, id)
+		if tag == nil {
+			return errorHelpers.RespondNotFoundError(c, "Tag not found")
+		}
+		if tag.Name == dto.Name {
+			return nil
+		}
+		if database.IsTagNameExists(tx, dto.Name, tag.Id) {
+			return errorHelpers.RespondConflictError(c, "Tag name already exists")
+		}
+		return database.UpdateTag(tx, tag, tag.UpdateName(dto.Name))
+	}, database.DefaultTxOptions)
+	if transactionError != nil {
+		return nil, transactionError
+	}
+	return getTagById(c, id)
+}
+
+func deleteTag(c *gin.Context, id int64) error {
+	return database.DbConn.Transaction(func(tx *gorm.DB) error {
+		tag := database.GetTagByIdForUpdate(tx, id)
+		if tag == nil {
+			return errorHelpers.RespondNotFoundError(c, "Tag not found")
+		}
+		return database.DeleteTag(tx, auditContext.Get(c), tag)
+	}, database.DefaultTxOptions)
+}
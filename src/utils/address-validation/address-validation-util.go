@@ -1,11 +1,179 @@
 package addressValidationUtil
 
 import (
-	"regexp"
+	"errors"
+	"slices"
+	"strings"
 )
 
-var addressRegex = regexp.MustCompile(`^[13][a-km-zA-HJ-NP-Z0-9]{26,33}$`)
+type AddressType string
 
-func IsValidAddress(address string) bool {
-	return addressRegex.MatchString(address)
+const (
+	AddressTypeP2pkh  AddressType = "p2pkh"
+	AddressTypeP2sh   AddressType = "p2sh"
+	AddressTypeP2wpkh AddressType = "p2wpkh"
+	AddressTypeP2wsh  AddressType = "p2wsh"
+	AddressTypeP2tr   AddressType = "p2tr"
+)
+
+// Chain is the Bitcore chain code, it is the currency code of the balance too
+type Chain string
+
+const (
+	ChainBtc  Chain = "BTC"
+	ChainLtc  Chain = "LTC"
+	ChainBch  Chain = "BCH"
+	ChainDoge Chain = "DOGE"
+)
+
+type Network string
+
+const (
+	NetworkMainnet Network = "mainnet"
+	NetworkTestnet Network = "testnet"
+	NetworkSignet  Network = "signet"
+	NetworkRegtest Network = "regtest"
+)
+
+// addressParams is the address format of a chain network: the version bytes of the Base58Check addresses,
+// the human-readable part of the SegWit addresses and the CashAddr prefix, an empty one is not supported
+type addressParams struct {
+	p2pkhVersions  []byte
+	p2shVersions   []byte
+	segwitHrp      string
+	cashAddrPrefix string
+}
+
+// Litecoin keeps accepting the Bitcoin P2SH version byte next to its own one.
+// Signet is a Bitcoin network only
+var addressParamsList = map[Chain]map[Network]addressParams{
+	ChainBtc: {
+		NetworkMainnet: {p2pkhVersions: []byte{0x00}, p2shVersions: []byte{0x05}, segwitHrp: "bc"},
+		NetworkTestnet: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0xc4}, segwitHrp: "tb"},
+		NetworkSignet:  {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0xc4}, segwitHrp: "tb"},
+		NetworkRegtest: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0xc4}, segwitHrp: "bcrt"},
+	},
+	ChainLtc: {
+		NetworkMainnet: {p2pkhVersions: []byte{0x30}, p2shVersions: []byte{0x32, 0x05}, segwitHrp: "ltc"},
+		NetworkTestnet: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0x3a, 0xc4}, segwitHrp: "tltc"},
+		NetworkRegtest: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0x3a, 0xc4}, segwitHrp: "rltc"},
+	},
+	ChainBch: {
+		NetworkMainnet: {p2pkhVersions: []byte{0x00}, p2shVersions: []byte{0x05}, cashAddrPrefix: "bitcoincash"},
+		NetworkTestnet: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0xc4}, cashAddrPrefix: "bchtest"},
+		NetworkRegtest: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0xc4}, cashAddrPrefix: "bchreg"},
+	},
+	ChainDoge: {
+		NetworkMainnet: {p2pkhVersions: []byte{0x1e}, p2shVersions: []byte{0x16}},
+		NetworkTestnet: {p2pkhVersions: []byte{0x71}, p2shVersions: []byte{0xc4}},
+		NetworkRegtest: {p2pkhVersions: []byte{0x6f}, p2shVersions: []byte{0xc4}},
+	},
+}
+
+const hash160Length = 20
+
+var errNetwork = errors.New("unknown chain network")
+var errAddressVersion = errors.New("unknown address version")
+var errWitnessProgram = errors.New("unsupported witness program")
+
+// IsValidChainNetwork checks the chain has the network
+func IsValidChainNetwork(chain Chain, network Network) bool {
+	_, exists := addressParamsList[chain][network]
+	return exists
+}
+
+func IsValidAddress(chain Chain, network Network, address string) bool {
+	_, err := DecodeAddress(chain, network, address)
+	return err == nil
+}
+
+// GetAddressType returns the type of a valid address, empty for an invalid one
+func GetAddressType(chain Chain, network Network, address string) AddressType {
+	addressType, _ := DecodeAddress(chain, network, address)
+	return addressType
+}
+
+// DecodeAddress checks the address checksum and structure for the chain network and classifies it.
+// Base58Check addresses are P2PKH or P2SH by the version byte, SegWit addresses are bech32 (BIP-173)
+// for witness version 0 and bech32m (BIP-350) for the later versions, CashAddr addresses are
+// P2PKH or P2SH by the type bits of the version byte
+func DecodeAddress(chain Chain, network Network, address string) (AddressType, error) {
+	addressType, _, err := decodeAddress(chain, network, address)
+	return addressType, err
+}
+
+// decodeAddress returns the address type with the hash of the P2PKH and P2SH addresses
+// or the witness program of the SegWit addresses
+func decodeAddress(chain Chain, network Network, address string) (AddressType, []byte, error) {
+	params, exists := addressParamsList[chain][network]
+	if !exists {
+		return "", nil, errNetwork
+	}
+	if params.segwitHrp != "" && strings.HasPrefix(strings.ToLower(address), params.segwitHrp+"1") {
+		return decodeSegwitAddress(params.segwitHrp, address)
+	}
+	if params.cashAddrPrefix != "" && isCashAddr(params.cashAddrPrefix, address) {
+		return decodeCashAddr(params.cashAddrPrefix, address)
+	}
+	payload, err := decodeBase58Check(address)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(payload) != 1+hash160Length {
+		return "", nil, errAddressVersion
+	}
+	if slices.Contains(params.p2pkhVersions, payload[0]) {
+		return AddressTypeP2pkh, payload[1:], nil
+	}
+	if slices.Contains(params.p2shVersions, payload[0]) {
+		return AddressTypeP2sh, payload[1:], nil
+	}
+	return "", nil, errAddressVersion
+}
+
+// isCashAddr tells a CashAddr address from a Base58Check one, the payload of the 160-bit
+// CashAddr types starts with q or p which no Base58Check address of the networks does
+func isCashAddr(prefix string, address string) bool {
+	lowerAddress := strings.ToLower(address)
+	return strings.HasPrefix(lowerAddress, prefix+":") || strings.HasPrefix(lowerAddress, "q") || strings.HasPrefix(lowerAddress, "p")
+}
+
+// decodeSegwitAddress applies the witness version and program length rules, the unassigned
+// witness versions are valid by BIP-350 but are rejected because they can not be classified yet
+func decodeSegwitAddress(hrp string, address string) (AddressType, []byte, error) {
+	witnessVersion, program, err := decodeSegwit(hrp, address)
+	if err != nil {
+		return "", nil, err
+	}
+	switch {
+	case witnessVersion == 0 && len(program) == 20:
+		return AddressTypeP2wpkh, program, nil
+	case witnessVersion == 0 && len(program) == 32:
+		return AddressTypeP2wsh, program, nil
+	case witnessVersion == 1 && len(program) == 32:
+		return AddressTypeP2tr, program, nil
+	}
+	return "", nil, errWitnessProgram
+}
+
+// NormalizeAddress returns the canonical form of the address: SegWit addresses are lowercased,
+// bech32 is case-insensitive, and CashAddr addresses are lowercased with the prefix.
+// Base58Check addresses are case-sensitive and are returned as is, like the addresses of an unknown chain network
+func NormalizeAddress(chain Chain, network Network, address string) string {
+	params, exists := addressParamsList[chain][network]
+	if !exists {
+		return address
+	}
+	lowerAddress := strings.ToLower(address)
+	if params.segwitHrp != "" && strings.HasPrefix(lowerAddress, params.segwitHrp+"1") {
+		return lowerAddress
+	}
+	if params.cashAddrPrefix != "" && isCashAddr(params.cashAddrPrefix, address) {
+		payload, err := splitCashAddr(params.cashAddrPrefix, address)
+		if err != nil {
+			return address
+		}
+		return params.cashAddrPrefix + ":" + payload
+	}
+	return address
 }
@@ -0,0 +1,47 @@
+package addressValidationUtil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58MaxLength is longer than any Base58Check address, it bounds the big number decoding
+const base58MaxLength = 64
+
+var errBase58Character = errors.New("invalid base58 character")
+var errBase58Checksum = errors.New("invalid base58 checksum")
+
+// decodeBase58Check returns the payload with the version byte, the checksum is verified and removed
+func decodeBase58Check(address string) ([]byte, error) {
+	if address == "" || len(address) > base58MaxLength {
+		return nil, errBase58Character
+	}
+	value := new(big.Int)
+	radix := big.NewInt(int64(len(base58Alphabet)))
+	for _, character := range address {
+		index := strings.IndexRune(base58Alphabet, character)
+		if index < 0 {
+			return nil, errBase58Character
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(index)))
+	}
+	// Every leading 1 is a leading zero byte, the number drops them
+	leadingZeros := len(address) - len(strings.TrimLeft(address, "1"))
+	decoded := append(make([]byte, leadingZeros), value.Bytes()...)
+	if len(decoded) < 5 {
+		return nil, errBase58Checksum
+	}
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	firstHash := sha256.Sum256(payload)
+	secondHash := sha256.Sum256(firstHash[:])
+	if !bytes.Equal(secondHash[:4], checksum) {
+		return nil, errBase58Checksum
+	}
+	return payload, nil
+}
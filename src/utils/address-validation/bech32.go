@@ -0,0 +1,140 @@
+package addressValidationUtil
+
+import (
+	"errors"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// The checksum constants of BIP-173 bech32 and BIP-350 bech32m
+const (
+	bech32Constant  = 1
+	bech32mConstant = 0x2bc830a3
+)
+
+// bech32MaxLength is the BIP-173 limit of the whole string
+const bech32MaxLength = 90
+
+var errBech32Format = errors.New("invalid bech32 format")
+var errBech32Checksum = errors.New("invalid bech32 checksum")
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for index := 0; index < 5; index++ {
+			if (top>>index)&1 == 1 {
+				checksum ^= generator[index]
+			}
+		}
+	}
+	return checksum
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	values := make([]byte, 0, len(hrp)*2+1)
+	for index := 0; index < len(hrp); index++ {
+		values = append(values, hrp[index]>>5)
+	}
+	values = append(values, 0)
+	for index := 0; index < len(hrp); index++ {
+		values = append(values, hrp[index]&31)
+	}
+	return values
+}
+
+// decodeBech32 splits the string into the human-readable part and the 5-bit data without the checksum,
+// it returns the checksum constant the string matched
+func decodeBech32(address string) (string, []byte, uint32, error) {
+	if len(address) > bech32MaxLength {
+		return "", nil, 0, errBech32Format
+	}
+	// Either case is valid, mixed case is not
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", nil, 0, errBech32Format
+	}
+	address = strings.ToLower(address)
+	separator := strings.LastIndexByte(address, '1')
+	if separator < 1 || separator+7 > len(address) {
+		return "", nil, 0, errBech32Format
+	}
+	hrp := address[:separator]
+	for index := 0; index < len(hrp); index++ {
+		if hrp[index] < 33 || hrp[index] > 126 {
+			return "", nil, 0, errBech32Format
+		}
+	}
+	data := make([]byte, 0, len(address)-separator-1)
+	for _, character := range address[separator+1:] {
+		value := strings.IndexRune(bech32Charset, character)
+		if value < 0 {
+			return "", nil, 0, errBech32Format
+		}
+		data = append(data, byte(value))
+	}
+	constant := bech32Polymod(append(bech32HrpExpand(hrp), data...))
+	if constant != bech32Constant && constant != bech32mConstant {
+		return "", nil, 0, errBech32Checksum
+	}
+	return hrp, data[:len(data)-6], constant, nil
+}
+
+// convertBits regroups the bits of the values, the 5-bit to 8-bit conversion rejects non-zero padding
+func convertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	accumulator := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errBech32Format
+		}
+		accumulator = accumulator<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(accumulator>>bits&maxValue))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(accumulator<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || accumulator<<(toBits-bits)&maxValue != 0 {
+		return nil, errBech32Format
+	}
+	return result, nil
+}
+
+// decodeSegwit returns the witness version and program of a SegWit address with the expected hrp.
+// Version 0 must use the bech32 checksum and the later versions bech32m, the program length follows BIP-141 and BIP-350
+func decodeSegwit(expectedHrp string, address string) (byte, []byte, error) {
+	hrp, data, constant, err := decodeBech32(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if hrp != expectedHrp || len(data) < 1 {
+		return 0, nil, errBech32Format
+	}
+	witnessVersion := data[0]
+	if witnessVersion > 16 {
+		return 0, nil, errBech32Format
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, errBech32Format
+	}
+	if witnessVersion == 0 && len(program) != 20 && len(program) != 32 {
+		return 0, nil, errBech32Format
+	}
+	if (witnessVersion == 0 && constant != bech32Constant) || (witnessVersion != 0 && constant != bech32mConstant) {
+		return 0, nil, errBech32Checksum
+	}
+	return witnessVersion, program, nil
+}
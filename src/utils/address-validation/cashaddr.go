@@ -0,0 +1,97 @@
+package addressValidationUtil
+
+import (
+	"errors"
+	"strings"
+)
+
+// cashAddrChecksumLength is the number of 5-bit checksum values, 40 bits
+const cashAddrChecksumLength = 8
+
+// The CashAddr version byte keeps the type in bits 3-6 and the hash size in bits 0-2, size 0 is 160 bits
+const (
+	cashAddrTypeP2pkh = 0
+	cashAddrTypeP2sh  = 1
+	cashAddrSize160   = 0
+)
+
+var errCashAddrFormat = errors.New("invalid cashaddr format")
+var errCashAddrChecksum = errors.New("invalid cashaddr checksum")
+
+func cashAddrPolymod(values []byte) uint64 {
+	generator := [5]uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+	checksum := uint64(1)
+	for _, value := range values {
+		top := checksum >> 35
+		checksum = (checksum&0x07ffffffff)<<5 ^ uint64(value)
+		for index := 0; index < 5; index++ {
+			if (top>>index)&1 == 1 {
+				checksum ^= generator[index]
+			}
+		}
+	}
+	return checksum ^ 1
+}
+
+// cashAddrPrefixExpand takes the lower 5 bits of the prefix characters followed by the zero separator
+func cashAddrPrefixExpand(prefix string) []byte {
+	values := make([]byte, 0, len(prefix)+1)
+	for index := 0; index < len(prefix); index++ {
+		values = append(values, prefix[index]&31)
+	}
+	return append(values, 0)
+}
+
+// splitCashAddr returns the lowercase payload of a CashAddr address with the expected prefix,
+// the prefix may be omitted. Either case is valid, mixed case is not
+func splitCashAddr(expectedPrefix string, address string) (string, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", errCashAddrFormat
+	}
+	address = strings.ToLower(address)
+	prefix, payload, hasPrefix := strings.Cut(address, ":")
+	if !hasPrefix {
+		return prefix, nil
+	}
+	if prefix != expectedPrefix {
+		return "", errCashAddrFormat
+	}
+	return payload, nil
+}
+
+// decodeCashAddr returns the address type and the hash of a CashAddr address with the expected prefix
+func decodeCashAddr(expectedPrefix string, address string) (AddressType, []byte, error) {
+	payload, err := splitCashAddr(expectedPrefix, address)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(payload) <= cashAddrChecksumLength {
+		return "", nil, errCashAddrFormat
+	}
+	data := make([]byte, 0, len(payload))
+	for _, character := range payload {
+		value := strings.IndexRune(bech32Charset, character)
+		if value < 0 {
+			return "", nil, errCashAddrFormat
+		}
+		data = append(data, byte(value))
+	}
+	if cashAddrPolymod(append(cashAddrPrefixExpand(expectedPrefix), data...)) != 0 {
+		return "", nil, errCashAddrChecksum
+	}
+	decoded, err := convertBits(data[:len(data)-cashAddrChecksumLength], 5, 8, false)
+	if err != nil || len(decoded) != 1+hash160Length {
+		return "", nil, errCashAddrFormat
+	}
+	version := decoded[0]
+	if version&0x80 != 0 || version&0x07 != cashAddrSize160 {
+		return "", nil, errCashAddrFormat
+	}
+	switch version >> 3 {
+	case cashAddrTypeP2pkh:
+		return AddressTypeP2pkh, decoded[1:], nil
+	case cashAddrTypeP2sh:
+		return AddressTypeP2sh, decoded[1:], nil
+	}
+	return "", nil, errCashAddrFormat
+}
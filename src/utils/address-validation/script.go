@@ -0,0 +1,32 @@
+package addressValidationUtil
+
+// The script opcodes of the standard output scripts
+const (
+	opDup         = 0x76
+	opHash160     = 0xa9
+	opEqual       = 0x87
+	opEqualVerify = 0x88
+	opCheckSig    = 0xac
+	op0           = 0x00
+	op1           = 0x51
+)
+
+// GetScriptPubKey returns the output script which pays to the address
+func GetScriptPubKey(chain Chain, network Network, address string) ([]byte, error) {
+	addressType, hash, err := decodeAddress(chain, network, address)
+	if err != nil {
+		return nil, err
+	}
+	switch addressType {
+	case AddressTypeP2pkh:
+		script := append([]byte{opDup, opHash160, byte(len(hash))}, hash...)
+		return append(script, opEqualVerify, opCheckSig), nil
+	case AddressTypeP2sh:
+		script := append([]byte{opHash160, byte(len(hash))}, hash...)
+		return append(script, opEqual), nil
+	case AddressTypeP2tr:
+		return append([]byte{op1, byte(len(hash))}, hash...), nil
+	}
+	// Witness version 0, P2WPKH and P2WSH
+	return append([]byte{op0, byte(len(hash))}, hash...), nil
+}
@@ -9,6 +9,28 @@ const (
 	BTCPrecision = 8
 )
 
+// currencyPrecisions is the number of decimal places of the currency base unit, satoshi for BTC, koinu for DOGE
+var currencyPrecisions = map[string]int32{
+	"BTC":  BTCPrecision,
+	"LTC":  8,
+	"BCH":  8,
+	"DOGE": 8,
+}
+
+// GetPrecision returns the base unit decimal places of the currency, BTC precision for an unknown one
+func GetPrecision(currency string) int32 {
+	if precision, exists := currencyPrecisions[currency]; exists {
+		return precision
+	}
+	return BTCPrecision
+}
+
+// FromBaseUnits converts the amount in the currency base units to the currency
+func FromBaseUnits(v interface{}, currency string) decimal.Decimal {
+	precision := GetPrecision(currency)
+	return toDecimal(v).Shift(-precision).Round(precision)
+}
+
 func FromSatoshi(v interface{}) decimal.Decimal {
 	value := toDecimal(v)
 	return value.Div(decimal.NewFromInt(SatoshiInBTC)).Round(BTCPrecision)
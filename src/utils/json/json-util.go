@@ -0,0 +1,40 @@
+package jsonUtil
+
+import (
+	"encoding/json"
+)
+
+// MergePatch applies the JSON merge patch (RFC 7396) to the target document,
+// null members of the patch remove the target members, nested objects are merged recursively
+func MergePatch(target []byte, patch []byte) ([]byte, error) {
+	var targetValue interface{}
+	if len(target) > 0 {
+		if err := json.Unmarshal(target, &targetValue); err != nil {
+			return nil, err
+		}
+	}
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatchValue(targetValue, patchValue))
+}
+
+func mergePatchValue(target interface{}, patch interface{}) interface{} {
+	patchObject, isPatchObject := patch.(map[string]interface{})
+	if !isPatchObject {
+		return patch
+	}
+	targetObject, isTargetObject := target.(map[string]interface{})
+	if !isTargetObject {
+		targetObject = make(map[string]interface{})
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatchValue(targetObject[key], value)
+	}
+	return targetObject
+}
@@ -0,0 +1,72 @@
+package metadataValidationUtil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// MAX_METADATA_SIZE is the max size of the metadata JSON in bytes
+const MAX_METADATA_SIZE = 4096
+
+// MAX_METADATA_DEPTH is the max nesting of the metadata objects and arrays, the top object is level 1
+const MAX_METADATA_DEPTH = 5
+
+// metadataPathSegmentRegex allows plain names only, so a path never carries quotes, wildcards or array indexes
+var metadataPathSegmentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
+// IsValidMetadataPath checks a dot-separated path of object keys like "crm.id"
+func IsValidMetadataPath(path string) bool {
+	segments := strings.Split(path, ".")
+	if len(segments) > MAX_METADATA_DEPTH {
+		return false
+	}
+	for _, segment := range segments {
+		if !metadataPathSegmentRegex.MatchString(segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidMetadata checks the metadata is a JSON object or null within the size and depth limits
+func IsValidMetadata(metadata []byte) bool {
+	if len(metadata) > MAX_METADATA_SIZE {
+		return false
+	}
+	trimmed := bytes.TrimSpace(metadata)
+	if bytes.Equal(trimmed, []byte("null")) {
+		return true
+	}
+	if !bytes.HasPrefix(trimmed, []byte("{")) || !json.Valid(trimmed) {
+		return false
+	}
+	depth, err := GetMetadataDepth(trimmed)
+	return err == nil && depth <= MAX_METADATA_DEPTH
+}
+
+// GetMetadataDepth returns the max nesting of the objects and arrays of valid JSON
+func GetMetadataDepth(metadata []byte) (int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(metadata))
+	depth := 0
+	maxDepth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return maxDepth, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			maxDepth = max(maxDepth, depth)
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
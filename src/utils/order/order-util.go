@@ -1,6 +1,8 @@
 package orderUtil
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	errorHelpers "go-gin-test-job/src/common/error-helpers"
@@ -12,8 +14,17 @@ var AvailableSortOrderList = map[string]bool{
 	"DESC": true,
 }
 
-func GetOrderByParamsSecure(c *gin.Context, data, separator string, availableSortFieldList []string) (map[string]string, error) {
-	orderByResult := make(map[string]string)
+// OrderParam is a single sort field, the position in the list defines its precedence
+type OrderParam struct {
+	Field     string
+	Direction string
+}
+
+// GetOrderByParamsSecure parses the order-by string in the given order and appends tiebreakerField
+// as the last ASC param when it is not requested, so the result order is always deterministic
+func GetOrderByParamsSecure(c *gin.Context, data, separator string, availableSortFieldList []string, tiebreakerField string) ([]OrderParam, error) {
+	orderByResult := make([]OrderParam, 0)
+	orderByFields := make(map[string]bool)
 	availableSortFields := make(map[string]bool)
 	// Convert availableSortFieldList to a map for faster lookup
 	for _, field := range availableSortFieldList {
@@ -48,10 +59,62 @@ func GetOrderByParamsSecure(c *gin.Context, data, separator string, availableSor
 			return nil, errorHelpers.RespondBadRequestError(c, fmt.Sprintf("invalid order direction: %s", direction))
 		}
 		// Avoid duplicate order fields
-		if _, exists := orderByResult[order]; !exists {
-			orderByResult[order] = direction
+		if !orderByFields[order] {
+			orderByFields[order] = true
+			orderByResult = append(orderByResult, OrderParam{Field: order, Direction: direction})
 		}
 	}
+	// Unique tiebreaker keeps pages stable when the other fields have equal values
+	if tiebreakerField != "" && !orderByFields[tiebreakerField] {
+		orderByResult = append(orderByResult, OrderParam{Field: tiebreakerField, Direction: "ASC"})
+	}
 
 	return orderByResult, nil
 }
+
+// OrderParamsToString returns the normalized order-by string, e.g. "rank DESC,id ASC"
+func OrderParamsToString(orderParams []OrderParam) string {
+	orderByList := make([]string, 0, len(orderParams))
+	for _, orderParam := range orderParams {
+		orderByList = append(orderByList, orderParam.Field+" "+orderParam.Direction)
+	}
+	return strings.Join(orderByList, ",")
+}
+
+type cursorPayload struct {
+	OrderBy string        `json:"o"`
+	Values  []interface{} `json:"v"`
+}
+
+// EncodeCursor builds an opaque cursor from the sort values of the last returned row
+func EncodeCursor(orderParams []OrderParam, values []interface{}) string {
+	payload, _ := json.Marshal(cursorPayload{OrderBy: OrderParamsToString(orderParams), Values: values})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// GetCursorValuesSecure decodes the cursor and checks it was issued for the same order params
+func GetCursorValuesSecure(c *gin.Context, cursor string, orderParams []OrderParam) ([]interface{}, error) {
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errorHelpers.RespondBadRequestError(c, "Cursor is invalid")
+	}
+	var payload cursorPayload
+	decoder := json.NewDecoder(strings.NewReader(string(payloadBytes)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, errorHelpers.RespondBadRequestError(c, "Cursor is invalid")
+	}
+	if payload.OrderBy != OrderParamsToString(orderParams) || len(payload.Values) != len(orderParams) {
+		return nil, errorHelpers.RespondBadRequestError(c, "Cursor does not match orderBy")
+	}
+	for index, value := range payload.Values {
+		switch typedValue := value.(type) {
+		case string:
+		case json.Number:
+			payload.Values[index] = typedValue.String()
+		default:
+			return nil, errorHelpers.RespondBadRequestError(c, "Cursor is invalid")
+		}
+	}
+	return payload.Values, nil
+}
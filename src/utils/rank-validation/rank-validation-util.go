@@ -1,5 +1,8 @@
 package rankValidationUtil
 
+const MIN_RANK = 1
+const MAX_RANK = 100
+
 func IsValidRank(rank uint64) bool {
-	return rank > 0 && rank <= 100
+	return rank >= MIN_RANK && rank <= MAX_RANK
 }
@@ -7,3 +7,10 @@ import (
 func CaseInsensitiveContains(str string, substr string) bool {
 	return strings.Contains(strings.ToLower(str), strings.ToLower(substr))
 }
+
+var likeReplacer = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// EscapeLike escapes LIKE wildcards, so the value is matched literally with the default escape character
+func EscapeLike(str string) string {
+	return likeReplacer.Replace(str)
+}
@@ -0,0 +1,14 @@
+package tagValidationUtil
+
+import (
+	"regexp"
+)
+
+const TagNameMaxLength = 64
+
+var tagNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// IsValidTagName allows lowercase slugs like "customer-deposit"
+func IsValidTagName(name string) bool {
+	return len(name) <= TagNameMaxLength && tagNameRegex.MatchString(name)
+}
@@ -0,0 +1,193 @@
+package testElectrum
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go-gin-test-job/src/modules/common/electrum"
+	"net"
+	"sync"
+)
+
+// FakeServer is a local Electrum server for the tests. It answers the version, ping, balance and subscribe calls
+// and pushes the subscribe notifications when a balance is set
+type FakeServer struct {
+	listener    net.Listener
+	mutex       sync.Mutex
+	connections map[*fakeConnection]bool
+	balances    map[string]electrum.ScripthashBalance
+	statuses    map[string]string
+	errors      map[string]string
+	subscribes  map[string]int
+	version     int
+}
+
+// fakeConnection is a client connection with the scripthashes it subscribed to
+type fakeConnection struct {
+	conn          net.Conn
+	writeMutex    sync.Mutex
+	subscriptions map[string]bool
+}
+
+type fakeRequest struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params []interface{}   `json:"params"`
+}
+
+type fakeResponse struct {
+	Id     json.RawMessage    `json:"id"`
+	Result interface{}        `json:"result"`
+	Error  *electrum.RpcError `json:"error,omitempty"`
+}
+
+type fakeNotification struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// NewFakeServer starts the server on a random local port
+func NewFakeServer() (*FakeServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server := &FakeServer{
+		listener:    listener,
+		connections: make(map[*fakeConnection]bool),
+		balances:    make(map[string]electrum.ScripthashBalance),
+		statuses:    make(map[string]string),
+		errors:      make(map[string]string),
+		subscribes:  make(map[string]int),
+	}
+	go server.acceptLoop()
+	return server, nil
+}
+
+func (s *FakeServer) URL() string {
+	return "tcp://" + s.listener.Addr().String()
+}
+
+// SetBalance changes the scripthash status and notifies the connections subscribed to it
+func (s *FakeServer) SetBalance(scripthash string, confirmed int64, unconfirmed int64) {
+	s.mutex.Lock()
+	s.version++
+	s.balances[scripthash] = electrum.ScripthashBalance{Confirmed: confirmed, Unconfirmed: unconfirmed}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%d:%d", scripthash, confirmed, unconfirmed, s.version)))
+	status := hex.EncodeToString(hash[:])
+	s.statuses[scripthash] = status
+	subscribers := make([]*fakeConnection, 0)
+	for connection := range s.connections {
+		if connection.subscriptions[scripthash] {
+			subscribers = append(subscribers, connection)
+		}
+	}
+	s.mutex.Unlock()
+	for _, connection := range subscribers {
+		connection.write(fakeNotification{Method: "blockchain.scripthash.subscribe", Params: []interface{}{scripthash, status}})
+	}
+}
+
+// SetError makes the balance and subscribe calls of the scripthash fail with the message
+func (s *FakeServer) SetError(scripthash string, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errors[scripthash] = message
+}
+
+// SubscribeCount returns how many times the scripthash was subscribed, every reconnect subscribes again
+func (s *FakeServer) SubscribeCount(scripthash string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.subscribes[scripthash]
+}
+
+// DropConnections closes the client connections, the server keeps accepting the new ones
+func (s *FakeServer) DropConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for connection := range s.connections {
+		_ = connection.conn.Close()
+		delete(s.connections, connection)
+	}
+}
+
+func (s *FakeServer) Close() {
+	_ = s.listener.Close()
+	s.DropConnections()
+}
+
+func (s *FakeServer) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		connection := &fakeConnection{conn: conn, subscriptions: make(map[string]bool)}
+		s.mutex.Lock()
+		s.connections[connection] = true
+		s.mutex.Unlock()
+		go s.readLoop(connection)
+	}
+}
+
+func (s *FakeServer) readLoop(connection *fakeConnection) {
+	defer func() {
+		s.mutex.Lock()
+		delete(s.connections, connection)
+		s.mutex.Unlock()
+		_ = connection.conn.Close()
+	}()
+	scanner := bufio.NewScanner(connection.conn)
+	for scanner.Scan() {
+		var request fakeRequest
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			return
+		}
+		result, rpcErr := s.handle(connection, request)
+		connection.write(fakeResponse{Id: request.Id, Result: result, Error: rpcErr})
+	}
+}
+
+func (s *FakeServer) handle(connection *fakeConnection, request fakeRequest) (interface{}, *electrum.RpcError) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch request.Method {
+	case "server.version":
+		return []string{"FakeElectrum 1.0", electrum.ProtocolVersion}, nil
+	case "server.ping":
+		return nil, nil
+	case "blockchain.scripthash.get_balance", "blockchain.scripthash.subscribe":
+		if len(request.Params) != 1 {
+			return nil, &electrum.RpcError{Code: 1, Message: "scripthash param is required"}
+		}
+		scripthash, _ := request.Params[0].(string)
+		if message, exists := s.errors[scripthash]; exists {
+			return nil, &electrum.RpcError{Code: 1, Message: message}
+		}
+		if request.Method == "blockchain.scripthash.get_balance" {
+			return s.balances[scripthash], nil
+		}
+		connection.subscriptions[scripthash] = true
+		s.subscribes[scripthash]++
+		// The status of a scripthash without history is null
+		if status, exists := s.statuses[scripthash]; exists {
+			return status, nil
+		}
+		return nil, nil
+	default:
+		return nil, &electrum.RpcError{Code: -32601, Message: fmt.Sprintf("unknown method %s", request.Method)}
+	}
+}
+
+func (c *fakeConnection) write(message interface{}) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	_, _ = c.conn.Write(append(data, '\n'))
+}
@@ -8,7 +8,10 @@ import (
 	logger "go-gin-test-job/src/logger"
 	middleware "go-gin-test-job/src/middlewares"
 	accountModule "go-gin-test-job/src/modules/account"
+	auditModule "go-gin-test-job/src/modules/audit"
 	cronModule "go-gin-test-job/src/modules/cron"
+	portfolioModule "go-gin-test-job/src/modules/portfolio"
+	tagModule "go-gin-test-job/src/modules/tag"
 )
 
 func New() *gin.Engine {
@@ -29,7 +32,44 @@ func New() *gin.Engine {
 	// Account routes
 	accountMethods := app.Group("/account")
 	accountMethods.GET("", middleware.AdminApiKeyGuard(), accountModule.GetAccounts)
-	accountMethods.POST("", middleware.AdminApiKeyGuard(), accountModule.CreateAccount)
+	accountMethods.POST("", middleware.AdminApiKeyGuard(), middleware.IdempotencyKeyMiddleware(), accountModule.CreateAccount)
+	accountMethods.POST("/bulk", middleware.AdminApiKeyGuard(), middleware.IdempotencyKeyMiddleware(), accountModule.CreateAccountsBulk)
+	accountMethods.POST("/import", middleware.AdminApiKeyGuard(), accountModule.ImportAccounts)
+	accountMethods.GET("/export", middleware.AdminApiKeyGuard(), accountModule.ExportAccounts)
+	accountMethods.GET("/stats", middleware.AdminApiKeyGuard(), accountModule.GetAccountStats)
+	accountMethods.GET("/:id", middleware.AdminApiKeyGuard(), accountModule.GetAccountById)
+	accountMethods.GET("/:id/balance-history", middleware.AdminApiKeyGuard(), accountModule.GetAccountBalanceHistory)
+	accountMethods.GET("/:id/transactions", middleware.AdminApiKeyGuard(), accountModule.GetAccountTransactions)
+	accountMethods.PATCH("/:id", middleware.AdminApiKeyGuard(), accountModule.UpdateAccount)
+	accountMethods.DELETE("/:id", middleware.AdminApiKeyGuard(), accountModule.DeleteAccount)
+	accountMethods.POST("/:id/restore", middleware.AdminApiKeyGuard(), middleware.IdempotencyKeyMiddleware(), accountModule.RestoreAccount)
+	accountMethods.POST("/:id/transition", middleware.AdminApiKeyGuard(), middleware.IdempotencyKeyMiddleware(), accountModule.TransitionAccount)
+	accountMethods.DELETE("/:id/purge", middleware.AdminApiKeyGuard(), accountModule.PurgeAccount)
+	accountMethods.GET("/by-address/:address", middleware.AdminApiKeyGuard(), accountModule.GetAccountByAddress)
+	accountMethods.PUT("/:id/tag/:tagId", middleware.AdminApiKeyGuard(), accountModule.AttachAccountTag)
+	accountMethods.DELETE("/:id/tag/:tagId", middleware.AdminApiKeyGuard(), accountModule.DetachAccountTag)
+
+	// Tag routes
+	tagMethods := app.Group("/tag")
+	tagMethods.GET("", middleware.AdminApiKeyGuard(), tagModule.GetTags)
+	tagMethods.POST("", middleware.AdminApiKeyGuard(), middleware.IdempotencyKeyMiddleware(), tagModule.CreateTag)
+	tagMethods.GET("/:id", middleware.AdminApiKeyGuard(), tagModule.GetTagById)
+	tagMethods.PATCH("/:id", middleware.AdminApiKeyGuard(), tagModule.UpdateTag)
+	tagMethods.DELETE("/:id", middleware.AdminApiKeyGuard(), tagModule.DeleteTag)
+
+	// Portfolio routes
+	portfolioMethods := app.Group("/portfolio")
+	portfolioMethods.GET("", middleware.AdminApiKeyGuard(), portfolioModule.GetPortfolios)
+	portfolioMethods.POST("", middleware.AdminApiKeyGuard(), middleware.IdempotencyKeyMiddleware(), portfolioModule.CreatePortfolio)
+	portfolioMethods.GET("/:id", middleware.AdminApiKeyGuard(), portfolioModule.GetPortfolioById)
+	portfolioMethods.PATCH("/:id", middleware.AdminApiKeyGuard(), portfolioModule.UpdatePortfolio)
+	portfolioMethods.DELETE("/:id", middleware.AdminApiKeyGuard(), portfolioModule.DeletePortfolio)
+	portfolioMethods.PUT("/:id/account/:accountId", middleware.AdminApiKeyGuard(), portfolioModule.AddPortfolioAccount)
+	portfolioMethods.DELETE("/:id/account/:accountId", middleware.AdminApiKeyGuard(), portfolioModule.RemovePortfolioAccount)
+
+	// Audit routes
+	auditMethods := app.Group("/audit")
+	auditMethods.GET("", middleware.AdminApiKeyGuard(), auditModule.GetAuditLogs)
 
 	// Cron routes
 	cronMethods := app.Group("/cron")
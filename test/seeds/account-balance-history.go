@@ -0,0 +1,50 @@
+package seeds
+
+import (
+	"go-gin-test-job/src/database/entities"
+	timeUtil "go-gin-test-job/src/utils/time"
+
+	"github.com/shopspring/decimal"
+)
+
+var ACCOUNT_BALANCE_HISTORY struct {
+	// HISTORY_1 is older than the default retention window
+	HISTORY_1 entities.AccountBalanceHistory
+	HISTORY_2 entities.AccountBalanceHistory
+	HISTORY_3 entities.AccountBalanceHistory
+	HISTORY_4 entities.AccountBalanceHistory
+	HISTORY_5 entities.AccountBalanceHistory
+}
+
+// AccountBalanceHistoryDayStart is the UTC start of the day two days ago, HISTORY_2 to HISTORY_4 are in that day
+var AccountBalanceHistoryDayStart int64
+
+func createSeedAccountBalanceHistory(id int64, oldBalance string, newBalance string, createdAt int64) entities.AccountBalanceHistory {
+	history := entities.CreateAccountBalanceHistory(
+		ACCOUNTS.ACCOUNT_1.Id,
+		decimal.RequireFromString(oldBalance),
+		decimal.RequireFromString(newBalance),
+		"bitcore",
+		createdAt,
+	)
+	history.Id = id
+	return *history
+}
+
+// FillAccountBalanceHistoryList records the changes of ACCOUNT_1 up to its seeded balance
+func FillAccountBalanceHistoryList() []entities.AccountBalanceHistory {
+	now := timeUtil.GetUnixTime()
+	AccountBalanceHistoryDayStart = now - now%86400 - 2*86400
+	ACCOUNT_BALANCE_HISTORY.HISTORY_1 = createSeedAccountBalanceHistory(1, "0", "0.5", now-400*86400)
+	ACCOUNT_BALANCE_HISTORY.HISTORY_2 = createSeedAccountBalanceHistory(2, "0.5", "0.95", AccountBalanceHistoryDayStart+3600+60)
+	ACCOUNT_BALANCE_HISTORY.HISTORY_3 = createSeedAccountBalanceHistory(3, "0.95", "0.96", AccountBalanceHistoryDayStart+3600+1800)
+	ACCOUNT_BALANCE_HISTORY.HISTORY_4 = createSeedAccountBalanceHistory(4, "0.96", "0.97", AccountBalanceHistoryDayStart+5*3600)
+	ACCOUNT_BALANCE_HISTORY.HISTORY_5 = createSeedAccountBalanceHistory(5, "0.97", "0.96224397", AccountBalanceHistoryDayStart+86400+2*3600)
+	return []entities.AccountBalanceHistory{
+		ACCOUNT_BALANCE_HISTORY.HISTORY_1,
+		ACCOUNT_BALANCE_HISTORY.HISTORY_2,
+		ACCOUNT_BALANCE_HISTORY.HISTORY_3,
+		ACCOUNT_BALANCE_HISTORY.HISTORY_4,
+		ACCOUNT_BALANCE_HISTORY.HISTORY_5,
+	}
+}
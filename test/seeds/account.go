@@ -15,48 +15,60 @@ var ACCOUNTS struct {
 
 func FillAccountList() []entities.Account {
 	ACCOUNTS.ACCOUNT_1 = entities.Account{
-		Id:        1,
-		Address:   "3JTCWLKubxuuXXnmQPxx43nP2LJAcPSL1W",
-		Name:      "Alice Smith",
-		Rank:      75,
-		Memo:      "VIP customer",
-		Balance:   decimal.RequireFromString("0.96224397"),
-		Status:    entities.AccountStatusOn,
-		CreatedAt: timeUtil.GetUnixTime(),
-		UpdatedAt: timeUtil.GetUnixTime(),
+		Id:          1,
+		Chain:       entities.AccountChainBtc,
+		Network:     entities.AccountNetworkMainnet,
+		Address:     "3JTCWLKubxuuXXnmQPxx43nP2LJAcPSL1W",
+		AddressType: entities.AccountAddressTypeP2sh,
+		Name:        "Alice Smith",
+		Rank:        75,
+		Memo:        "VIP customer",
+		Balance:     decimal.RequireFromString("0.96224397"),
+		Status:      entities.AccountStatusActive,
+		CreatedAt:   timeUtil.GetUnixTime(),
+		UpdatedAt:   timeUtil.GetUnixTime(),
 	}
 	ACCOUNTS.ACCOUNT_2 = entities.Account{
-		Id:        2,
-		Address:   "38JeTiYSS2Y4kSxNBNH6kmH5kjm8sodDvU",
-		Name:      "Bob Johnson",
-		Rank:      50,
-		Memo:      "Regular customer",
-		Balance:   decimal.RequireFromString("0.00056665"),
-		Status:    entities.AccountStatusOn,
-		CreatedAt: timeUtil.GetUnixTime(),
-		UpdatedAt: timeUtil.GetUnixTime(),
+		Id:          2,
+		Chain:       entities.AccountChainBtc,
+		Network:     entities.AccountNetworkMainnet,
+		Address:     "38JeTiYSS2Y4kSxNBNH6kmH5kjm8sodDvU",
+		AddressType: entities.AccountAddressTypeP2sh,
+		Name:        "Bob Johnson",
+		Rank:        50,
+		Memo:        "Regular customer",
+		Balance:     decimal.RequireFromString("0.00056665"),
+		Status:      entities.AccountStatusActive,
+		CreatedAt:   timeUtil.GetUnixTime(),
+		UpdatedAt:   timeUtil.GetUnixTime(),
 	}
 	ACCOUNTS.ACCOUNT_3 = entities.Account{
-		Id:        3,
-		Address:   "34bMmbjiiK5WfV2ZtgZGxLVYycJGNPEqjE",
-		Name:      "Charlie Brown",
-		Rank:      25,
-		Memo:      "",
-		Balance:   decimal.NewFromInt(0),
-		Status:    entities.AccountStatusOff,
-		CreatedAt: timeUtil.GetUnixTime(),
-		UpdatedAt: timeUtil.GetUnixTime(),
+		Id:          3,
+		Chain:       entities.AccountChainBtc,
+		Network:     entities.AccountNetworkMainnet,
+		Address:     "34bMmbjiiK5WfV2ZtgZGxLVYycJGNPEqjE",
+		AddressType: entities.AccountAddressTypeP2sh,
+		Name:        "Charlie Brown",
+		Rank:        25,
+		Memo:        "",
+		Balance:     decimal.NewFromInt(0),
+		Status:      entities.AccountStatusSuspended,
+		CreatedAt:   timeUtil.GetUnixTime(),
+		UpdatedAt:   timeUtil.GetUnixTime(),
 	}
 	ACCOUNTS.ACCOUNT_4 = entities.Account{
-		Id:        4,
-		Address:   "1CmSPVJifmK3HXqy2tYgbTSb4eExK4wqYT",
-		Name:      "David Wilson",
-		Rank:      90,
-		Memo:      "Premium customer with special requirements",
-		Balance:   decimal.RequireFromString("0.07134313"),
-		Status:    entities.AccountStatusOff,
-		CreatedAt: timeUtil.GetUnixTime(),
-		UpdatedAt: timeUtil.GetUnixTime(),
+		Id:          4,
+		Chain:       entities.AccountChainBtc,
+		Network:     entities.AccountNetworkMainnet,
+		Address:     "1CmSPVJifmK3HXqy2tYgbTSb4eExK4wqYT",
+		AddressType: entities.AccountAddressTypeP2pkh,
+		Name:        "David Wilson",
+		Rank:        90,
+		Memo:        "Premium customer with special requirements",
+		Balance:     decimal.RequireFromString("0.07134313"),
+		Status:      entities.AccountStatusSuspended,
+		CreatedAt:   timeUtil.GetUnixTime(),
+		UpdatedAt:   timeUtil.GetUnixTime(),
 	}
 	return []entities.Account{
 		ACCOUNTS.ACCOUNT_1,
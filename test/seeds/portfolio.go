@@ -0,0 +1,39 @@
+package seeds
+
+import (
+	"go-gin-test-job/src/database/entities"
+	timeUtil "go-gin-test-job/src/utils/time"
+)
+
+var PORTFOLIOS struct {
+	PORTFOLIO_1 entities.Portfolio
+	PORTFOLIO_2 entities.Portfolio
+}
+
+func FillPortfolioList() []entities.Portfolio {
+	PORTFOLIOS.PORTFOLIO_1 = entities.Portfolio{
+		Id:        1,
+		Name:      "Treasury",
+		CreatedAt: timeUtil.GetUnixTime(),
+		UpdatedAt: timeUtil.GetUnixTime(),
+	}
+	PORTFOLIOS.PORTFOLIO_2 = entities.Portfolio{
+		Id:        2,
+		Name:      "Customers",
+		CreatedAt: timeUtil.GetUnixTime(),
+		UpdatedAt: timeUtil.GetUnixTime(),
+	}
+	return []entities.Portfolio{
+		PORTFOLIOS.PORTFOLIO_1,
+		PORTFOLIOS.PORTFOLIO_2,
+	}
+}
+
+// FillPortfolioAccountList puts the On ACCOUNT_1 and the Off ACCOUNT_3 and ACCOUNT_4 into Treasury, Customers stays empty
+func FillPortfolioAccountList() []entities.PortfolioAccount {
+	return []entities.PortfolioAccount{
+		*entities.CreatePortfolioAccount(PORTFOLIOS.PORTFOLIO_1.Id, ACCOUNTS.ACCOUNT_1.Id),
+		*entities.CreatePortfolioAccount(PORTFOLIOS.PORTFOLIO_1.Id, ACCOUNTS.ACCOUNT_3.Id),
+		*entities.CreatePortfolioAccount(PORTFOLIOS.PORTFOLIO_1.Id, ACCOUNTS.ACCOUNT_4.Id),
+	}
+}
@@ -0,0 +1,56 @@
+package seeds
+
+import (
+	"go-gin-test-job/src/database/entities"
+	timeUtil "go-gin-test-job/src/utils/time"
+)
+
+var TAGS struct {
+	TAG_1 entities.Tag
+	TAG_2 entities.Tag
+	TAG_3 entities.Tag
+}
+
+func FillTagList() []entities.Tag {
+	TAGS.TAG_1 = entities.Tag{
+		Id:        1,
+		Name:      "exchange",
+		CreatedAt: timeUtil.GetUnixTime(),
+		UpdatedAt: timeUtil.GetUnixTime(),
+	}
+	TAGS.TAG_2 = entities.Tag{
+		Id:        2,
+		Name:      "cold",
+		CreatedAt: timeUtil.GetUnixTime(),
+		UpdatedAt: timeUtil.GetUnixTime(),
+	}
+	TAGS.TAG_3 = entities.Tag{
+		Id:        3,
+		Name:      "customer-deposit",
+		CreatedAt: timeUtil.GetUnixTime(),
+		UpdatedAt: timeUtil.GetUnixTime(),
+	}
+	return []entities.Tag{
+		TAGS.TAG_1,
+		TAGS.TAG_2,
+		TAGS.TAG_3,
+	}
+}
+
+// FillAccountTagList links ACCOUNT_1 to exchange and cold, ACCOUNT_2 to exchange and ACCOUNT_4 to customer-deposit
+func FillAccountTagList() []entities.AccountTag {
+	return []entities.AccountTag{
+		*entities.CreateAccountTag(ACCOUNTS.ACCOUNT_1.Id, TAGS.TAG_1.Id),
+		*entities.CreateAccountTag(ACCOUNTS.ACCOUNT_1.Id, TAGS.TAG_2.Id),
+		*entities.CreateAccountTag(ACCOUNTS.ACCOUNT_2.Id, TAGS.TAG_1.Id),
+		*entities.CreateAccountTag(ACCOUNTS.ACCOUNT_4.Id, TAGS.TAG_3.Id),
+	}
+}
+
+func GetTagList() []entities.Tag {
+	return []entities.Tag{
+		TAGS.TAG_1,
+		TAGS.TAG_2,
+		TAGS.TAG_3,
+	}
+}
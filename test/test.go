@@ -1,6 +1,8 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
 	"go-gin-test-job/src/config"
 	appDatabase "go-gin-test-job/src/database"
 	"go-gin-test-job/src/database/entities"
@@ -9,6 +11,10 @@ import (
 	testDatabase "go-gin-test-job/test/database"
 	testRoutes "go-gin-test-job/test/routes"
 	"go-gin-test-job/test/seeds"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -72,6 +78,75 @@ func createTestData() {
 	for _, account := range seeds.FillAccountList() {
 		testDatabase.DbConn.Create(&account)
 	}
+	// Add tags
+	for _, tag := range seeds.FillTagList() {
+		testDatabase.DbConn.Create(&tag)
+	}
+	for _, accountTag := range seeds.FillAccountTagList() {
+		testDatabase.DbConn.Create(&accountTag)
+	}
+	// Add balance history
+	for _, history := range seeds.FillAccountBalanceHistoryList() {
+		testDatabase.DbConn.Create(&history)
+	}
+	// Add portfolios
+	for _, portfolio := range seeds.FillPortfolioList() {
+		testDatabase.DbConn.Create(&portfolio)
+	}
+	for _, portfolioAccount := range seeds.FillPortfolioAccountList() {
+		testDatabase.DbConn.Create(&portfolioAccount)
+	}
+}
+
+// NewAdminRequest creates the request authorized with the admin API key, the query is added to the path.
+// A string or reader body is sent as it is, any other non-nil body is encoded as JSON
+func NewAdminRequest(t *testing.T, method string, path string, query url.Values, body interface{}) *http.Request {
+	target := path
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		target += separator + query.Encode()
+	}
+	var requestBody io.Reader
+	switch value := body.(type) {
+	case nil:
+	case string:
+		requestBody = bytes.NewBufferString(value)
+	case io.Reader:
+		requestBody = value
+	default:
+		data, err := json.Marshal(value)
+		assert.Nil(t, err)
+		requestBody = bytes.NewBuffer(data)
+	}
+	request := httptest.NewRequest(method, target, requestBody)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+	return request
+}
+
+// ServeRequest runs the request through the test app
+func ServeRequest(request *http.Request) *httptest.ResponseRecorder {
+	response := httptest.NewRecorder()
+	TestApp.ServeHTTP(response, request)
+	return response
+}
+
+// SendAdminRequest serves the admin request, use NewAdminRequest and ServeRequest when it needs more headers
+func SendAdminRequest(t *testing.T, method string, path string, query url.Values, body interface{}) *httptest.ResponseRecorder {
+	return ServeRequest(NewAdminRequest(t, method, path, query, body))
+}
+
+// DecodeResponse decodes the JSON response body
+func DecodeResponse[T any](t *testing.T, response *httptest.ResponseRecorder) T {
+	var responseDto T
+	err := json.NewDecoder(response.Body).Decode(&responseDto)
+	assert.Nil(t, err)
+	return responseDto
 }
 
 func TestListSort[T any](list []T, orderBy string) bool {
@@ -203,10 +278,14 @@ func getFieldValue(obj interface{}, fieldName string) (interface{}, bool) {
 
 func CompareAccount(t *testing.T, account *entities.Account, accountDto accountModuleDto.AccountDto) {
 	assert.Equal(t, account.Id, accountDto.Id)
+	assert.Equal(t, string(account.Chain), accountDto.Chain)
+	assert.Equal(t, string(account.Network), accountDto.Network)
 	assert.Equal(t, account.Address, accountDto.Address)
+	assert.Equal(t, string(account.AddressType), accountDto.AddressType)
 	assert.Equal(t, account.Name, accountDto.Name)
 	assert.Equal(t, account.Rank, accountDto.Rank)
 	assert.Equal(t, account.Memo, accountDto.Memo)
+	assert.Equal(t, account.Chain.GetCurrency(), accountDto.Currency)
 	assert.Equal(t, string(account.Status), accountDto.Status)
 	assert.Equal(t, account.CreatedAt, accountDto.CreatedAt)
 	assert.Equal(t, account.UpdatedAt, accountDto.UpdatedAt)
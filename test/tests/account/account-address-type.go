@@ -0,0 +1,104 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	p2wpkhAddress = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+	p2trAddress   = "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"
+)
+
+func TestAccountAddressTypeRoute_Fail(t *testing.T) {
+	validationTests := []struct {
+		name    string
+		address string
+	}{
+		{"FailBase58Checksum", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3"},
+		{"FailBase58Version", "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"},
+		{"FailBech32Checksum", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5"},
+		// Witness version 0 must use bech32, the address has a valid bech32m checksum
+		{"FailBech32mForVersion0", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh"},
+		// Witness version 1 must use bech32m, the address has a valid bech32 checksum
+		{"FailBech32ForVersion1", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd"},
+		{"FailMixedCase", "bc1qW508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"FailMixedCaseHrp", "bc1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"},
+		{"FailTestnetHrp", "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"},
+		{"FailProgramLength", "bc1qr508d6qejxtdg4y5r3zarvaryv98gj9p"},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestAccountAddressTypeRoute_"+tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"address": "%s", "name": "Address", "rank": 10, "status": "Suspended"}`, tt.address)
+			response := test.SendAdminRequest(t, "POST", "/account", nil, body)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, "Address format is wrong", responseDto.Message)
+			assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, tt.address))
+
+			// The lookup validates the address as it was sent too
+			response = test.SendAdminRequest(t, "GET", "/account/by-address/"+tt.address, nil, nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	}
+}
+
+func TestAccountAddressTypeRoute_Success(t *testing.T) {
+	// The uppercase bech32 address is stored in the lowercase form
+	response := test.SendAdminRequest(t, "POST", "/account", nil,
+		fmt.Sprintf(`{"address": "%s", "name": "SegWit", "rank": 10, "status": "Suspended"}`, "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"))
+	assert.Equal(t, http.StatusOK, response.Code)
+	p2wpkhAccountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, p2wpkhAddress, p2wpkhAccountDto.Address)
+	assert.Equal(t, "p2wpkh", p2wpkhAccountDto.AddressType)
+
+	response = test.SendAdminRequest(t, "POST", "/account", nil,
+		fmt.Sprintf(`{"address": "%s", "name": "Taproot", "rank": 10, "status": "Suspended"}`, p2trAddress))
+	assert.Equal(t, http.StatusOK, response.Code)
+	p2trAccountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, "p2tr", p2trAccountDto.AddressType)
+	account := database.GetAccountById(p2trAccountDto.Id)
+	test.CompareAccount(t, account, p2trAccountDto)
+
+	// The same address in the other case is a duplicate
+	response = test.SendAdminRequest(t, "POST", "/account", nil,
+		fmt.Sprintf(`{"address": "%s", "name": "Taproot", "rank": 10, "status": "Suspended"}`, "BC1P0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQZK5JJ0"))
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	// The by-address lookup accepts the uppercase form
+	response = test.SendAdminRequest(t, "GET", "/account/by-address/BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, p2wpkhAccountDto.Id, accountDto.Id)
+
+	filterTests := []struct {
+		addressType string
+		expectedIds []int64
+	}{
+		{"p2tr", []int64{p2trAccountDto.Id}},
+		{"p2wpkh", []int64{p2wpkhAccountDto.Id}},
+		{"p2wsh", []int64{}},
+		{"bech32", []int64{p2wpkhAccountDto.Id, p2trAccountDto.Id}},
+	}
+	for _, tt := range filterTests {
+		response = sendGetAccountsRequest(t, url.Values{"addressType": {tt.addressType}, "orderBy": {"id ASC"}})
+		assert.Equal(t, http.StatusOK, response.Code)
+		responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+		ids := make([]int64, 0)
+		for _, accountDto := range responseDto.List {
+			ids = append(ids, accountDto.Id)
+		}
+		assert.Equal(t, tt.expectedIds, ids, tt.addressType)
+	}
+}
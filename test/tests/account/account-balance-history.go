@@ -0,0 +1,182 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendGetAccountBalanceHistoryRequest(t *testing.T, id int64, query url.Values) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "GET", fmt.Sprintf("/account/%d/balance-history", id), query, nil)
+}
+
+func getAccountBalanceHistory(t *testing.T, query url.Values) accountModuleDto.GetAccountBalanceHistoryResponseDto {
+	response := sendGetAccountBalanceHistoryRequest(t, seeds.ACCOUNTS.ACCOUNT_1.Id, query)
+	assert.Equal(t, http.StatusOK, response.Code)
+	return test.DecodeResponse[accountModuleDto.GetAccountBalanceHistoryResponseDto](t, response)
+}
+
+func getAccountBalanceHistoryIds(responseDto accountModuleDto.GetAccountBalanceHistoryResponseDto) []int64 {
+	ids := make([]int64, 0)
+	for _, item := range responseDto.List {
+		ids = append(ids, item.Id)
+	}
+	return ids
+}
+
+func validationGetAccountBalanceHistoryTests(t *testing.T) {
+	rawCursor := orderUtil.EncodeCursor(
+		[]orderUtil.OrderParam{{Field: "created_at", Direction: "DESC"}, {Field: "id", Direction: "DESC"}},
+		[]interface{}{1, 1},
+	)
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailBucket",
+			url.Values{"bucket": {"month"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Bucket must be one of the next values: hour,day,week"},
+		},
+		{
+			"FailFromAfterTo",
+			url.Values{"from": {"200"}, "to": {"100"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "From must be less than or equal To"},
+		},
+		{
+			"FailFromNotNumber",
+			url.Values{"from": {"yesterday"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "from is invalid"},
+		},
+		{
+			"FailCountMax",
+			url.Values{"count": {"1001"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Count must be less than or equal 1000"},
+		},
+		{
+			"FailCursorOtherBucket",
+			url.Values{"cursor": {rawCursor}, "bucket": {"day"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Cursor does not match orderBy"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountBalanceHistoryRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountBalanceHistoryRequest(t, seeds.ACCOUNTS.ACCOUNT_1.Id, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto)
+		})
+	}
+}
+
+func TestGetAccountBalanceHistoryRoute_FailNotFound(t *testing.T) {
+	response := sendGetAccountBalanceHistoryRequest(t, 999999, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Account not found", responseDto.Message)
+}
+
+func TestGetAccountBalanceHistoryRoute_SuccessCursorPagination(t *testing.T) {
+	history := seeds.ACCOUNT_BALANCE_HISTORY
+	expectedPages := [][]int64{
+		{history.HISTORY_5.Id, history.HISTORY_4.Id},
+		{history.HISTORY_3.Id, history.HISTORY_2.Id},
+		{history.HISTORY_1.Id},
+	}
+	query := url.Values{"count": {"2"}}
+	for index, expectedIds := range expectedPages {
+		responseDto := getAccountBalanceHistory(t, query)
+		assert.Equal(t, expectedIds, getAccountBalanceHistoryIds(responseDto))
+		if index == len(expectedPages)-1 {
+			assert.Nil(t, responseDto.NextCursor)
+			break
+		}
+		if !assert.NotNil(t, responseDto.NextCursor) {
+			return
+		}
+		query.Set("cursor", *responseDto.NextCursor)
+	}
+
+	// The change is stored with full precision
+	responseDto := getAccountBalanceHistory(t, url.Values{"count": {"1"}})
+	assert.Equal(t, 1, len(responseDto.List))
+	assert.Equal(t, history.HISTORY_5.OldBalance.String(), responseDto.List[0].OldBalance)
+	assert.Equal(t, seeds.ACCOUNTS.ACCOUNT_1.Balance.String(), responseDto.List[0].NewBalance)
+	assert.Equal(t, history.HISTORY_5.Delta.String(), responseDto.List[0].Delta)
+	assert.Equal(t, "bitcore", responseDto.List[0].Source)
+	assert.Nil(t, responseDto.List[0].BucketStart)
+}
+
+func TestGetAccountBalanceHistoryRoute_SuccessRange(t *testing.T) {
+	dayStart := seeds.AccountBalanceHistoryDayStart
+	responseDto := getAccountBalanceHistory(t, url.Values{
+		"from": {fmt.Sprint(dayStart)},
+		"to":   {fmt.Sprint(dayStart + 86400 - 1)},
+	})
+	history := seeds.ACCOUNT_BALANCE_HISTORY
+	assert.Equal(t, []int64{history.HISTORY_4.Id, history.HISTORY_3.Id, history.HISTORY_2.Id}, getAccountBalanceHistoryIds(responseDto))
+	assert.Nil(t, responseDto.NextCursor)
+}
+
+func TestGetAccountBalanceHistoryRoute_SuccessBuckets(t *testing.T) {
+	history := seeds.ACCOUNT_BALANCE_HISTORY
+	allHistory := []entities.AccountBalanceHistory{history.HISTORY_5, history.HISTORY_4, history.HISTORY_3, history.HISTORY_2, history.HISTORY_1}
+	for _, bucket := range []database.AccountBalanceHistoryBucket{
+		database.AccountBalanceHistoryBucketHour,
+		database.AccountBalanceHistoryBucketDay,
+		database.AccountBalanceHistoryBucketWeek,
+	} {
+		t.Run(string(bucket), func(t *testing.T) {
+			// The history is newest first, so the first row of a bucket is its last change
+			expectedIds := make([]int64, 0)
+			expectedBucketStarts := make([]int64, 0)
+			for _, item := range allHistory {
+				bucketStart := database.GetAccountBalanceHistoryBucketStart(bucket, item.CreatedAt)
+				if len(expectedBucketStarts) > 0 && expectedBucketStarts[len(expectedBucketStarts)-1] == bucketStart {
+					continue
+				}
+				expectedIds = append(expectedIds, item.Id)
+				expectedBucketStarts = append(expectedBucketStarts, bucketStart)
+			}
+
+			ids := make([]int64, 0)
+			bucketStarts := make([]int64, 0)
+			query := url.Values{"bucket": {string(bucket)}, "count": {"2"}}
+			for page := 0; page < len(allHistory); page++ {
+				responseDto := getAccountBalanceHistory(t, query)
+				for _, item := range responseDto.List {
+					ids = append(ids, item.Id)
+					if assert.NotNil(t, item.BucketStart) {
+						bucketStarts = append(bucketStarts, *item.BucketStart)
+					}
+				}
+				if responseDto.NextCursor == nil {
+					break
+				}
+				query.Set("cursor", *responseDto.NextCursor)
+			}
+			assert.Equal(t, expectedIds, ids)
+			assert.Equal(t, expectedBucketStarts, bucketStarts)
+		})
+	}
+
+	// Two changes in the same hour are reduced to the later one
+	responseDto := getAccountBalanceHistory(t, url.Values{"bucket": {"hour"}})
+	assert.NotContains(t, getAccountBalanceHistoryIds(responseDto), history.HISTORY_2.Id)
+	assert.Contains(t, getAccountBalanceHistoryIds(responseDto), history.HISTORY_3.Id)
+}
@@ -0,0 +1,152 @@
+package accountTests
+
+import (
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// The balance kind accounts are on the LTC testnet only, so the chain network filter leaves the other accounts out
+const (
+	balanceKindAddressA = "tltc1q9y4zktpd9chnqvfjxv6r2d3h8qun5weuv5zt4h"
+	balanceKindAddressB = "tltc1q85lr7szpgfp5g32xgayyjjjtf3x5un6skkyf5a"
+	balanceKindAddressC = "tltc1q29f9x4z42et4sk26tdw96hjlvpskycmyxf6ca0"
+)
+
+func getBalanceKindAccountIds(t *testing.T, query url.Values) []int64 {
+	query.Set("chain", "LTC")
+	query.Set("network", "testnet")
+	response := sendGetAccountsRequest(t, query)
+	assert.Equal(t, http.StatusOK, response.Code)
+	responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+	ids := make([]int64, 0)
+	for _, accountDto := range responseDto.List {
+		ids = append(ids, accountDto.Id)
+	}
+	return ids
+}
+
+func TestAccountBalanceKindRoute_Fail(t *testing.T) {
+	validationTests := []struct {
+		name            string
+		query           url.Values
+		expectedMessage string
+	}{
+		{"FailBalanceKind", url.Values{"balanceKind": {"pending"}}, "BalanceKind must be one of the next values: confirmed,unconfirmed,total"},
+		{"FailBalanceKindSort", url.Values{"orderBy": {"unconfirmed ASC"}}, "cannot order by unconfirmed ASC"},
+		{"FailNegativeConfirmedBalance", url.Values{"balanceKind": {"confirmed"}, "balanceMax": {"-0.1"}}, "BalanceMax must be a decimal number, non-negative for the confirmed balance"},
+	}
+	for _, tt := range validationTests {
+		t.Run("TestAccountBalanceKindRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Contains(t, responseDto.Message, tt.expectedMessage)
+		})
+	}
+}
+
+func TestAccountBalanceKindRoute_Success(t *testing.T) {
+	balanceTests := []struct {
+		address     string
+		confirmed   string
+		unconfirmed string
+		total       string
+	}{
+		// The mempool spends a part of the confirmed balance
+		{balanceKindAddressA, "1", "-0.5", "0.5"},
+		{balanceKindAddressB, "0.2", "0.7", "0.9"},
+		{balanceKindAddressC, "0.6", "0.5", "1.1"},
+	}
+	ids := make([]int64, 0)
+	for _, tt := range balanceTests {
+		body := `{"chain": "LTC", "network": "testnet", "address": "` + tt.address + `", "name": "Balance Kind", "rank": 10, "status": "Suspended"}`
+		response := test.SendAdminRequest(t, "POST", "/account", nil, body)
+		assert.Equal(t, http.StatusOK, response.Code)
+		accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+		assert.Equal(t, "0", accountDto.UnconfirmedBalance)
+		assert.Equal(t, "0", accountDto.TotalBalance)
+
+		account := database.GetAccountById(accountDto.Id)
+		updateData := account.UpdateBalance(decimal.RequireFromString(tt.confirmed), decimal.RequireFromString(tt.unconfirmed))
+		err := database.UpdateAccount(database.DbConn, database.AuditContext{Actor: "test"}, entities.AuditActionUpdate, account, updateData)
+		assert.Nil(t, err)
+		ids = append(ids, accountDto.Id)
+	}
+	idA, idB, idC := ids[0], ids[1], ids[2]
+
+	// The account exposes all the balances
+	response := test.SendAdminRequest(t, "GET", "/account/by-address/"+balanceKindAddressA, url.Values{"chain": {"LTC"}, "network": {"testnet"}}, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, "1", accountDto.Balance)
+	assert.Equal(t, "-0.5", accountDto.UnconfirmedBalance)
+	assert.Equal(t, "0.5", accountDto.TotalBalance)
+
+	filterTests := []struct {
+		balanceKind string
+		expectedIds []int64
+	}{
+		{"", []int64{idA, idC}},
+		{"confirmed", []int64{idA, idC}},
+		{"unconfirmed", []int64{idB}},
+		{"total", []int64{idB, idC}},
+	}
+	for _, tt := range filterTests {
+		query := url.Values{"balanceMin": {"0.55"}, "orderBy": {"id ASC"}}
+		if tt.balanceKind != "" {
+			query.Set("balanceKind", tt.balanceKind)
+		}
+		assert.Equal(t, tt.expectedIds, getBalanceKindAccountIds(t, query), "filter "+tt.balanceKind)
+	}
+
+	// The unconfirmed balance of a spending mempool transaction is negative
+	query := url.Values{"balanceKind": {"unconfirmed"}, "balanceMax": {"-0.1"}}
+	assert.Equal(t, []int64{idA}, getBalanceKindAccountIds(t, query))
+
+	sortTests := []struct {
+		query       url.Values
+		expectedIds []int64
+	}{
+		{url.Values{"orderBy": {"balance DESC"}}, []int64{idA, idC, idB}},
+		{url.Values{"orderBy": {"balance DESC"}, "balanceKind": {"unconfirmed"}}, []int64{idB, idC, idA}},
+		{url.Values{"orderBy": {"balance DESC"}, "balanceKind": {"total"}}, []int64{idC, idB, idA}},
+		{url.Values{"orderBy": {"total_balance ASC"}}, []int64{idA, idB, idC}},
+	}
+	for _, tt := range sortTests {
+		assert.Equal(t, tt.expectedIds, getBalanceKindAccountIds(t, tt.query), tt.query.Encode())
+	}
+
+	// The cursor pages keep the balance kind order
+	pageIds := make([]int64, 0)
+	query = url.Values{"orderBy": {"balance DESC"}, "balanceKind": {"total"}, "count": {"1"}, "chain": {"LTC"}, "network": {"testnet"}}
+	for page := 0; page < 3; page++ {
+		response = sendGetAccountsRequest(t, query)
+		assert.Equal(t, http.StatusOK, response.Code)
+		responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+		assert.Equal(t, 1, len(responseDto.List))
+		pageIds = append(pageIds, responseDto.List[0].Id)
+		if responseDto.NextCursor == nil {
+			break
+		}
+		query.Set("cursor", *responseDto.NextCursor)
+	}
+	assert.Equal(t, []int64{idC, idB, idA}, pageIds)
+
+	// The balances can be selected as fields
+	response = sendGetAccountsRequest(t, url.Values{"fields": {"unconfirmed_balance,total_balance"}, "chain": {"LTC"}, "network": {"testnet"}, "orderBy": {"id ASC"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+	fieldsResponse := test.DecodeResponse[struct {
+		List []map[string]interface{} `json:"list"`
+	}](t, response)
+	assert.Equal(t, 3, len(fieldsResponse.List))
+	assert.Equal(t, map[string]interface{}{"unconfirmed_balance": "-0.5", "total_balance": "0.5"}, fieldsResponse.List[0])
+}
@@ -0,0 +1,157 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendCreateAccountsBulkRequest(t *testing.T, params accountModuleDto.PostCreateAccountsBulkRequestDto) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "POST", "/account/bulk", nil, params)
+}
+
+func validationCreateAccountsBulkTests(t *testing.T) {
+	validItem := accountModuleDto.PostCreateAccountRequestDto{
+		Address: "1JLTERe1ctE1bK4TJtCYx3HWM3Z5pJLnMY",
+		Name:    "Bulk",
+		Rank:    10,
+		Status:  entities.AccountStatusActive,
+	}
+	tooManyItems := make([]accountModuleDto.PostCreateAccountRequestDto, config.AppConfig.BulkAccountMax+1)
+	for i := range tooManyItems {
+		tooManyItems[i] = validItem
+	}
+	validationTests := []struct {
+		name         string
+		params       accountModuleDto.PostCreateAccountsBulkRequestDto
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailNoItems",
+			accountModuleDto.PostCreateAccountsBulkRequestDto{},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Items must contain at least 1 element"},
+		},
+		{
+			"FailInvalidMode",
+			accountModuleDto.PostCreateAccountsBulkRequestDto{Mode: "invalid", Items: []accountModuleDto.PostCreateAccountRequestDto{validItem}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Mode must be one of the next values: atomic,bestEffort"},
+		},
+		{
+			"FailTooManyItems",
+			accountModuleDto.PostCreateAccountsBulkRequestDto{Items: tooManyItems},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: fmt.Sprintf("Items must contain at most %d elements", config.AppConfig.BulkAccountMax)},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestCreateAccountsBulkRoute_"+tt.name, func(t *testing.T) {
+			response := sendCreateAccountsBulkRequest(t, tt.params)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseBody := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestCreateAccountsBulkRoute_AtomicRollback(t *testing.T) {
+	params := accountModuleDto.PostCreateAccountsBulkRequestDto{
+		Mode: accountModuleDto.AccountBulkModeAtomic,
+		Items: []accountModuleDto.PostCreateAccountRequestDto{
+			{Address: "1NJXML3K4Wivx43EtYobzbdsKzmYzDbu8u", Name: "Bulk New", Rank: 10, Status: entities.AccountStatusActive},
+			{Address: seeds.ACCOUNTS.ACCOUNT_1.Address, Name: "Bulk Existing", Rank: 10, Status: entities.AccountStatusActive},
+		},
+	}
+
+	response := sendCreateAccountsBulkRequest(t, params)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostCreateAccountsBulkResponseDto](t, response)
+
+	assert.Equal(t, accountModuleDto.AccountBulkModeAtomic, responseDto.Mode)
+	assert.Equal(t, 2, responseDto.Total)
+	assert.Equal(t, 0, responseDto.Created)
+	assert.Equal(t, 1, responseDto.Failed)
+	assert.Equal(t, 2, len(responseDto.Items))
+	assert.Equal(t, accountModuleDto.AccountBulkItemStatusSkipped, responseDto.Items[0].Status)
+	assert.Equal(t, accountModuleDto.AccountBulkItemStatusConflict, responseDto.Items[1].Status)
+	assert.Equal(t, "Address already exists", responseDto.Items[1].Message)
+
+	assert.Equal(t, false, database.IsAddressExists(nil, entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Items[0].Address), "Atomic request must be rolled back")
+}
+
+func TestCreateAccountsBulkRoute_SuccessAtomic(t *testing.T) {
+	params := accountModuleDto.PostCreateAccountsBulkRequestDto{
+		Mode: accountModuleDto.AccountBulkModeAtomic,
+		Items: []accountModuleDto.PostCreateAccountRequestDto{
+			{Address: "1LaXf1RfYwBdziJYTBAHTchiKcpF2RbrJZ", Name: "Bulk One", Rank: 10, Status: entities.AccountStatusActive},
+			{Address: "38LMka9GRPbzg1TrPuRBdmcsJV546qwGtQ", Name: "Bulk Two", Rank: 20, Memo: "Bulk memo", Status: entities.AccountStatusSuspended},
+		},
+	}
+
+	response := sendCreateAccountsBulkRequest(t, params)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostCreateAccountsBulkResponseDto](t, response)
+
+	assert.Equal(t, 2, responseDto.Total)
+	assert.Equal(t, 2, responseDto.Created)
+	assert.Equal(t, 0, responseDto.Failed)
+	for index, item := range responseDto.Items {
+		assert.Equal(t, index, item.Index)
+		assert.Equal(t, accountModuleDto.AccountBulkItemStatusCreated, item.Status)
+		assert.NotNil(t, item.Account)
+		accountAfter := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Items[index].Address)
+		assert.NotNil(t, accountAfter)
+		assert.Equal(t, params.Items[index].Name, accountAfter.Name)
+		test.CompareAccount(t, accountAfter, *item.Account)
+	}
+}
+
+func TestCreateAccountsBulkRoute_SuccessBestEffort(t *testing.T) {
+	params := accountModuleDto.PostCreateAccountsBulkRequestDto{
+		Mode: accountModuleDto.AccountBulkModeBestEffort,
+		Items: []accountModuleDto.PostCreateAccountRequestDto{
+			{Address: "12EgPexBkVKq8d5iXYqJXzx3ULDrzLeRvh", Name: "Bulk Created", Rank: 10, Status: entities.AccountStatusActive},
+			{Address: "12EgPexBkVKq8d5iXYqJXzx3ULDrzLeRvh", Name: "Bulk Duplicate", Rank: 10, Status: entities.AccountStatusActive},
+			{Address: seeds.ACCOUNTS.ACCOUNT_2.Address, Name: "Bulk Existing", Rank: 10, Status: entities.AccountStatusActive},
+			{Address: "invalid address", Name: "Bulk Invalid", Rank: 10, Status: entities.AccountStatusActive},
+		},
+	}
+
+	response := sendCreateAccountsBulkRequest(t, params)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostCreateAccountsBulkResponseDto](t, response)
+
+	assert.Equal(t, accountModuleDto.AccountBulkModeBestEffort, responseDto.Mode)
+	assert.Equal(t, 4, responseDto.Total)
+	assert.Equal(t, 1, responseDto.Created)
+	assert.Equal(t, 3, responseDto.Failed)
+
+	assert.Equal(t, accountModuleDto.AccountBulkItemStatusCreated, responseDto.Items[0].Status)
+	assert.Equal(t, accountModuleDto.AccountBulkItemStatusConflict, responseDto.Items[1].Status)
+	assert.Equal(t, "Duplicate address in request", responseDto.Items[1].Message)
+	assert.Equal(t, accountModuleDto.AccountBulkItemStatusConflict, responseDto.Items[2].Status)
+	assert.Equal(t, "Address already exists", responseDto.Items[2].Message)
+	assert.Equal(t, accountModuleDto.AccountBulkItemStatusInvalid, responseDto.Items[3].Status)
+	assert.Equal(t, "Address format is wrong", responseDto.Items[3].Message)
+
+	accountAfter := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Items[0].Address)
+	assert.NotNil(t, accountAfter)
+	assert.Equal(t, params.Items[0].Name, accountAfter.Name)
+	test.CompareAccount(t, accountAfter, *responseDto.Items[0].Account)
+
+	existingAccount := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, seeds.ACCOUNTS.ACCOUNT_2.Address)
+	assert.Equal(t, seeds.ACCOUNTS.ACCOUNT_2.Name, existingAccount.Name, "Existing account must not be changed")
+}
@@ -0,0 +1,169 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ltcP2wpkhAddress   = "ltc1qka8r22kmtty9g0fqh7yf9zfyxy83sh8xct4unj"
+	ltcP2pkhAddress    = "LZ1PzNWetRGaSvETJXUNJH7shWiDu27YSU"
+	ltcP2shAddress     = "MTV38zudQGtoxqiKFWtp5AnVfNrES7U7YD"
+	bchP2pkhAddress    = "bitcoincash:qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za963"
+	bchP2shAddress     = "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq"
+	dogeP2pkhAddress   = "DFJfM8QrSJAqYTGDt7GNc24bHpuYZmUseB"
+	dogeP2shAddress    = "A5eZArP6dEYMwAZAnFC6fzukx3q28ckQ2w"
+	chainErrorMessage  = "Chain must be one of the next values: BTC,LTC,BCH,DOGE"
+	chainAccountStatus = entities.AccountStatusPending
+)
+
+func createChainAccount(t *testing.T, chain string, network string, address string) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"chain": "%s", "network": "%s", "address": "%s", "name": "Chain", "rank": 10, "status": "%s"}`, chain, network, address, chainAccountStatus)
+	return test.SendAdminRequest(t, "POST", "/account", nil, body)
+}
+
+func TestAccountChainRoute_Fail(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		chain        string
+		network      string
+		address      string
+		expectedBody string
+	}{
+		{"FailUnknownChain", "ETH", "mainnet", ltcP2wpkhAddress, chainErrorMessage},
+		{"FailChainNetwork", "LTC", "signet", ltcP2wpkhAddress, "Network signet is not supported by the chain"},
+		{"FailBtcAddressOnDoge", "DOGE", "mainnet", seeds.ACCOUNTS.ACCOUNT_4.Address, "Address format is wrong"},
+		{"FailLtcAddressOnBtc", "BTC", "mainnet", ltcP2wpkhAddress, "Address format is wrong"},
+		{"FailCashAddrChecksum", "BCH", "mainnet", "bitcoincash:qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za964", "Address format is wrong"},
+		{"FailCashAddrPrefix", "BCH", "testnet", bchP2pkhAddress, "Address format is wrong"},
+		{"FailCashAddrMixedCase", "BCH", "mainnet", "bitcoincash:QZG2NMNXMGPYW3JP3Q4LGNNL8WH0KMNL253R8ZA963", "Address format is wrong"},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestAccountChainRoute_"+tt.name, func(t *testing.T) {
+			response := createChainAccount(t, tt.chain, tt.network, tt.address)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto.Message)
+		})
+	}
+
+	for _, response := range []*httptest.ResponseRecorder{
+		sendGetAccountsRequest(t, url.Values{"chain": {"ETH"}}),
+		sendGetAccountStatsRequest(t, url.Values{"chain": {"ETH"}}),
+		sendGetAccountByAddressRequest(t, ltcP2wpkhAddress, url.Values{"chain": {"ETH"}}),
+	} {
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+		assert.Equal(t, chainErrorMessage, responseDto.Message)
+	}
+}
+
+func TestAccountChainRoute_Success(t *testing.T) {
+	accountIds := make(map[string]int64)
+	createTests := []struct {
+		chain               string
+		address             string
+		expectedAddress     string
+		expectedAddressType string
+	}{
+		{"LTC", ltcP2wpkhAddress, ltcP2wpkhAddress, "p2wpkh"},
+		{"LTC", ltcP2pkhAddress, ltcP2pkhAddress, "p2pkh"},
+		{"LTC", ltcP2shAddress, ltcP2shAddress, "p2sh"},
+		// CashAddr is stored with the prefix in lowercase
+		{"BCH", "QZG2NMNXMGPYW3JP3Q4LGNNL8WH0KMNL253R8ZA963", bchP2pkhAddress, "p2pkh"},
+		{"BCH", bchP2shAddress, bchP2shAddress, "p2sh"},
+		{"DOGE", dogeP2pkhAddress, dogeP2pkhAddress, "p2pkh"},
+		{"DOGE", dogeP2shAddress, dogeP2shAddress, "p2sh"},
+	}
+	for _, tt := range createTests {
+		response := createChainAccount(t, tt.chain, "mainnet", tt.address)
+		assert.Equal(t, http.StatusOK, response.Code, tt.chain+" "+tt.address)
+		accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+		assert.Equal(t, tt.chain, accountDto.Chain)
+		assert.Equal(t, tt.chain, accountDto.Currency)
+		assert.Equal(t, tt.expectedAddress, accountDto.Address)
+		assert.Equal(t, tt.expectedAddressType, accountDto.AddressType)
+		test.CompareAccount(t, database.GetAccountById(accountDto.Id), accountDto)
+		accountIds[tt.chain+":"+tt.expectedAddress] = accountDto.Id
+	}
+
+	// The address without the prefix is the same CashAddr address
+	response := createChainAccount(t, "BCH", "mainnet", "qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za963")
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	response = sendGetAccountByAddressRequest(t, "qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za963", url.Values{"chain": {"BCH"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+	accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, accountIds["BCH:"+bchP2pkhAddress], accountDto.Id)
+
+	filterTests := []struct {
+		chain       string
+		expectedIds []int64
+	}{
+		{"LTC", []int64{accountIds["LTC:"+ltcP2wpkhAddress], accountIds["LTC:"+ltcP2pkhAddress], accountIds["LTC:"+ltcP2shAddress]}},
+		{"BCH", []int64{accountIds["BCH:"+bchP2pkhAddress], accountIds["BCH:"+bchP2shAddress]}},
+		{"DOGE", []int64{accountIds["DOGE:"+dogeP2pkhAddress], accountIds["DOGE:"+dogeP2shAddress]}},
+	}
+	for _, tt := range filterTests {
+		response = sendGetAccountsRequest(t, url.Values{"chain": {tt.chain}, "orderBy": {"id ASC"}})
+		assert.Equal(t, http.StatusOK, response.Code)
+		responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+		ids := make([]int64, 0)
+		for _, listAccountDto := range responseDto.List {
+			ids = append(ids, listAccountDto.Id)
+		}
+		assert.Equal(t, tt.expectedIds, ids, tt.chain)
+	}
+
+	// The stats of one chain are in its currency, byChain keeps the chains apart
+	response = sendGetAccountStatsRequest(t, url.Values{"status": {string(chainAccountStatus)}})
+	assert.Equal(t, http.StatusOK, response.Code)
+	statsDto := test.DecodeResponse[accountModuleDto.GetAccountStatsResponseDto](t, response)
+	chainCounts := make(map[entities.AccountChain]int64)
+	for _, chainStatsDto := range statsDto.ByChain {
+		assert.Equal(t, string(chainStatsDto.Chain), chainStatsDto.Currency)
+		chainCounts[chainStatsDto.Chain] = chainStatsDto.Count
+	}
+	assert.Equal(t, int64(3), chainCounts[entities.AccountChainLtc])
+	assert.Equal(t, int64(2), chainCounts[entities.AccountChainBch])
+	assert.Equal(t, int64(2), chainCounts[entities.AccountChainDoge])
+	// The status balances and the top accounts are per chain too
+	statusChainCounts := make(map[entities.AccountChain]int64)
+	for _, statusStatsDto := range statsDto.ByStatus {
+		assert.Equal(t, string(statusStatsDto.Chain), statusStatsDto.Currency)
+		statusChainCounts[statusStatsDto.Chain] += statusStatsDto.Count
+	}
+	assert.Equal(t, chainCounts, statusChainCounts)
+	for _, chainTopDto := range statsDto.TopByBalance {
+		for _, accountDto := range chainTopDto.Accounts {
+			assert.Equal(t, string(chainTopDto.Chain), accountDto.Chain)
+		}
+	}
+
+	response = sendGetAccountStatsRequest(t, url.Values{"chain": {"DOGE"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+	statsDto = test.DecodeResponse[accountModuleDto.GetAccountStatsResponseDto](t, response)
+	assert.Equal(t, int64(2), statsDto.Count)
+	assert.Equal(t, []accountModuleDto.AccountChainStatsDto{
+		{Chain: entities.AccountChainDoge, Currency: "DOGE", Count: 2, Balance: "0"},
+	}, statsDto.ByChain)
+
+	// Leave the accounts out of the later polling tests
+	for _, accountId := range accountIds {
+		response = test.SendAdminRequest(t, "POST", fmt.Sprintf("/account/%d/transition", accountId), nil, `{"status": "Suspended", "reason": "Chain test"}`)
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+}
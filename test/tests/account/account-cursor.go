@@ -0,0 +1,120 @@
+package accountTests
+
+import (
+	"encoding/json"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	orderUtil "go-gin-test-job/src/utils/order"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendGetAccountsRequest(t *testing.T, query url.Values) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "GET", "/account", query, nil)
+}
+
+func validationGetAccountsCursorTests(t *testing.T) {
+	idCursor := orderUtil.EncodeCursor([]orderUtil.OrderParam{{Field: "id", Direction: "ASC"}}, []interface{}{1})
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailCursorWithOffset",
+			url.Values{"cursor": {idCursor}, "offset": {"2"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Cursor can not be used together with Offset"},
+		},
+		{
+			"FailCursorNotBase64",
+			url.Values{"cursor": {"not a cursor"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Cursor is invalid"},
+		},
+		{
+			"FailCursorOtherOrderBy",
+			url.Values{"cursor": {idCursor}, "orderBy": {"rank DESC"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Cursor does not match orderBy"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var responseBody errorHelpers.ResponseBadRequestErrorHTTP
+			err := json.Unmarshal(response.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestGetAccountsRoute_SuccessOrderByPrecedence(t *testing.T) {
+	orderParams, err := orderUtil.GetOrderByParamsSecure(nil, "rank DESC, name ASC,rank ASC", ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []orderUtil.OrderParam{
+		{Field: "rank", Direction: "DESC"},
+		{Field: "name", Direction: "ASC"},
+		{Field: "id", Direction: "ASC"},
+	}, orderParams)
+
+	orderParams, err = orderUtil.GetOrderByParamsSecure(nil, "id DESC", ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []orderUtil.OrderParam{{Field: "id", Direction: "DESC"}}, orderParams)
+}
+
+func TestGetAccountsRoute_SuccessCursorPagination(t *testing.T) {
+	testCases := []struct {
+		name    string
+		orderBy string
+	}{
+		{"ByDefault", ""},
+		{"ByRankDesc", "rank DESC"},
+		{"ByUpdatedAtAndNameDesc", "updated_at ASC,name DESC"},
+		{"ByBalanceDescAndStatus", "balance DESC,status ASC"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderParams, err := orderUtil.GetOrderByParamsSecure(nil, tc.orderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+			assert.NoError(t, err)
+			accounts, total := database.GetAccountsAndTotal(database.AccountFilter{}, orderParams, 0, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
+
+			ids := make([]int64, 0)
+			cursor := ""
+			for page := 0; page <= len(accounts); page++ {
+				query := url.Values{"count": {"1"}, "orderBy": {tc.orderBy}}
+				if cursor != "" {
+					query.Add("cursor", cursor)
+				}
+				response := sendGetAccountsRequest(t, query)
+				assert.Equal(t, http.StatusOK, response.Code)
+
+				var responseDto accountModuleDto.GetAccountResponseDto
+				err = json.Unmarshal(response.Body.Bytes(), &responseDto)
+				assert.NoError(t, err)
+				assert.Equal(t, total, responseDto.Total)
+				for _, accountDto := range responseDto.List {
+					ids = append(ids, accountDto.Id)
+				}
+				if responseDto.NextCursor == nil {
+					break
+				}
+				cursor = *responseDto.NextCursor
+			}
+
+			expectedIds := make([]int64, 0)
+			for _, account := range accounts {
+				expectedIds = append(expectedIds, account.Id)
+			}
+			assert.Equal(t, expectedIds, ids)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package accountTests
+
+import (
+	"fmt"
+	"go-gin-test-job/src/common/dto"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testAuditContext is the actor of the changes the tests write without a request
+var testAuditContext = database.AuditContext{Actor: "test"}
+
+func createDeleteTestAccount(t *testing.T, address string, isDeleted bool) *entities.Account {
+	account, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, address, entities.AccountStatusActive, "Delete Test", 10, "Delete test memo"))
+	assert.Nil(t, err)
+	if isDeleted {
+		err = database.UpdateAccountWithDeleted(nil, testAuditContext, entities.AuditActionDelete, account, account.MarkDeleted())
+		assert.Nil(t, err)
+	}
+	return account
+}
+
+func TestDeleteAccountRoute_FailNotFound(t *testing.T) {
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/account/%d", 999999), nil, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Account not found", responseDto.Message)
+}
+
+func TestDeleteAccountRoute_Success(t *testing.T) {
+	account := createDeleteTestAccount(t, "1KUCzSr49wPWckUDouJLybJuRYtViF5hfM", false)
+
+	path := fmt.Sprintf("/account/%d", account.Id)
+	response := test.SendAdminRequest(t, "DELETE", path, nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[dto.SuccessDto](t, response)
+	assert.Equal(t, true, responseDto.Success)
+
+	// Deleted account is hidden from every read
+	assert.Nil(t, database.GetAccountById(account.Id))
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, account.Address))
+	assert.Equal(t, false, database.IsAddressExists(nil, entities.AccountChainBtc, entities.AccountNetworkMainnet, account.Address))
+	assert.Equal(t, 0, len(database.GetAccountsByIds([]int64{account.Id})))
+	for _, batchAccount := range database.GetAccountsBatch([]entities.AccountChainNetwork{{Chain: entities.AccountChainBtc, Network: entities.AccountNetworkMainnet}}, 1000) {
+		assert.NotEqual(t, account.Id, batchAccount.Id, "Deleted account must not be polled")
+	}
+
+	accountAfter := database.GetAccountByIdWithDeleted(account.Id)
+	assert.NotNil(t, accountAfter)
+	assert.NotNil(t, accountAfter.DeletedAt)
+
+	// Second delete does not find the account
+	response = test.SendAdminRequest(t, "DELETE", path, nil, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestRestoreAccountRoute_FailNotDeleted(t *testing.T) {
+	response := test.SendAdminRequest(t, "POST", fmt.Sprintf("/account/%d/restore", seeds.ACCOUNTS.ACCOUNT_1.Id), nil, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Account is not deleted", responseDto.Message)
+}
+
+func TestRestoreAccountRoute_Success(t *testing.T) {
+	account := createDeleteTestAccount(t, "16fZuj9x4tozLd6CAQ7AhTLd9RYXEfJL2U", true)
+	assert.Nil(t, database.GetAccountById(account.Id))
+
+	response := test.SendAdminRequest(t, "POST", fmt.Sprintf("/account/%d/restore", account.Id), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+
+	accountAfter := database.GetAccountById(account.Id)
+	assert.NotNil(t, accountAfter)
+	assert.Nil(t, accountAfter.DeletedAt)
+	test.CompareAccount(t, accountAfter, responseDto)
+}
+
+func TestCreateAccountRoute_SuccessRestoresDeleted(t *testing.T) {
+	account := createDeleteTestAccount(t, "15Ep2vW3TjZVpdvmFFvw3XrEAALpRabJTG", true)
+	params := accountModuleDto.PostCreateAccountRequestDto{
+		Address: account.Address,
+		Name:    "Restored Name",
+		Rank:    33,
+		Memo:    "Restored memo",
+		Status:  entities.AccountStatusSuspended,
+	}
+
+	response := test.SendAdminRequest(t, "POST", "/account", nil, params)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+
+	assert.Equal(t, account.Id, responseDto.Id, "Deleted account row must be reused")
+	accountAfter := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, account.Address)
+	assert.NotNil(t, accountAfter)
+	assert.Equal(t, params.Name, accountAfter.Name)
+	assert.Equal(t, params.Rank, accountAfter.Rank)
+	assert.Equal(t, params.Memo, accountAfter.Memo)
+	assert.Equal(t, params.Status, accountAfter.Status)
+	test.CompareAccount(t, accountAfter, responseDto)
+}
+
+func TestPurgeAccountRoute_FailNotDeleted(t *testing.T) {
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/account/%d/purge", seeds.ACCOUNTS.ACCOUNT_1.Id), nil, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Account must be deleted before purge", responseDto.Message)
+	assert.NotNil(t, database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_1.Id))
+}
+
+func TestPurgeAccountRoute_Success(t *testing.T) {
+	account := createDeleteTestAccount(t, "13FTZKk18itEyDpnPvCcUFTYsQ74kNqr9z", true)
+
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/account/%d/purge", account.Id), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[dto.SuccessDto](t, response)
+	assert.Equal(t, true, responseDto.Success)
+
+	assert.Nil(t, database.GetAccountByIdWithDeleted(account.Id))
+}
@@ -0,0 +1,113 @@
+package accountTests
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendExportAccountsRequest(t *testing.T, query url.Values, accept string) *httptest.ResponseRecorder {
+	request := test.NewAdminRequest(t, "GET", "/account/export", query, nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	return test.ServeRequest(request)
+}
+
+func validationExportAccountsTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailInvalidFormat",
+			url.Values{"format": {"xml"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Format must be one of the next values: csv,tsv,ndjson"},
+		},
+		{
+			"FailUnknownField",
+			url.Values{"fields": {"id,password"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: accountModuleDto.GetAccountFieldsErrorMessage("password", accountModuleDto.AccountExportFieldList)},
+		},
+		{
+			"FailInvalidOrderBy",
+			url.Values{"orderBy": {"balance ASC"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "cannot order by balance ASC"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestExportAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendExportAccountsRequest(t, tt.query, "")
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var responseBody errorHelpers.ResponseBadRequestErrorHTTP
+			err := json.Unmarshal(response.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestExportAccountsRoute_SuccessCsv(t *testing.T) {
+	query := url.Values{"status": {"Active"}, "orderBy": {"id DESC"}, "fields": {"id,address,name,balance"}}
+	response := sendExportAccountsRequest(t, query, "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(response.Header().Get("Content-Disposition"), "attachment; filename=\"accounts-"))
+
+	account1 := seeds.ACCOUNTS.ACCOUNT_1
+	account2 := seeds.ACCOUNTS.ACCOUNT_2
+	expectedBody := "id,address,name,balance\n" +
+		fmt.Sprintf("%d,%s,%s,%s\n", account2.Id, account2.Address, account2.Name, account2.Balance.String()) +
+		fmt.Sprintf("%d,%s,%s,%s\n", account1.Id, account1.Address, account1.Name, account1.Balance.String())
+	assert.Equal(t, expectedBody, response.Body.String())
+}
+
+func TestExportAccountsRoute_SuccessTsv(t *testing.T) {
+	query := url.Values{"format": {"tsv"}, "search": {"Charlie"}, "fields": {"address,rank,status"}}
+	response := sendExportAccountsRequest(t, query, "application/x-ndjson")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/tab-separated-values; charset=utf-8", response.Header().Get("Content-Type"))
+
+	account3 := seeds.ACCOUNTS.ACCOUNT_3
+	expectedBody := "address\trank\tstatus\n" +
+		fmt.Sprintf("%s\t%d\t%s\n", account3.Address, account3.Rank, account3.Status)
+	assert.Equal(t, expectedBody, response.Body.String())
+}
+
+func TestExportAccountsRoute_SuccessNdjsonByAccept(t *testing.T) {
+	query := url.Values{"orderBy": {"id ASC"}}
+	response := sendExportAccountsRequest(t, query, "text/html;q=0.9, application/x-ndjson")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", response.Header().Get("Content-Type"))
+
+	accounts := seeds.GetAccountList()
+	scanner := bufio.NewScanner(response.Body)
+	index := 0
+	for scanner.Scan() {
+		var row map[string]interface{}
+		err := json.Unmarshal(scanner.Bytes(), &row)
+		assert.NoError(t, err)
+		assert.Less(t, index, len(accounts))
+		assert.Equal(t, float64(accounts[index].Id), row["id"])
+		assert.Equal(t, accounts[index].Address, row["address"])
+		assert.Equal(t, accounts[index].Balance.String(), row["balance"])
+		assert.Equal(t, string(accounts[index].Status), row["status"])
+		assert.Len(t, row, 9)
+		index++
+	}
+	assert.Equal(t, len(accounts), index)
+}
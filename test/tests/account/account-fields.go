@@ -0,0 +1,139 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sparseAccountResponseDto keeps the raw list items to check which keys are present
+type sparseAccountResponseDto struct {
+	List       []map[string]interface{} `json:"list"`
+	NextCursor *string                  `json:"nextCursor"`
+}
+
+func sendGetAccountFieldsRequest(t *testing.T, path string, fields string) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "GET", path, url.Values{"fields": {fields}}, nil)
+}
+
+func getAccountItemKeys(item map[string]interface{}) []string {
+	keys := make([]string, 0, len(item))
+	for key := range item {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func validationGetAccountsFieldsTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		path         string
+		fields       string
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailListUnknownField",
+			"/account",
+			"id,password",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: accountModuleDto.GetAccountFieldsErrorMessage("password", accountModuleDto.AccountFieldList)},
+		},
+		{
+			"FailListScoreField",
+			"/account",
+			"id,score",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: accountModuleDto.GetAccountFieldsErrorMessage("score", accountModuleDto.AccountFieldList)},
+		},
+		{
+			"FailByIdUnknownField",
+			fmt.Sprintf("/account/%d", seeds.ACCOUNTS.ACCOUNT_1.Id),
+			"search",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: accountModuleDto.GetAccountFieldsErrorMessage("search", accountModuleDto.AccountFieldList)},
+		},
+		{
+			"FailByAddressUnknownField",
+			fmt.Sprintf("/account/by-address/%s", seeds.ACCOUNTS.ACCOUNT_1.Address),
+			"address,owner",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: accountModuleDto.GetAccountFieldsErrorMessage("owner", accountModuleDto.AccountFieldList)},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountFieldsRequest(t, tt.path, tt.fields)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto)
+		})
+	}
+}
+
+func TestGetAccountsRoute_SuccessFields(t *testing.T) {
+	response := sendGetAccountsRequest(t, url.Values{"fields": {"id,address,balance"}, "orderBy": {"rank DESC"}, "count": {"2"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[sparseAccountResponseDto](t, response)
+	assert.Equal(t, 2, len(responseDto.List))
+	for _, item := range responseDto.List {
+		assert.ElementsMatch(t, []string{"id", "address", "balance"}, getAccountItemKeys(item))
+		account := database.GetAccountById(int64(item["id"].(float64)))
+		assert.NotNil(t, account)
+		assert.Equal(t, account.Address, item["address"])
+		assert.Equal(t, account.Balance.String(), item["balance"])
+	}
+
+	// The cursor is built from rank, which is read but not returned
+	assert.NotNil(t, responseDto.NextCursor)
+	if responseDto.NextCursor == nil {
+		return
+	}
+	response = sendGetAccountsRequest(t, url.Values{"fields": {"id,address,balance"}, "orderBy": {"rank DESC"}, "count": {"2"}, "cursor": {*responseDto.NextCursor}})
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	nextResponseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+	assert.NotEqual(t, 0, len(nextResponseDto.List))
+	for _, accountDto := range nextResponseDto.List {
+		account := database.GetAccountById(accountDto.Id)
+		assert.NotNil(t, account)
+		assert.LessOrEqual(t, account.Rank, seeds.ACCOUNTS.ACCOUNT_1.Rank)
+		assert.Equal(t, "", accountDto.Name)
+	}
+}
+
+func TestGetAccountByIdRoute_SuccessFields(t *testing.T) {
+	account := database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_1.Id)
+	assert.NotNil(t, account)
+
+	response := sendGetAccountFieldsRequest(t, fmt.Sprintf("/account/%d", account.Id), "tags,name")
+	assert.Equal(t, http.StatusOK, response.Code)
+	// The ETag is built from fields which were not requested
+	assert.Equal(t, accountModuleDto.CreateAccountETag(account), response.Header().Get("ETag"))
+
+	responseDto := test.DecodeResponse[map[string]interface{}](t, response)
+	assert.ElementsMatch(t, []string{"name", "tags"}, getAccountItemKeys(responseDto))
+	assert.Equal(t, account.Name, responseDto["name"])
+	assert.Equal(t, len(account.Tags), len(responseDto["tags"].([]interface{})))
+}
+
+func TestGetAccountByAddressRoute_SuccessFields(t *testing.T) {
+	account := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, seeds.ACCOUNTS.ACCOUNT_2.Address)
+	assert.NotNil(t, account)
+
+	response := sendGetAccountFieldsRequest(t, fmt.Sprintf("/account/by-address/%s", account.Address), "status, rank")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[map[string]interface{}](t, response)
+	assert.ElementsMatch(t, []string{"rank", "status"}, getAccountItemKeys(responseDto))
+	assert.Equal(t, float64(account.Rank), responseDto["rank"])
+	assert.Equal(t, string(account.Status), responseDto["status"])
+}
@@ -0,0 +1,146 @@
+package accountTests
+
+import (
+	"encoding/json"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validationGetAccountsFilterTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailInvalidStatusList",
+			url.Values{"status": {"Active,Deleted"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Status must be one of the next values: Pending,Active,Suspended,Archived,Error"},
+		},
+		{
+			"FailInvalidBalanceMin",
+			url.Values{"balanceMin": {"one"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "BalanceMin must be a decimal number, non-negative for the confirmed balance"},
+		},
+		{
+			"FailNegativeBalanceMax",
+			url.Values{"balanceMax": {"-0.5"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "BalanceMax must be a decimal number, non-negative for the confirmed balance"},
+		},
+		{
+			"FailBalanceRange",
+			url.Values{"balanceMin": {"0.5"}, "balanceMax": {"0.25"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "BalanceMin must be less than or equal BalanceMax"},
+		},
+		{
+			"FailRankMaxValue",
+			url.Values{"rankMax": {"101"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "RankMax must be less than or equal 100"},
+		},
+		{
+			"FailRankRange",
+			url.Values{"rankMin": {"60"}, "rankMax": {"50"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "RankMin must be less than or equal RankMax"},
+		},
+		{
+			"FailCreatedFromMinValue",
+			url.Values{"createdFrom": {"-1"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "CreatedFrom must be a unix timestamp greater than or equal 0"},
+		},
+		{
+			"FailUpdatedRange",
+			url.Values{"updatedFrom": {"1700000000"}, "updatedTo": {"1600000000"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "UpdatedFrom must be less than or equal UpdatedTo"},
+		},
+		{
+			"FailInvalidAddressType",
+			url.Values{"addressType": {"p2pk"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "AddressType must be one of the next values: p2pkh,p2sh,p2wpkh,p2wsh,p2tr,bech32"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var responseBody errorHelpers.ResponseBadRequestErrorHTTP
+			err := json.Unmarshal(response.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestGetAccountsRoute_SuccessFilters(t *testing.T) {
+	account1 := seeds.ACCOUNTS.ACCOUNT_1
+	account2 := seeds.ACCOUNTS.ACCOUNT_2
+	account3 := seeds.ACCOUNTS.ACCOUNT_3
+	account4 := seeds.ACCOUNTS.ACCOUNT_4
+
+	testCases := []struct {
+		name        string
+		query       url.Values
+		expectedIds []int64
+	}{
+		{
+			"ByStatusList",
+			url.Values{"status": {"Active,Suspended"}},
+			[]int64{account1.Id, account2.Id, account3.Id, account4.Id},
+		},
+		{
+			"ByBalanceRange",
+			url.Values{"balanceMin": {"0.00056665"}, "balanceMax": {"0.07134313"}},
+			[]int64{account2.Id, account4.Id},
+		},
+		{
+			"ByRankRange",
+			url.Values{"rankMin": {"50"}, "rankMax": {"75"}},
+			[]int64{account1.Id, account2.Id},
+		},
+		{
+			"ByCreatedRange",
+			url.Values{"createdFrom": {"0"}, "createdTo": {"1"}},
+			[]int64{},
+		},
+		{
+			"ByAddressTypeAndStatus",
+			url.Values{"addressType": {"p2sh"}, "status": {"Suspended"}},
+			[]int64{account3.Id},
+		},
+		{
+			"SortByBalance",
+			url.Values{"orderBy": {"balance DESC"}},
+			[]int64{account1.Id, account4.Id, account2.Id, account3.Id},
+		},
+		{
+			"SortByStatusAndRank",
+			url.Values{"orderBy": {"status DESC,rank DESC"}},
+			[]int64{account1.Id, account2.Id, account4.Id, account3.Id},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tc.query)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var responseDto accountModuleDto.GetAccountResponseDto
+			err := json.Unmarshal(response.Body.Bytes(), &responseDto)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expectedIds)), responseDto.Total)
+
+			ids := make([]int64, 0)
+			for _, accountDto := range responseDto.List {
+				ids = append(ids, accountDto.Id)
+			}
+			assert.Equal(t, tc.expectedIds, ids)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package accountTests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	middleware "go-gin-test-job/src/middlewares"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	timeUtil "go-gin-test-job/src/utils/time"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendIdempotentCreateAccountRequest(t *testing.T, idempotencyKey string, body string) *httptest.ResponseRecorder {
+	request := test.NewAdminRequest(t, "POST", "/account", nil, body)
+	if idempotencyKey != "" {
+		request.Header.Set(middleware.IdempotencyKeyHeader, idempotencyKey)
+	}
+	return test.ServeRequest(request)
+}
+
+func createIdempotencyAccountBody(address string, name string) string {
+	return fmt.Sprintf(`{"address": "%s", "name": "%s", "rank": 10, "status": "Active"}`, address, name)
+}
+
+func TestCreateAccountRoute_FailIdempotencyKeyTooLong(t *testing.T) {
+	response := sendIdempotentCreateAccountRequest(t, strings.Repeat("k", 256), createIdempotencyAccountBody("14CFdQmFHLqJPyTVgqemyAXbgdqUnUzfB4", "Idempotency Long"))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+	assert.Equal(t, "Idempotency-Key must be shorter than or equal to 255 characters", responseDto.Message)
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "14CFdQmFHLqJPyTVgqemyAXbgdqUnUzfB4"))
+}
+
+func TestCreateAccountRoute_SuccessIdempotencyKeyReplay(t *testing.T) {
+	body := createIdempotencyAccountBody("14CFdQmFHLqJPyTVgqemyAXbgdqUnUzfB4", "Idempotency Replay")
+	response := sendIdempotentCreateAccountRequest(t, "create-account-replay", body)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "", response.Header().Get(middleware.IdempotentReplayedHeader))
+	firstBody := response.Body.String()
+
+	// The retry gets the stored response instead of 409 Address already exists
+	response = sendIdempotentCreateAccountRequest(t, "create-account-replay", body)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "true", response.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Contains(t, response.Header().Get("Content-Type"), "application/json")
+	assert.Equal(t, firstBody, response.Body.String())
+
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	account := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "14CFdQmFHLqJPyTVgqemyAXbgdqUnUzfB4")
+	assert.NotNil(t, account)
+	test.CompareAccount(t, account, responseDto)
+
+	// Without the key the same request is a real conflict
+	response = sendIdempotentCreateAccountRequest(t, "", body)
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
+func TestCreateAccountRoute_FailIdempotencyKeyOtherRequest(t *testing.T) {
+	response := sendIdempotentCreateAccountRequest(t, "create-account-replay", createIdempotencyAccountBody("1NGFjR4T2xHDrM4Uoeorc9BifWkmU5H6Uj", "Idempotency Other"))
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseUnprocessableEntityErrorHTTP](t, response)
+	assert.Equal(t, "Idempotency-Key was used with a different request", responseDto.Message)
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1NGFjR4T2xHDrM4Uoeorc9BifWkmU5H6Uj"))
+}
+
+func TestCreateAccountRoute_SuccessIdempotencyKeyExpired(t *testing.T) {
+	err := database.DbConn.Exec("UPDATE idempotency_key SET expires_at = ? WHERE request_key = ?", timeUtil.GetUnixTime()-1, "create-account-replay").Error
+	assert.Nil(t, err)
+
+	// An expired key is reused for the new request
+	response := sendIdempotentCreateAccountRequest(t, "create-account-replay", createIdempotencyAccountBody("1NGFjR4T2xHDrM4Uoeorc9BifWkmU5H6Uj", "Idempotency Expired"))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "", response.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.NotNil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1NGFjR4T2xHDrM4Uoeorc9BifWkmU5H6Uj"))
+}
+
+func TestCreateAccountRoute_SuccessIdempotencyKeyConcurrent(t *testing.T) {
+	body := createIdempotencyAccountBody("19bboitwXdxS4ZzY6XSQTj4bVoP3sVSRm3", "Idempotency Concurrent")
+	const requestCount = 5
+	responses := make([]*httptest.ResponseRecorder, requestCount)
+	var wg sync.WaitGroup
+	for index := 0; index < requestCount; index++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			responses[index] = sendIdempotentCreateAccountRequest(t, "create-account-concurrent", body)
+		}(index)
+	}
+	wg.Wait()
+
+	// One request creates the account, the others wait for it and get its response
+	replayedCount := 0
+	for _, response := range responses {
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, responses[0].Body.String(), response.Body.String())
+		if response.Header().Get(middleware.IdempotentReplayedHeader) == "true" {
+			replayedCount++
+		}
+	}
+	assert.Equal(t, requestCount-1, replayedCount)
+}
+
+func TestCreateAccountRoute_FailIdempotencyKeyInProgress(t *testing.T) {
+	waitSec := config.AppConfig.IdempotencyKeyWaitSec
+	defer func() {
+		config.AppConfig.IdempotencyKeyWaitSec = waitSec
+	}()
+	config.AppConfig.IdempotencyKeyWaitSec = 0
+
+	// The key is claimed by a request which response was not stored
+	body := createIdempotencyAccountBody("1CounterpartyXXXXXXXXXXXXXXXUWLpVr", "Idempotency In Progress")
+	fingerprint := sha256.Sum256([]byte("POST /account\n" + body))
+	_, isCreated, err := database.CreateIdempotencyKeyForUpdate(database.DbConn, entities.CreateIdempotencyKey("create-account-in-progress", hex.EncodeToString(fingerprint[:]), timeUtil.GetUnixTime()+60))
+	assert.Nil(t, err)
+	assert.True(t, isCreated)
+
+	response := sendIdempotentCreateAccountRequest(t, "create-account-in-progress", body)
+	assert.Equal(t, http.StatusConflict, response.Code)
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Idempotency-Key request is still in progress", responseDto.Message)
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1CounterpartyXXXXXXXXXXXXXXXUWLpVr"))
+}
+
+func TestImportAccountsRoute_FailIdempotencyKey(t *testing.T) {
+	// The streamed import is not fingerprinted, so the key is rejected before any row is read
+	file := "address,name,rank,status\n1JwSSubhmg6iPtRjtyqhUYYH7bZg3Lfy1T,Idempotency Import,10,Active"
+	request := test.NewAdminRequest(t, "POST", "/account/import", nil, strings.NewReader(file))
+	request.Header.Set("Content-Type", "text/csv")
+	request.Header.Set(middleware.IdempotencyKeyHeader, "import-accounts")
+	response := test.ServeRequest(request)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+	assert.Equal(t, "Idempotency-Key is not supported by the streamed uploads", responseDto.Message)
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1JwSSubhmg6iPtRjtyqhUYYH7bZg3Lfy1T"))
+}
@@ -0,0 +1,243 @@
+package accountTests
+
+import (
+	"bytes"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendImportAccountsRequest(t *testing.T, query url.Values, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	request := test.NewAdminRequest(t, "POST", "/account/import", query, body)
+	request.Header.Set("Content-Type", contentType)
+	return test.ServeRequest(request)
+}
+
+func validationImportAccountsTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		contentType  string
+		body         string
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailUnknownFormat",
+			url.Values{},
+			"application/octet-stream",
+			"address\n",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Format must be one of the next values: csv,ndjson"},
+		},
+		{
+			"FailInvalidOnExisting",
+			url.Values{"onExisting": {"replace"}},
+			"text/csv",
+			"address\n",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "OnExisting must be one of the next values: skip,update"},
+		},
+		{
+			"FailUnknownColumn",
+			url.Values{},
+			"text/csv",
+			"address,balance\n",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Unknown column balance, available columns: chain,network,address,name,rank,memo,status,metadata"},
+		},
+		{
+			"FailEmptyFile",
+			url.Values{"format": {"csv"}},
+			"text/plain",
+			"",
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "File is empty"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestImportAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendImportAccountsRequest(t, tt.query, tt.contentType, strings.NewReader(tt.body))
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseBody := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestImportAccountsRoute_SuccessDryRun(t *testing.T) {
+	newAddress := "1L3RK7a217TvT3n9v6k5MMQXHWAaxU7W24"
+	file := strings.Join([]string{
+		"address,name,rank,memo,status",
+		fmt.Sprintf("%s,Import New,10,New memo,Active", newAddress),
+		fmt.Sprintf("%s,Import Existing,10,,Active", seeds.ACCOUNTS.ACCOUNT_1.Address),
+		"invalid address,Import Invalid,10,,Active",
+		fmt.Sprintf("%s,Import Duplicate,10,,Active", newAddress),
+	}, "\n")
+
+	response := sendImportAccountsRequest(t, url.Values{"dryRun": {"true"}}, "text/csv", strings.NewReader(file))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostImportAccountsResponseDto](t, response)
+
+	assert.Equal(t, true, responseDto.DryRun)
+	assert.Equal(t, accountModuleDto.AccountImportOnExistingSkip, responseDto.OnExisting)
+	assert.Equal(t, 4, responseDto.Total)
+	assert.Equal(t, 1, responseDto.Created)
+	assert.Equal(t, 0, responseDto.Updated)
+	assert.Equal(t, 1, responseDto.Skipped)
+	assert.Equal(t, 2, responseDto.Rejected)
+
+	rowResults := make(map[int]accountModuleDto.PostImportAccountsRowResultDto)
+	for _, row := range responseDto.Rows {
+		rowResults[row.Line] = row
+	}
+	assert.Equal(t, accountModuleDto.AccountImportRowResultCreated, rowResults[2].Result)
+	assert.Equal(t, accountModuleDto.AccountImportRowResultSkipped, rowResults[3].Result)
+	assert.Equal(t, accountModuleDto.AccountImportRowResultRejected, rowResults[4].Result)
+	assert.Equal(t, "Address format is wrong", rowResults[4].Message)
+	assert.Equal(t, accountModuleDto.AccountImportRowResultRejected, rowResults[5].Result)
+	assert.Equal(t, "Duplicate address in file", rowResults[5].Message)
+
+	assert.Equal(t, false, database.IsAddressExists(nil, entities.AccountChainBtc, entities.AccountNetworkMainnet, newAddress), "Dry run must not write")
+	assert.Equal(t, false, responseDto.RowsTruncated)
+
+	// The report lists the first rows only, the counts cover the whole file
+	rowMax := config.AppConfig.ImportReportRowMax
+	defer func() {
+		config.AppConfig.ImportReportRowMax = rowMax
+	}()
+	config.AppConfig.ImportReportRowMax = 2
+	response = sendImportAccountsRequest(t, url.Values{"dryRun": {"true"}}, "text/csv", strings.NewReader(file))
+	assert.Equal(t, http.StatusOK, response.Code)
+	responseDto = test.DecodeResponse[accountModuleDto.PostImportAccountsResponseDto](t, response)
+	assert.Equal(t, 4, responseDto.Total)
+	assert.Equal(t, 2, responseDto.Rejected)
+	assert.Equal(t, 2, len(responseDto.Rows))
+	assert.Equal(t, true, responseDto.RowsTruncated)
+}
+
+func TestImportAccountsRoute_SuccessMultipartCsv(t *testing.T) {
+	newAddress := "18e5z6L9zFthHRxVBnwPoP4hgbbgRU4piG"
+	file := strings.Join([]string{
+		"address,name,rank,memo,status",
+		fmt.Sprintf("%s,Import Multipart,15,Multipart memo,Suspended", newAddress),
+	}, "\n")
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "accounts.csv")
+	assert.Nil(t, err)
+	_, _ = part.Write([]byte(file))
+	assert.Nil(t, writer.Close())
+
+	response := sendImportAccountsRequest(t, url.Values{}, writer.FormDataContentType(), body)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostImportAccountsResponseDto](t, response)
+	assert.Equal(t, 1, responseDto.Total)
+	assert.Equal(t, 1, responseDto.Created)
+
+	accountAfter := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, newAddress)
+	assert.NotNil(t, accountAfter)
+	assert.Equal(t, "Import Multipart", accountAfter.Name)
+	assert.Equal(t, uint8(15), accountAfter.Rank)
+	assert.Equal(t, "Multipart memo", accountAfter.Memo)
+	assert.Equal(t, entities.AccountStatusSuspended, accountAfter.Status)
+}
+
+func TestImportAccountsRoute_SuccessNdjsonUpdate(t *testing.T) {
+	existingAccount, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1P3YNDSmSawUnumBZkYUVwMd97eqzd93pr", entities.AccountStatusActive, "Import Before", 10, "Before memo"))
+	assert.Nil(t, err)
+	newAddress := "1GEzfbr2mfFa9fpic4kmK1ux1Qfdss9g9H"
+	file := strings.Join([]string{
+		fmt.Sprintf(`{"address": "%s", "name": "Import After", "rank": 20, "memo": "Before memo", "status": "Active", "metadata": {"crm": {"id": 7}}}`, existingAccount.Address),
+		"",
+		fmt.Sprintf(`{"address": "%s", "name": "Import Ndjson", "rank": 30, "status": "Active"}`, newAddress),
+		`{"address": `,
+	}, "\n")
+
+	response := sendImportAccountsRequest(t, url.Values{"onExisting": {"update"}}, "application/x-ndjson", strings.NewReader(file))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostImportAccountsResponseDto](t, response)
+
+	assert.Equal(t, false, responseDto.DryRun)
+	assert.Equal(t, 3, responseDto.Total)
+	assert.Equal(t, 1, responseDto.Created)
+	assert.Equal(t, 1, responseDto.Updated)
+	assert.Equal(t, 1, responseDto.Rejected)
+
+	for _, row := range responseDto.Rows {
+		if row.Result == accountModuleDto.AccountImportRowResultUpdated {
+			assert.Equal(t, existingAccount.Address, row.Address)
+			assert.Equal(t, accountModuleDto.AccountFieldChangeDto{From: "Import Before", To: "Import After"}, row.Changes["name"])
+			assert.Equal(t, accountModuleDto.AccountFieldChangeDto{From: "10", To: "20"}, row.Changes["rank"])
+			assert.NotContains(t, row.Changes, "memo")
+			assert.Equal(t, accountModuleDto.AccountFieldChangeDto{From: "", To: `{"crm": {"id": 7}}`}, row.Changes["metadata"])
+		}
+		if row.Result == accountModuleDto.AccountImportRowResultRejected {
+			assert.Equal(t, 4, row.Line)
+			assert.Equal(t, "Row format is wrong", row.Message)
+		}
+	}
+
+	accountAfter := database.GetAccountById(existingAccount.Id)
+	assert.Equal(t, "Import After", accountAfter.Name)
+	assert.Equal(t, uint8(20), accountAfter.Rank)
+	if assert.NotNil(t, accountAfter.Metadata) {
+		assert.JSONEq(t, `{"crm": {"id": 7}}`, *accountAfter.Metadata)
+	}
+	assert.NotNil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, newAddress))
+
+	// The same metadata with another formatting is no change, a row without metadata keeps it
+	file = strings.Join([]string{
+		"address,name,rank,memo,status,metadata",
+		fmt.Sprintf(`%s,Import After,20,Before memo,Active,"{""crm"":{""id"":7}}"`, existingAccount.Address),
+		fmt.Sprintf("%s,Import Ndjson,30,,Active,", newAddress),
+	}, "\n")
+	response = sendImportAccountsRequest(t, url.Values{"onExisting": {"update"}}, "text/csv", strings.NewReader(file))
+	assert.Equal(t, http.StatusOK, response.Code)
+	responseDto = test.DecodeResponse[accountModuleDto.PostImportAccountsResponseDto](t, response)
+	assert.Equal(t, 2, responseDto.Skipped)
+	assert.Equal(t, 0, responseDto.Updated)
+	accountAfter = database.GetAccountById(existingAccount.Id)
+	assert.NotNil(t, accountAfter.Metadata)
+}
+
+func TestImportAccountsRoute_SuccessAborted(t *testing.T) {
+	importedAddress := "1HLoD9E4SDFFPDiYfNYnkBLQ85Y51J3Zb1"
+	// The third line is longer than an NDJSON line may be, the file can not be read past it
+	file := strings.Join([]string{
+		fmt.Sprintf(`{"address": "%s", "name": "Import Aborted", "rank": 40, "status": "Active"}`, importedAddress),
+		`{"address": "invalid"}`,
+		fmt.Sprintf(`{"address": "12cbQLTFMXRnSzktFkuoG3eHoMeFtpTu3S", "memo": "%s"}`, strings.Repeat("x", 1024*1024)),
+		`{"address": "1GEzfbr2mfFa9fpic4kmK1ux1Qfdss9g9H"}`,
+	}, "\n")
+
+	response := sendImportAccountsRequest(t, url.Values{}, "application/x-ndjson", strings.NewReader(file))
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.PostImportAccountsResponseDto](t, response)
+
+	// The rows before the broken line are imported and reported
+	assert.Equal(t, true, responseDto.Aborted)
+	assert.Equal(t, 3, responseDto.AbortedLine)
+	assert.Contains(t, responseDto.AbortedMessage, "Read import file error at line 3")
+	assert.Equal(t, 2, responseDto.Total)
+	assert.Equal(t, 1, responseDto.Created)
+	assert.Equal(t, 1, responseDto.Rejected)
+	assert.NotNil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, importedAddress))
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "12cbQLTFMXRnSzktFkuoG3eHoMeFtpTu3S"))
+}
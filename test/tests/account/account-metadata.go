@@ -0,0 +1,169 @@
+package accountTests
+
+import (
+	"encoding/json"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const metadataErrorMessage = "Metadata must be a JSON object up to 4096 bytes and 5 levels deep"
+
+func decodeAccountMetadata(t *testing.T, metadata json.RawMessage) map[string]interface{} {
+	var values map[string]interface{}
+	assert.Nil(t, json.Unmarshal(metadata, &values))
+	return values
+}
+
+func TestAccountMetadataRoute_Fail(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expectedBody string
+	}{
+		{
+			"FailCreateArray",
+			"POST",
+			"/account",
+			`{"address": "17m9DuJSXC2DamiM9jawBJkx6zkVHmFNSH", "name": "Meta", "rank": 10, "status": "Active", "metadata": ["crm"]}`,
+			metadataErrorMessage,
+		},
+		{
+			"FailCreateTooDeep",
+			"POST",
+			"/account",
+			`{"address": "17m9DuJSXC2DamiM9jawBJkx6zkVHmFNSH", "name": "Meta", "rank": 10, "status": "Active", "metadata": {"a": {"b": {"c": {"d": {"e": {}}}}}}}`,
+			metadataErrorMessage,
+		},
+		{
+			"FailCreateTooLarge",
+			"POST",
+			"/account",
+			fmt.Sprintf(`{"address": "17m9DuJSXC2DamiM9jawBJkx6zkVHmFNSH", "name": "Meta", "rank": 10, "status": "Active", "metadata": {"note": "%s"}}`, strings.Repeat("n", 4096)),
+			metadataErrorMessage,
+		},
+		{
+			"FailUpdateString",
+			"PATCH",
+			"/account/1",
+			`{"metadata": "crm"}`,
+			metadataErrorMessage,
+		},
+		{
+			"FailFilterPathQuote",
+			"GET",
+			"/account?" + url.Values{"meta.crm'id": {"1"}}.Encode(),
+			"",
+			"meta.crm'id path must be up to 5 dot-separated names of letters, digits and underscores",
+		},
+		{
+			"FailFilterPathWildcard",
+			"GET",
+			"/account/export?" + url.Values{"meta.crm.*": {"1"}}.Encode(),
+			"",
+			"meta.crm.* path must be up to 5 dot-separated names of letters, digits and underscores",
+		},
+		{
+			"FailFilterPathEmptySegment",
+			"GET",
+			"/account/stats?" + url.Values{"meta.crm..id": {"1"}}.Encode(),
+			"",
+			"meta.crm..id path must be up to 5 dot-separated names of letters, digits and underscores",
+		},
+		{
+			"FailFilterRepeated",
+			"GET",
+			"/account?" + url.Values{"meta.crm": {"1", "2"}}.Encode(),
+			"",
+			"meta.crm must be set once",
+		},
+		{
+			"FailFilterCount",
+			"GET",
+			"/account?" + url.Values{"meta.a": {"1"}, "meta.b": {"1"}, "meta.c": {"1"}, "meta.d": {"1"}, "meta.e": {"1"}, "meta.f": {"1"}}.Encode(),
+			"",
+			"Meta filters count must be less than or equal 5",
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestAccountMetadataRoute_"+tt.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, tt.method, tt.path, nil, tt.body)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto.Message)
+		})
+	}
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "17m9DuJSXC2DamiM9jawBJkx6zkVHmFNSH"))
+}
+
+func TestAccountMetadataRoute_Success(t *testing.T) {
+	response := test.SendAdminRequest(t, "POST", "/account", nil,
+		`{"address": "17m9DuJSXC2DamiM9jawBJkx6zkVHmFNSH", "name": "Meta One", "rank": 10, "status": "Active", "metadata": {"crm": {"id": "CRM-42"}, "risk": 3, "customerRef": "C-1"}}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, map[string]interface{}{"crm": map[string]interface{}{"id": "CRM-42"}, "risk": float64(3), "customerRef": "C-1"}, decodeAccountMetadata(t, accountDto.Metadata))
+
+	// An account without metadata has null
+	response = test.SendAdminRequest(t, "POST", "/account", nil,
+		`{"address": "13zVK9ZSj832AnMG51UiH2FyKBWjVW62m9", "name": "Meta Two", "rank": 10, "status": "Active"}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	otherAccountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, "null", string(otherAccountDto.Metadata))
+
+	// Nested string, number and top level values are matched by the unquoted JSON value
+	filterTests := []url.Values{
+		{"meta.crm.id": {"CRM-42"}},
+		{"meta.risk": {"3"}},
+		{"meta.risk": {"3"}, "meta.customerRef": {"C-1"}},
+	}
+	for _, query := range filterTests {
+		response = sendGetAccountsRequest(t, query)
+		assert.Equal(t, http.StatusOK, response.Code)
+		responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+		if assert.Equal(t, 1, len(responseDto.List), query.Encode()) {
+			assert.Equal(t, accountDto.Id, responseDto.List[0].Id)
+		}
+	}
+	for _, query := range []url.Values{{"meta.crm.id": {"CRM-43"}}, {"meta.crm": {"CRM-42"}}, {"meta.unknown": {"1"}}} {
+		response = sendGetAccountsRequest(t, query)
+		responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+		assert.Equal(t, 0, len(responseDto.List), query.Encode())
+	}
+
+	// The update merges the patch into the stored object
+	path := fmt.Sprintf("/account/%d", accountDto.Id)
+	response = test.SendAdminRequest(t, "PATCH", path, nil, `{"metadata": {"crm": {"tier": "gold"}, "risk": null}}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	accountDto = test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, map[string]interface{}{"crm": map[string]interface{}{"id": "CRM-42", "tier": "gold"}, "customerRef": "C-1"}, decodeAccountMetadata(t, accountDto.Metadata))
+
+	// The merged object is checked against the limits too, it outgrows the patch
+	response = test.SendAdminRequest(t, "PATCH", path, nil, fmt.Sprintf(`{"metadata": {"note": "%s"}}`, strings.Repeat("n", 4050)))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	errorDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+	assert.Equal(t, metadataErrorMessage, errorDto.Message)
+
+	// Exported as JSON text
+	response = sendExportAccountsRequest(t, url.Values{"meta.crm.tier": {"gold"}, "fields": {"id,metadata"}}, "text/csv")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, fmt.Sprintf("id,metadata\n%d,\"{\"\"crm\"\": {\"\"id\"\": \"\"CRM-42\"\", \"\"tier\"\": \"\"gold\"\"}, \"\"customerRef\"\": \"\"C-1\"\"}\"\n", accountDto.Id), response.Body.String())
+
+	// Null clears the metadata
+	response = test.SendAdminRequest(t, "PATCH", path, nil, `{"metadata": null}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	account := database.GetAccountById(accountDto.Id)
+	assert.Nil(t, account.Metadata)
+}
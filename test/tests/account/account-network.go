@@ -0,0 +1,137 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/src/modules/common/blockchain"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testnetP2wpkhAddress = "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx"
+	testnetP2pkhAddress  = "mibmFRATf4dRNqpWFG9xBjkeWq8cM3fH9n"
+	testnetP2shAddress   = "2Mu77MQuANer59C6NLqbspjLaciCTiSAq9B"
+	regtestP2wpkhAddress = "bcrt1q566sh4qru2js2rt6yvp9edplm3ztan7auvd0ju"
+	networkErrorMessage  = "Network must be one of the next values: mainnet,testnet,signet,regtest"
+)
+
+func sendGetAccountByAddressRequest(t *testing.T, address string, query url.Values) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "GET", fmt.Sprintf("/account/by-address/%s", address), query, nil)
+}
+
+func createNetworkAccount(t *testing.T, network string, address string, status entities.AccountStatus) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"network": "%s", "address": "%s", "name": "Network", "rank": 10, "status": "%s"}`, network, address, status)
+	return test.SendAdminRequest(t, "POST", "/account", nil, body)
+}
+
+func TestAccountNetworkRoute_Fail(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		network      string
+		address      string
+		expectedBody string
+	}{
+		{"FailUnknownNetwork", "testnet3", testnetP2wpkhAddress, networkErrorMessage},
+		{"FailTestnetAddressOnMainnet", "mainnet", testnetP2pkhAddress, "Address format is wrong"},
+		{"FailMainnetAddressOnTestnet", "testnet", seeds.ACCOUNTS.ACCOUNT_4.Address, "Address format is wrong"},
+		{"FailRegtestHrpOnTestnet", "testnet", regtestP2wpkhAddress, "Address format is wrong"},
+		{"FailTestnetHrpOnRegtest", "regtest", testnetP2wpkhAddress, "Address format is wrong"},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestAccountNetworkRoute_"+tt.name, func(t *testing.T) {
+			response := createNetworkAccount(t, tt.network, tt.address, entities.AccountStatusSuspended)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto.Message)
+		})
+	}
+
+	for _, response := range []*httptest.ResponseRecorder{
+		sendGetAccountsRequest(t, url.Values{"network": {"testnet3"}}),
+		sendGetAccountByAddressRequest(t, testnetP2wpkhAddress, url.Values{"network": {"testnet3"}}),
+	} {
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+		assert.Equal(t, networkErrorMessage, responseDto.Message)
+	}
+}
+
+func TestAccountNetworkRoute_Success(t *testing.T) {
+	accountIds := make(map[string]int64)
+	createTests := []struct {
+		network             string
+		address             string
+		expectedAddressType string
+	}{
+		{"testnet", testnetP2wpkhAddress, "p2wpkh"},
+		{"testnet", testnetP2pkhAddress, "p2pkh"},
+		{"testnet", testnetP2shAddress, "p2sh"},
+		// Signet shares the address format with testnet, the address is unique within its network
+		{"signet", testnetP2wpkhAddress, "p2wpkh"},
+		{"regtest", regtestP2wpkhAddress, "p2wpkh"},
+	}
+	for _, tt := range createTests {
+		response := createNetworkAccount(t, tt.network, tt.address, entities.AccountStatusPending)
+		assert.Equal(t, http.StatusOK, response.Code, tt.network+" "+tt.address)
+		accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+		assert.Equal(t, tt.network, accountDto.Network)
+		assert.Equal(t, tt.expectedAddressType, accountDto.AddressType)
+		accountIds[tt.network+":"+tt.address] = accountDto.Id
+	}
+
+	response := createNetworkAccount(t, "testnet", testnetP2wpkhAddress, entities.AccountStatusPending)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	// The by-address lookup validates and reads the address in mainnet unless the network is set
+	response = sendGetAccountByAddressRequest(t, testnetP2wpkhAddress, url.Values{"network": {"signet"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+	accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, accountIds["signet:"+testnetP2wpkhAddress], accountDto.Id)
+	response = sendGetAccountByAddressRequest(t, testnetP2wpkhAddress, nil)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	errorDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+	assert.Equal(t, "Address format is wrong", errorDto.Message)
+
+	filterTests := []struct {
+		network     string
+		expectedIds []int64
+	}{
+		{"testnet", []int64{accountIds["testnet:"+testnetP2wpkhAddress], accountIds["testnet:"+testnetP2pkhAddress], accountIds["testnet:"+testnetP2shAddress]}},
+		{"signet", []int64{accountIds["signet:"+testnetP2wpkhAddress]}},
+		{"regtest", []int64{accountIds["regtest:"+regtestP2wpkhAddress]}},
+	}
+	for _, tt := range filterTests {
+		response = sendGetAccountsRequest(t, url.Values{"network": {tt.network}, "orderBy": {"id ASC"}})
+		assert.Equal(t, http.StatusOK, response.Code)
+		responseDto := test.DecodeResponse[accountModuleDto.GetAccountResponseDto](t, response)
+		ids := make([]int64, 0)
+		for _, listAccountDto := range responseDto.List {
+			ids = append(ids, listAccountDto.Id)
+		}
+		assert.Equal(t, tt.expectedIds, ids, tt.network)
+	}
+
+	// Regtest has no provider URL by default, its accounts are not polled
+	assert.NotContains(t, blockchain.GetChainNetworks(), entities.AccountChainNetwork{Chain: entities.AccountChainBtc, Network: entities.AccountNetworkRegtest})
+	for _, batchAccount := range database.GetAccountsBatch(blockchain.GetChainNetworks(), 1000) {
+		assert.NotEqual(t, entities.AccountNetworkRegtest, batchAccount.Network)
+	}
+
+	// Leave the accounts out of the later polling tests
+	for _, accountId := range accountIds {
+		response = test.SendAdminRequest(t, "POST", fmt.Sprintf("/account/%d/transition", accountId), nil, `{"status": "Suspended", "reason": "Network test"}`)
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+}
@@ -0,0 +1,112 @@
+package accountTests
+
+import (
+	"encoding/json"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validationGetAccountsSearchTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailInvalidSort",
+			url.Values{"sort": {"score"}, "search": {"customer"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Sort must be one of the next values: relevance"},
+		},
+		{
+			"FailRelevanceSortWithoutSearch",
+			url.Values{"sort": {"relevance"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Sort relevance requires Search"},
+		},
+		{
+			"FailRelevanceSortWithCursor",
+			url.Values{"sort": {"relevance"}, "search": {"customer"}, "cursor": {"eyJvIjoiaWQgQVNDIiwidiI6WyI0Il19"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Cursor can not be used together with Sort"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var responseBody errorHelpers.ResponseBadRequestErrorHTTP
+			err := json.Unmarshal(response.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestGetAccountsRoute_SuccessSearch(t *testing.T) {
+	account1 := seeds.ACCOUNTS.ACCOUNT_1
+	account2 := seeds.ACCOUNTS.ACCOUNT_2
+	account4 := seeds.ACCOUNTS.ACCOUNT_4
+
+	testCases := []struct {
+		name        string
+		search      string
+		expectedIds []int64
+	}{
+		{"ByWordPrefix", "cust", []int64{account1.Id, account2.Id, account4.Id}},
+		{"ByAllWords", "premium customer", []int64{account4.Id}},
+		{"ByNameWord", "smith", []int64{account1.Id}},
+		{"ByAddressPrefix", account4.Address[:8], []int64{account4.Id}},
+		{"NotByAddressMiddle", account4.Address[4:12], []int64{}},
+		{"EscapedPercent", "%", []int64{}},
+		{"EscapedUnderscore", "_", []int64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, url.Values{"search": {tc.search}})
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var responseDto accountModuleDto.GetAccountResponseDto
+			err := json.Unmarshal(response.Body.Bytes(), &responseDto)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expectedIds)), responseDto.Total)
+
+			ids := make([]int64, 0)
+			for _, accountDto := range responseDto.List {
+				assert.Nil(t, accountDto.Score)
+				ids = append(ids, accountDto.Id)
+			}
+			assert.Equal(t, tc.expectedIds, ids)
+		})
+	}
+}
+
+func TestGetAccountsRoute_SuccessRelevanceSort(t *testing.T) {
+	response := sendGetAccountsRequest(t, url.Values{"search": {"premium customer"}, "sort": {"relevance"}, "status": {"Active,Suspended"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var responseDto accountModuleDto.GetAccountResponseDto
+	err := json.Unmarshal(response.Body.Bytes(), &responseDto)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), responseDto.Total)
+	assert.Nil(t, responseDto.NextCursor)
+
+	response = sendGetAccountsRequest(t, url.Values{"search": {"customer"}, "sort": {"relevance"}})
+	assert.Equal(t, http.StatusOK, response.Code)
+	responseDto = accountModuleDto.GetAccountResponseDto{}
+	err = json.Unmarshal(response.Body.Bytes(), &responseDto)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), responseDto.Total)
+	for index, accountDto := range responseDto.List {
+		assert.NotNil(t, accountDto.Score)
+		if index > 0 && accountDto.Score != nil && responseDto.List[index-1].Score != nil {
+			assert.GreaterOrEqual(t, *responseDto.List[index-1].Score, *accountDto.Score, "List is not sorted by score")
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package accountTests
+
+import (
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendGetAccountStatsRequest(t *testing.T, query url.Values) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "GET", "/account/stats", query, nil)
+}
+
+func getAccountStats(t *testing.T, query url.Values) accountModuleDto.GetAccountStatsResponseDto {
+	response := sendGetAccountStatsRequest(t, query)
+	assert.Equal(t, http.StatusOK, response.Code)
+	return test.DecodeResponse[accountModuleDto.GetAccountStatsResponseDto](t, response)
+}
+
+func validationGetAccountStatsTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailRankBucketSizeNotNumber",
+			url.Values{"rankBucketSize": {"ten"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "rankBucketSize is invalid"},
+		},
+		{
+			"FailRankBucketSizeMax",
+			url.Values{"rankBucketSize": {"101"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "RankBucketSize must be less than or equal 100"},
+		},
+		{
+			"FailTopMin",
+			url.Values{"top": {"-1"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Top must be greater than or equal 1"},
+		},
+		{
+			"FailStaleAfterMin",
+			url.Values{"staleAfter": {"-60"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "StaleAfter must be greater than or equal 1"},
+		},
+		{
+			"FailStatus",
+			url.Values{"status": {"Active,Maybe"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Status must be one of the next values: Pending,Active,Suspended,Archived,Error"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountStatsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountStatsRequest(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto)
+		})
+	}
+}
+
+// TestGetAccountStatsRoute_Success runs before the account changes, so the stats are the seeded ones
+func TestGetAccountStatsRoute_Success(t *testing.T) {
+	responseDto := getAccountStats(t, url.Values{"top": {"2"}, "staleAfter": {"86400"}})
+
+	assert.Equal(t, int64(4), responseDto.Count)
+	assert.Equal(t, []accountModuleDto.AccountStatusStatsDto{
+		{Status: entities.AccountStatusActive, Chain: entities.AccountChainBtc, Currency: "BTC", Count: 2, Balance: "0.96281062"},
+		{Status: entities.AccountStatusSuspended, Chain: entities.AccountChainBtc, Currency: "BTC", Count: 2, Balance: "0.07134313"},
+	}, responseDto.ByStatus)
+	assert.Equal(t, []accountModuleDto.AccountChainStatsDto{
+		{Chain: entities.AccountChainBtc, Currency: "BTC", Count: 4, Balance: "1.03415375"},
+	}, responseDto.ByChain)
+
+	// Ranks 75, 50, 25 and 90 in buckets of 10
+	assert.Equal(t, accountModuleDto.DEFAULT_ACCOUNT_STATS_RANK_BUCKET_SIZE, responseDto.RankBucketSize)
+	assert.Equal(t, 10, len(responseDto.RankHistogram))
+	expectedCounts := map[int]int64{21: 1, 41: 1, 71: 1, 81: 1}
+	for _, bucket := range responseDto.RankHistogram {
+		assert.Equal(t, bucket.From+9, bucket.To)
+		assert.Equal(t, expectedCounts[bucket.From], bucket.Count)
+	}
+
+	// The accounts are ranked within their chain
+	if assert.Equal(t, 1, len(responseDto.TopByBalance)) {
+		assert.Equal(t, entities.AccountChainBtc, responseDto.TopByBalance[0].Chain)
+		assert.Equal(t, "BTC", responseDto.TopByBalance[0].Currency)
+		topIds := make([]int64, 0)
+		for _, accountDto := range responseDto.TopByBalance[0].Accounts {
+			topIds = append(topIds, accountDto.Id)
+		}
+		assert.Equal(t, []int64{seeds.ACCOUNTS.ACCOUNT_1.Id, seeds.ACCOUNTS.ACCOUNT_4.Id}, topIds)
+	}
+
+	assert.Equal(t, int64(1), responseDto.ZeroBalanceCount)
+	assert.Equal(t, int64(86400), responseDto.Stale.StaleAfter)
+	assert.Equal(t, int64(0), responseDto.Stale.Count)
+}
+
+func TestGetAccountStatsRoute_SuccessFilters(t *testing.T) {
+	responseDto := getAccountStats(t, url.Values{"status": {"Active"}, "rankBucketSize": {"30"}})
+	assert.Equal(t, int64(2), responseDto.Count)
+	if assert.Equal(t, 1, len(responseDto.ByStatus)) {
+		assert.Equal(t, "0.96281062", responseDto.ByStatus[0].Balance)
+	}
+	assert.Equal(t, int64(0), responseDto.ZeroBalanceCount)
+	// The last bucket is cut at the max rank
+	assert.Equal(t, []accountModuleDto.AccountRankBucketDto{
+		{From: 1, To: 30, Count: 0},
+		{From: 31, To: 60, Count: 1},
+		{From: 61, To: 90, Count: 1},
+		{From: 91, To: 100, Count: 0},
+	}, responseDto.RankHistogram)
+
+	responseDto = getAccountStats(t, url.Values{"search": {seeds.ACCOUNTS.ACCOUNT_4.Name}})
+	assert.Equal(t, int64(1), responseDto.Count)
+	if assert.Equal(t, 1, len(responseDto.ByChain)) {
+		assert.Equal(t, seeds.ACCOUNTS.ACCOUNT_4.Balance.String(), responseDto.ByChain[0].Balance)
+	}
+	if assert.Equal(t, 1, len(responseDto.TopByBalance)) && assert.Equal(t, 1, len(responseDto.TopByBalance[0].Accounts)) {
+		assert.Equal(t, seeds.ACCOUNTS.ACCOUNT_4.Id, responseDto.TopByBalance[0].Accounts[0].Id)
+	}
+}
@@ -0,0 +1,159 @@
+package accountTests
+
+import (
+	"encoding/json"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validationGetAccountsTagTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailInvalidTags",
+			url.Values{"tags": {"exchange,Cold Storage"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Tags must be a comma-separated list of tag names"},
+		},
+		{
+			"FailInvalidTagMode",
+			url.Values{"tags": {"exchange"}, "tagMode": {"none"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "TagMode must be one of the next values: any,all"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var responseBody errorHelpers.ResponseBadRequestErrorHTTP
+			err := json.Unmarshal(response.Body.Bytes(), &responseBody)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestGetAccountsRoute_SuccessTags(t *testing.T) {
+	account1 := seeds.ACCOUNTS.ACCOUNT_1
+	account2 := seeds.ACCOUNTS.ACCOUNT_2
+	account4 := seeds.ACCOUNTS.ACCOUNT_4
+
+	testCases := []struct {
+		name        string
+		query       url.Values
+		expectedIds []int64
+	}{
+		{"ByOneTag", url.Values{"tags": {"exchange"}}, []int64{account1.Id, account2.Id}},
+		{"ByAnyTag", url.Values{"tags": {"cold,customer-deposit"}, "tagMode": {"any"}}, []int64{account1.Id, account4.Id}},
+		{"ByAllTags", url.Values{"tags": {"exchange,cold"}, "tagMode": {"all"}}, []int64{account1.Id}},
+		{"ByAllTagsWithDuplicate", url.Values{"tags": {"exchange,exchange"}, "tagMode": {"all"}}, []int64{account1.Id, account2.Id}},
+		{"ByAllTagsNoMatch", url.Values{"tags": {"exchange,customer-deposit"}, "tagMode": {"all"}}, []int64{}},
+		{"ByUnknownTag", url.Values{"tags": {"unknown"}}, []int64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := sendGetAccountsRequest(t, tc.query)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			var responseDto accountModuleDto.GetAccountResponseDto
+			err := json.Unmarshal(response.Body.Bytes(), &responseDto)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.expectedIds)), responseDto.Total)
+
+			ids := make([]int64, 0)
+			for _, accountDto := range responseDto.List {
+				ids = append(ids, accountDto.Id)
+			}
+			assert.Equal(t, tc.expectedIds, ids)
+		})
+	}
+}
+
+func TestGetAccountByIdRoute_SuccessTags(t *testing.T) {
+	account := seeds.ACCOUNTS.ACCOUNT_1
+	response := test.SendAdminRequest(t, "GET", fmt.Sprintf("/account/%d", account.Id), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	// Tags are ordered by name
+	assert.Equal(t, []string{seeds.TAGS.TAG_2.Name, seeds.TAGS.TAG_1.Name}, responseDto.Tags)
+}
+
+func TestAttachAccountTagRoute_Fail(t *testing.T) {
+	testCases := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"InvalidTagId",
+			fmt.Sprintf("/account/%d/tag/%s", seeds.ACCOUNTS.ACCOUNT_3.Id, "cold"),
+			http.StatusBadRequest,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "TagId is invalid"},
+		},
+		{
+			"AccountNotFound",
+			fmt.Sprintf("/account/%d/tag/%d", 999999, seeds.TAGS.TAG_1.Id),
+			http.StatusNotFound,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Account not found"},
+		},
+		{
+			"TagNotFound",
+			fmt.Sprintf("/account/%d/tag/%d", seeds.ACCOUNTS.ACCOUNT_3.Id, 999999),
+			http.StatusNotFound,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Tag not found"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, "PUT", tc.path, nil, nil)
+			assert.Equal(t, tc.expectedCode, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tc.expectedBody, responseDto)
+		})
+	}
+}
+
+func TestAttachAndDetachAccountTagRoute_Success(t *testing.T) {
+	account := seeds.ACCOUNTS.ACCOUNT_3
+	tag := seeds.TAGS.TAG_1
+	path := fmt.Sprintf("/account/%d/tag/%d", account.Id, tag.Id)
+
+	// Attaching twice keeps a single link
+	for attempt := 0; attempt < 2; attempt++ {
+		response := test.SendAdminRequest(t, "PUT", path, nil, nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+		assert.Equal(t, account.Id, responseDto.Id)
+		assert.Equal(t, []string{tag.Name}, responseDto.Tags)
+	}
+
+	response := sendGetAccountsRequest(t, url.Values{"tags": {tag.Name}})
+	var listDto accountModuleDto.GetAccountResponseDto
+	err := json.Unmarshal(response.Body.Bytes(), &listDto)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), listDto.Total)
+
+	response = test.SendAdminRequest(t, "DELETE", path, nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, []string{}, responseDto.Tags)
+}
@@ -0,0 +1,109 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func sendGetAccountTransactionsRequest(t *testing.T, id int64, query url.Values) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "GET", fmt.Sprintf("/account/%d/transactions", id), query, nil)
+}
+
+func getAccountTransactions(t *testing.T, query url.Values) accountModuleDto.GetAccountTransactionsResponseDto {
+	response := sendGetAccountTransactionsRequest(t, seeds.ACCOUNTS.ACCOUNT_1.Id, query)
+	assert.Equal(t, http.StatusOK, response.Code)
+	return test.DecodeResponse[accountModuleDto.GetAccountTransactionsResponseDto](t, response)
+}
+
+func getAccountTransactionTxids(responseDto accountModuleDto.GetAccountTransactionsResponseDto) []string {
+	txids := make([]string, 0)
+	for _, item := range responseDto.List {
+		txids = append(txids, item.Txid)
+	}
+	return txids
+}
+
+func TestGetAccountTransactionsRoute_Fail(t *testing.T) {
+	validationTests := []struct {
+		name            string
+		query           url.Values
+		expectedMessage string
+	}{
+		{"FailDirection", url.Values{"direction": {"both"}}, "Direction must be one of the next values: in,out"},
+		{"FailCountMax", url.Values{"count": {"1001"}}, "Count must be less than or equal 1000"},
+		{"FailCountNotNumber", url.Values{"count": {"many"}}, "count is invalid"},
+		{"FailCursor", url.Values{"cursor": {"not a cursor"}}, "Cursor is invalid"},
+	}
+	for _, tt := range validationTests {
+		t.Run("TestGetAccountTransactionsRoute_"+tt.name, func(t *testing.T) {
+			response := sendGetAccountTransactionsRequest(t, seeds.ACCOUNTS.ACCOUNT_1.Id, tt.query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedMessage, responseDto.Message)
+		})
+	}
+
+	response := sendGetAccountTransactionsRequest(t, 999999, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestGetAccountTransactionsRoute_Success(t *testing.T) {
+	blockHeight := int64(170)
+	transactionTime := int64(1231731025)
+	// Two transactions share the time, the later inserted one goes first
+	transactions := []*entities.Transaction{
+		entities.CreateTransaction(seeds.ACCOUNTS.ACCOUNT_1.Id, "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16", &blockHeight, transactionTime, decimal.RequireFromString("1"), decimal.Zero, 6),
+		entities.CreateTransaction(seeds.ACCOUNTS.ACCOUNT_1.Id, "a1075db55d416d3ca199f55b6084e2115b9345e16c5cf302fc80e9d5fbf5d48d", &blockHeight, transactionTime, decimal.RequireFromString("-0.6"), decimal.RequireFromString("0.00001"), 6),
+		entities.CreateTransaction(seeds.ACCOUNTS.ACCOUNT_1.Id, "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9", nil, transactionTime+3600, decimal.RequireFromString("0.00005"), decimal.RequireFromString("0.000003"), 0),
+	}
+	err := database.CreateTransactions(nil, transactions)
+	assert.Nil(t, err)
+
+	expectedPages := [][]string{
+		{transactions[2].Txid, transactions[1].Txid},
+		{transactions[0].Txid},
+	}
+	query := url.Values{"count": {"2"}}
+	for index, expectedTxids := range expectedPages {
+		responseDto := getAccountTransactions(t, query)
+		assert.Equal(t, expectedTxids, getAccountTransactionTxids(responseDto))
+		if index == len(expectedPages)-1 {
+			assert.Nil(t, responseDto.NextCursor)
+			break
+		}
+		if !assert.NotNil(t, responseDto.NextCursor) {
+			return
+		}
+		query.Set("cursor", *responseDto.NextCursor)
+	}
+
+	// The mempool transaction has no block
+	responseDto := getAccountTransactions(t, url.Values{"count": {"1"}})
+	if assert.Equal(t, 1, len(responseDto.List)) {
+		assert.Nil(t, responseDto.List[0].BlockHeight)
+		assert.Equal(t, uint(0), responseDto.List[0].Confirmations)
+		assert.Equal(t, "0.000003", responseDto.List[0].Fee)
+		assert.Equal(t, "in", responseDto.List[0].Direction)
+	}
+
+	responseDto = getAccountTransactions(t, url.Values{"direction": {"out"}})
+	assert.Equal(t, []string{transactions[1].Txid}, getAccountTransactionTxids(responseDto))
+	assert.Equal(t, "-0.6", responseDto.List[0].Amount)
+	assert.Equal(t, "out", responseDto.List[0].Direction)
+
+	responseDto = getAccountTransactions(t, url.Values{"direction": {"in"}})
+	assert.Equal(t, []string{transactions[2].Txid, transactions[0].Txid}, getAccountTransactionTxids(responseDto))
+}
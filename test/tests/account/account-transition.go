@@ -0,0 +1,167 @@
+package accountTests
+
+import (
+	"encoding/json"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	middleware "go-gin-test-job/src/middlewares"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sendTransitionAccountRequest(t *testing.T, id int64, body string) *httptest.ResponseRecorder {
+	return sendIdempotentTransitionAccountRequest(t, id, "", body)
+}
+
+func sendIdempotentTransitionAccountRequest(t *testing.T, id int64, idempotencyKey string, body string) *httptest.ResponseRecorder {
+	request := test.NewAdminRequest(t, "POST", fmt.Sprintf("/account/%d/transition", id), nil, body)
+	if idempotencyKey != "" {
+		request.Header.Set(middleware.IdempotencyKeyHeader, idempotencyKey)
+	}
+	return test.ServeRequest(request)
+}
+
+func sendUpdateAccountStatusRequest(t *testing.T, id int64, status entities.AccountStatus) *httptest.ResponseRecorder {
+	return test.SendAdminRequest(t, "PATCH", fmt.Sprintf("/account/%d", id), nil, fmt.Sprintf(`{"status": "%s"}`, status))
+}
+
+func assertAccountConflict(t *testing.T, response *httptest.ResponseRecorder, message string) {
+	assert.Equal(t, http.StatusConflict, response.Code)
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, message, responseDto.Message)
+}
+
+func TestTransitionAccountRoute_Fail(t *testing.T) {
+	account, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1B6W1i6bbfRht2zuUrwEbxEr2YoxfrQnJu", entities.AccountStatusPending, "Transition Fail", 10, ""))
+	assert.Nil(t, err)
+
+	validationTests := []struct {
+		name         string
+		id           int64
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			"FailInvalidStatus",
+			account.Id,
+			`{"status": "On", "reason": "Legacy status"}`,
+			http.StatusBadRequest,
+			fmt.Sprintf("Status must be one of the next values: %s", strings.Join(entities.AccountStatusList, ",")),
+		},
+		{
+			"FailEmptyReason",
+			account.Id,
+			`{"status": "Active", "reason": "  "}`,
+			http.StatusBadRequest,
+			"Reason must not be empty",
+		},
+		{
+			"FailReasonTooLong",
+			account.Id,
+			fmt.Sprintf(`{"status": "Active", "reason": "%s"}`, strings.Repeat("r", 256)),
+			http.StatusBadRequest,
+			"Reason must be shorter than or equal to 255 characters",
+		},
+		{
+			"FailNotFound",
+			999999,
+			`{"status": "Active", "reason": "Checked"}`,
+			http.StatusNotFound,
+			"Account not found",
+		},
+		{
+			"FailSameStatus",
+			account.Id,
+			`{"status": "Pending", "reason": "Checked"}`,
+			http.StatusConflict,
+			"Account status is already Pending",
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestTransitionAccountRoute_"+tt.name, func(t *testing.T) {
+			response := sendTransitionAccountRequest(t, tt.id, tt.body)
+			assert.Equal(t, tt.expectedCode, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto.Message)
+		})
+	}
+}
+
+func TestTransitionAccountRoute_Success(t *testing.T) {
+	account := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1B6W1i6bbfRht2zuUrwEbxEr2YoxfrQnJu")
+	if !assert.NotNil(t, account) {
+		return
+	}
+	assert.Equal(t, entities.AccountStatusPending, account.Status)
+
+	response := sendTransitionAccountRequest(t, account.Id, `{"status": "Suspended", "reason": " Owner request "}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+	assert.Equal(t, string(entities.AccountStatusSuspended), responseDto.Status)
+	assert.Equal(t, "Owner request", responseDto.StatusReason)
+
+	// A suspended account is not polled by the cron
+	for _, batchAccount := range database.GetAccountsBatch([]entities.AccountChainNetwork{{Chain: entities.AccountChainBtc, Network: entities.AccountNetworkMainnet}}, 1000) {
+		assert.True(t, batchAccount.Status.IsPolling())
+		assert.NotEqual(t, account.Id, batchAccount.Id)
+	}
+
+	// The lifecycle rejects the illegal moves of both endpoints
+	assertAccountConflict(t, sendTransitionAccountRequest(t, account.Id, `{"status": "Pending", "reason": "Check again"}`),
+		"Account status can not change from Suspended to Pending")
+	assertAccountConflict(t, sendUpdateAccountStatusRequest(t, account.Id, entities.AccountStatusError),
+		"Account status can not change from Suspended to Error")
+
+	// The transition is audited with the reason
+	logs := database.GetAuditLogs(database.AuditLogFilter{AccountId: &account.Id, Action: entities.AuditActionTransition}, nil, 10)
+	if assert.Equal(t, 1, len(logs)) {
+		var before, after map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(*logs[0].Before), &before))
+		assert.Nil(t, json.Unmarshal([]byte(*logs[0].After), &after))
+		assert.Equal(t, map[string]interface{}{"status": "Pending", "status_reason": ""}, before)
+		assert.Equal(t, map[string]interface{}{"status": "Suspended", "status_reason": "Owner request"}, after)
+	}
+
+	// The update moves through the lifecycle too and clears the reason
+	response = sendUpdateAccountStatusRequest(t, account.Id, entities.AccountStatusActive)
+	assert.Equal(t, http.StatusOK, response.Code)
+	account = database.GetAccountById(account.Id)
+	assert.Equal(t, entities.AccountStatusActive, account.Status)
+	assert.Equal(t, "", account.StatusReason)
+
+	response = sendTransitionAccountRequest(t, account.Id, `{"status": "Archived", "reason": "Closed wallet"}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	account = database.GetAccountById(account.Id)
+	assert.Equal(t, entities.AccountStatusArchived, account.Status)
+	assert.Equal(t, "Closed wallet", account.StatusReason)
+}
+
+func TestTransitionAccountRoute_SuccessIdempotencyKey(t *testing.T) {
+	account, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", entities.AccountStatusActive, "Transition Idempotency", 10, ""))
+	assert.Nil(t, err)
+
+	body := `{"status": "Suspended", "reason": "Owner request"}`
+	response := sendIdempotentTransitionAccountRequest(t, account.Id, "transition-account-replay", body)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "", response.Header().Get(middleware.IdempotentReplayedHeader))
+
+	// The retry gets the stored response instead of the Suspended to Suspended conflict
+	replayResponse := sendIdempotentTransitionAccountRequest(t, account.Id, "transition-account-replay", body)
+	assert.Equal(t, http.StatusOK, replayResponse.Code)
+	assert.Equal(t, "true", replayResponse.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, response.Body.String(), replayResponse.Body.String())
+
+	logs := database.GetAuditLogs(database.AuditLogFilter{AccountId: &account.Id, Action: entities.AuditActionTransition}, nil, 10)
+	assert.Equal(t, 1, len(logs))
+}
@@ -0,0 +1,129 @@
+package accountTests
+
+import (
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validationUpdateAccountTests(t *testing.T) {
+	accountInfo := seeds.ACCOUNTS.ACCOUNT_4
+	validationTests := []struct {
+		name         string
+		body         string
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailInvalidBody",
+			`not json`,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Invalid request query"},
+		},
+		{
+			"FailNullName",
+			`{"name": null}`,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "name must not be null"},
+		},
+		{
+			"FailEmptyName",
+			`{"name": ""}`,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Name must be between 1 and 255 characters"},
+		},
+		{
+			"FailRankTooHigh",
+			`{"rank": 101}`,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Rank must be between 0 and 100"},
+		},
+		{
+			"FailInvalidStatus",
+			`{"status": "invalid status"}`,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: fmt.Sprintf("%s must be one of the next values: %s", "Status", strings.Join(entities.AccountStatusList, ","))},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestUpdateAccountRoute_"+tt.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, "PATCH", fmt.Sprintf("/account/%d", accountInfo.Id), nil, tt.body)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseBody := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseBody)
+		})
+	}
+}
+
+func TestUpdateAccountRoute_FailNotFound(t *testing.T) {
+	response := test.SendAdminRequest(t, "PATCH", fmt.Sprintf("/account/%d", 999999), nil, `{"name": "New Name"}`)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Account not found", responseDto.Message)
+}
+
+func TestUpdateAccountRoute_FailStaleETag(t *testing.T) {
+	accountBefore := database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_4.Id)
+	assert.NotNil(t, accountBefore)
+
+	request := test.NewAdminRequest(t, "PATCH", fmt.Sprintf("/account/%d", accountBefore.Id), nil, `{"name": "Stale Name"}`)
+	request.Header.Set("If-Match", fmt.Sprintf("\"%d-%d\"", accountBefore.Id, accountBefore.Version-1))
+	response := test.ServeRequest(request)
+	assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponsePreconditionFailedErrorHTTP](t, response)
+	assert.Equal(t, false, responseDto.Success)
+	assert.Equal(t, "Account has been modified", responseDto.Message)
+
+	accountAfter := database.GetAccountById(accountBefore.Id)
+	assert.Equal(t, accountBefore.Name, accountAfter.Name, "Name should not be changed")
+}
+
+func TestUpdateAccountRoute_Success(t *testing.T) {
+	accountBefore := database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_4.Id)
+	assert.NotNil(t, accountBefore)
+	assert.NotEqual(t, "", accountBefore.Memo)
+
+	path := fmt.Sprintf("/account/%d", accountBefore.Id)
+
+	// Read the current version first
+	response := test.SendAdminRequest(t, "GET", path, nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	assert.Equal(t, accountModuleDto.CreateAccountETag(accountBefore), etag)
+
+	request := test.NewAdminRequest(t, "PATCH", path, nil, `{"name": "David Wilson Jr", "memo": null}`)
+	request.Header.Set("Content-Type", "application/merge-patch+json")
+	request.Header.Set("If-Match", etag)
+	response = test.ServeRequest(request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+
+	accountAfter := database.GetAccountById(accountBefore.Id)
+	assert.NotNil(t, accountAfter)
+	assert.Equal(t, "David Wilson Jr", accountAfter.Name)
+	assert.Equal(t, "", accountAfter.Memo, "Memo should be cleared")
+	assert.Equal(t, accountBefore.Rank, accountAfter.Rank, "Rank should not be changed")
+	assert.Equal(t, accountBefore.Status, accountAfter.Status, "Status should not be changed")
+	assert.Equal(t, accountBefore.Balance.String(), accountAfter.Balance.String(), "Balance should not be changed")
+	assert.GreaterOrEqual(t, accountAfter.UpdatedAt, accountBefore.UpdatedAt)
+	assert.Equal(t, accountModuleDto.CreateAccountETag(accountAfter), response.Header().Get("ETag"))
+	assert.NotEqual(t, etag, response.Header().Get("ETag"))
+
+	test.CompareAccount(t, accountAfter, responseDto)
+
+	// The write moved the version, so the read ETag is stale even within the same second
+	request = test.NewAdminRequest(t, "PATCH", path, nil, `{"name": "Stale Name"}`)
+	request.Header.Set("Content-Type", "application/merge-patch+json")
+	request.Header.Set("If-Match", etag)
+	response = test.ServeRequest(request)
+	assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+	assert.Equal(t, "David Wilson Jr", database.GetAccountById(accountBefore.Id).Name)
+}
@@ -35,10 +35,106 @@ func TestAccountRoute(t *testing.T) {
 	t.Run("TestGetAccountsRoute_SuccessParamsOffsetAndCountAndStatusAndOrderBy", TestGetAccountsRoute_SuccessParamsOffsetAndCountAndStatusAndOrderBy)
 	t.Run("TestGetAccountsRoute_SuccessParamsSearch", TestGetAccountsRoute_SuccessParamsSearch)
 	t.Run("TestGetAccountsRoute_SuccessParamsSearchAndStatus", TestGetAccountsRoute_SuccessParamsSearchAndStatus)
+	validationGetAccountsCursorTests(t)
+	t.Run("TestGetAccountsRoute_SuccessOrderByPrecedence", TestGetAccountsRoute_SuccessOrderByPrecedence)
+	t.Run("TestGetAccountsRoute_SuccessCursorPagination", TestGetAccountsRoute_SuccessCursorPagination)
+	validationGetAccountsFilterTests(t)
+	t.Run("TestGetAccountsRoute_SuccessFilters", TestGetAccountsRoute_SuccessFilters)
+	validationGetAccountsSearchTests(t)
+	t.Run("TestGetAccountsRoute_SuccessSearch", TestGetAccountsRoute_SuccessSearch)
+	t.Run("TestGetAccountsRoute_SuccessRelevanceSort", TestGetAccountsRoute_SuccessRelevanceSort)
+	validationGetAccountsTagTests(t)
+	t.Run("TestGetAccountsRoute_SuccessTags", TestGetAccountsRoute_SuccessTags)
+	validationGetAccountsFieldsTests(t)
+	t.Run("TestGetAccountsRoute_SuccessFields", TestGetAccountsRoute_SuccessFields)
+	// GetAccountById
+	t.Run("TestGetAccountByIdRoute_FailInvalidId", TestGetAccountByIdRoute_FailInvalidId)
+	t.Run("TestGetAccountByIdRoute_FailNotFound", TestGetAccountByIdRoute_FailNotFound)
+	t.Run("TestGetAccountByIdRoute_Success", TestGetAccountByIdRoute_Success)
+	t.Run("TestGetAccountByIdRoute_SuccessFields", TestGetAccountByIdRoute_SuccessFields)
+	// GetAccountByAddress
+	t.Run("TestGetAccountByAddressRoute_FailInvalidAddress", TestGetAccountByAddressRoute_FailInvalidAddress)
+	t.Run("TestGetAccountByAddressRoute_FailNotFound", TestGetAccountByAddressRoute_FailNotFound)
+	t.Run("TestGetAccountByAddressRoute_Success", TestGetAccountByAddressRoute_Success)
+	t.Run("TestGetAccountByAddressRoute_SuccessFields", TestGetAccountByAddressRoute_SuccessFields)
+	// ExportAccounts
+	validationExportAccountsTests(t)
+	t.Run("TestExportAccountsRoute_SuccessCsv", TestExportAccountsRoute_SuccessCsv)
+	t.Run("TestExportAccountsRoute_SuccessTsv", TestExportAccountsRoute_SuccessTsv)
+	t.Run("TestExportAccountsRoute_SuccessNdjsonByAccept", TestExportAccountsRoute_SuccessNdjsonByAccept)
+	// GetAccountStats
+	validationGetAccountStatsTests(t)
+	t.Run("TestGetAccountStatsRoute_Success", TestGetAccountStatsRoute_Success)
+	t.Run("TestGetAccountStatsRoute_SuccessFilters", TestGetAccountStatsRoute_SuccessFilters)
+	// GetAccountBalanceHistory
+	validationGetAccountBalanceHistoryTests(t)
+	t.Run("TestGetAccountBalanceHistoryRoute_FailNotFound", TestGetAccountBalanceHistoryRoute_FailNotFound)
+	t.Run("TestGetAccountBalanceHistoryRoute_SuccessCursorPagination", TestGetAccountBalanceHistoryRoute_SuccessCursorPagination)
+	t.Run("TestGetAccountBalanceHistoryRoute_SuccessRange", TestGetAccountBalanceHistoryRoute_SuccessRange)
+	t.Run("TestGetAccountBalanceHistoryRoute_SuccessBuckets", TestGetAccountBalanceHistoryRoute_SuccessBuckets)
+	// GetAccountTransactions
+	t.Run("TestGetAccountTransactionsRoute_Fail", TestGetAccountTransactionsRoute_Fail)
+	t.Run("TestGetAccountTransactionsRoute_Success", TestGetAccountTransactionsRoute_Success)
+	// UpdateAccount
+	validationUpdateAccountTests(t)
+	t.Run("TestUpdateAccountRoute_FailNotFound", TestUpdateAccountRoute_FailNotFound)
+	t.Run("TestUpdateAccountRoute_FailStaleETag", TestUpdateAccountRoute_FailStaleETag)
+	t.Run("TestUpdateAccountRoute_Success", TestUpdateAccountRoute_Success)
 	// CreateAccount
 	validationCreateAccountTests(t)
 	t.Run("TestCreateAccountRoute_FailAddressAlreadyExists", TestCreateAccountRoute_FailAddressAlreadyExists)
 	t.Run("TestCreateAccountRoute_Success", TestCreateAccountRoute_Success)
+	t.Run("TestCreateAccountRoute_SuccessRestoresDeleted", TestCreateAccountRoute_SuccessRestoresDeleted)
+	t.Run("TestCreateAccountRoute_FailIdempotencyKeyTooLong", TestCreateAccountRoute_FailIdempotencyKeyTooLong)
+	t.Run("TestCreateAccountRoute_SuccessIdempotencyKeyReplay", TestCreateAccountRoute_SuccessIdempotencyKeyReplay)
+	t.Run("TestCreateAccountRoute_FailIdempotencyKeyOtherRequest", TestCreateAccountRoute_FailIdempotencyKeyOtherRequest)
+	t.Run("TestCreateAccountRoute_SuccessIdempotencyKeyExpired", TestCreateAccountRoute_SuccessIdempotencyKeyExpired)
+	t.Run("TestCreateAccountRoute_SuccessIdempotencyKeyConcurrent", TestCreateAccountRoute_SuccessIdempotencyKeyConcurrent)
+	t.Run("TestCreateAccountRoute_FailIdempotencyKeyInProgress", TestCreateAccountRoute_FailIdempotencyKeyInProgress)
+	t.Run("TestImportAccountsRoute_FailIdempotencyKey", TestImportAccountsRoute_FailIdempotencyKey)
+	// CreateAccountsBulk
+	validationCreateAccountsBulkTests(t)
+	t.Run("TestCreateAccountsBulkRoute_AtomicRollback", TestCreateAccountsBulkRoute_AtomicRollback)
+	t.Run("TestCreateAccountsBulkRoute_SuccessAtomic", TestCreateAccountsBulkRoute_SuccessAtomic)
+	t.Run("TestCreateAccountsBulkRoute_SuccessBestEffort", TestCreateAccountsBulkRoute_SuccessBestEffort)
+	// ImportAccounts
+	validationImportAccountsTests(t)
+	t.Run("TestImportAccountsRoute_SuccessDryRun", TestImportAccountsRoute_SuccessDryRun)
+	t.Run("TestImportAccountsRoute_SuccessMultipartCsv", TestImportAccountsRoute_SuccessMultipartCsv)
+	t.Run("TestImportAccountsRoute_SuccessNdjsonUpdate", TestImportAccountsRoute_SuccessNdjsonUpdate)
+	t.Run("TestImportAccountsRoute_SuccessAborted", TestImportAccountsRoute_SuccessAborted)
+	// DeleteAccount
+	t.Run("TestDeleteAccountRoute_FailNotFound", TestDeleteAccountRoute_FailNotFound)
+	t.Run("TestDeleteAccountRoute_Success", TestDeleteAccountRoute_Success)
+	// RestoreAccount
+	t.Run("TestRestoreAccountRoute_FailNotDeleted", TestRestoreAccountRoute_FailNotDeleted)
+	t.Run("TestRestoreAccountRoute_Success", TestRestoreAccountRoute_Success)
+	// AccountMetadata
+	t.Run("TestAccountMetadataRoute_Fail", TestAccountMetadataRoute_Fail)
+	t.Run("TestAccountMetadataRoute_Success", TestAccountMetadataRoute_Success)
+	// AccountAddressType
+	t.Run("TestAccountAddressTypeRoute_Fail", TestAccountAddressTypeRoute_Fail)
+	t.Run("TestAccountAddressTypeRoute_Success", TestAccountAddressTypeRoute_Success)
+	// AccountNetwork
+	t.Run("TestAccountNetworkRoute_Fail", TestAccountNetworkRoute_Fail)
+	t.Run("TestAccountNetworkRoute_Success", TestAccountNetworkRoute_Success)
+	// AccountChain
+	t.Run("TestAccountChainRoute_Fail", TestAccountChainRoute_Fail)
+	t.Run("TestAccountChainRoute_Success", TestAccountChainRoute_Success)
+	// AccountBalanceKind
+	t.Run("TestAccountBalanceKindRoute_Fail", TestAccountBalanceKindRoute_Fail)
+	t.Run("TestAccountBalanceKindRoute_Success", TestAccountBalanceKindRoute_Success)
+	// TransitionAccount
+	t.Run("TestTransitionAccountRoute_Fail", TestTransitionAccountRoute_Fail)
+	t.Run("TestTransitionAccountRoute_Success", TestTransitionAccountRoute_Success)
+	t.Run("TestTransitionAccountRoute_SuccessIdempotencyKey", TestTransitionAccountRoute_SuccessIdempotencyKey)
+	// PurgeAccount
+	t.Run("TestPurgeAccountRoute_FailNotDeleted", TestPurgeAccountRoute_FailNotDeleted)
+	t.Run("TestPurgeAccountRoute_Success", TestPurgeAccountRoute_Success)
+	// AccountTags
+	t.Run("TestGetAccountByIdRoute_SuccessTags", TestGetAccountByIdRoute_SuccessTags)
+	t.Run("TestAttachAccountTagRoute_Fail", TestAttachAccountTagRoute_Fail)
+	t.Run("TestAttachAndDetachAccountTagRoute_Success", TestAttachAndDetachAccountTagRoute_Success)
 }
 
 func validationGetAccountsTests(t *testing.T) {
@@ -68,7 +164,7 @@ func validationGetAccountsTests(t *testing.T) {
 		},
 		{
 			"FailInvalidStatus",
-			accountModuleDto.GetAccountRequestDto{Status: "invalid status"},
+			accountModuleDto.GetAccountRequestDto{AccountFilterRequestDto: accountModuleDto.AccountFilterRequestDto{Status: "invalid status"}},
 			http.StatusBadRequest,
 			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: fmt.Sprintf("%s must be one of the next values: %s", "Status", strings.Join(entities.AccountStatusList, ","))},
 		},
@@ -96,7 +192,7 @@ func validationGetAccountsTests(t *testing.T) {
 			params := &Params{
 				Count:   validationTest.params.Count,
 				Offset:  validationTest.params.Offset,
-				Status:  validationTest.params.Status,
+				Status:  entities.AccountStatus(validationTest.params.Status),
 				OrderBy: validationTest.params.OrderBy,
 			}
 
@@ -136,7 +232,7 @@ func TestGetAccountsRoute_SuccessNoParams(t *testing.T) {
 		Path: fmt.Sprintf("/account"),
 	}
 
-	accounts, total := database.GetAccountsAndTotal("", make(map[string]string), accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT, "")
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{}, []orderUtil.OrderParam{{Field: "id", Direction: "ASC"}}, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -189,7 +285,7 @@ func TestGetAccountsRoute_SuccessParamsOffsetAndCount(t *testing.T) {
 		RawQuery: query.Encode(),
 	}
 
-	accounts, total := database.GetAccountsAndTotal("", make(map[string]string), params.Offset, params.Count, "")
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{}, []orderUtil.OrderParam{{Field: "id", Direction: "ASC"}}, params.Offset, params.Count)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -228,7 +324,7 @@ func TestGetAccountsRoute_SuccessParamsStatus(t *testing.T) {
 		Status entities.AccountStatus `json:"status"`
 	}
 	params := &Params{
-		Status: entities.AccountStatusOn,
+		Status: entities.AccountStatusActive,
 	}
 
 	query := url.Values{}
@@ -239,7 +335,7 @@ func TestGetAccountsRoute_SuccessParamsStatus(t *testing.T) {
 		RawQuery: query.Encode(),
 	}
 
-	accounts, total := database.GetAccountsAndTotal(params.Status, make(map[string]string), accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT, "")
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{Statuses: []entities.AccountStatus{params.Status}}, []orderUtil.OrderParam{{Field: "id", Direction: "ASC"}}, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -317,8 +413,8 @@ func TestGetAccountsRoute_SuccessParamsOrderBy(t *testing.T) {
 				RawQuery: query.Encode(),
 			}
 
-			orderParams, err := orderUtil.GetOrderByParamsSecure(nil, params.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList)
-			accounts, total := database.GetAccountsAndTotal("", orderParams, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT, "")
+			orderParams, err := orderUtil.GetOrderByParamsSecure(nil, params.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+			accounts, total := database.GetAccountsAndTotal(database.AccountFilter{}, orderParams, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
 
 			response := httptest.NewRecorder()
 			request := httptest.NewRequest("GET", u.String(), nil)
@@ -362,7 +458,7 @@ func TestGetAccountsRoute_SuccessParamsStatusAndOrderBy(t *testing.T) {
 		OrderBy string                 `json:"orderBy"`
 	}
 	params := &Params{
-		Status:  entities.AccountStatusOff,
+		Status:  entities.AccountStatusSuspended,
 		OrderBy: "updated_at DESC",
 	}
 
@@ -375,8 +471,8 @@ func TestGetAccountsRoute_SuccessParamsStatusAndOrderBy(t *testing.T) {
 		RawQuery: query.Encode(),
 	}
 
-	orderParams, err := orderUtil.GetOrderByParamsSecure(nil, params.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList)
-	accounts, total := database.GetAccountsAndTotal(params.Status, orderParams, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT, "")
+	orderParams, err := orderUtil.GetOrderByParamsSecure(nil, params.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{Statuses: []entities.AccountStatus{params.Status}}, orderParams, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -422,7 +518,7 @@ func TestGetAccountsRoute_SuccessParamsOffsetAndCountAndStatusAndOrderBy(t *test
 	params := &Params{
 		Count:   2,
 		Offset:  0,
-		Status:  entities.AccountStatusOff,
+		Status:  entities.AccountStatusSuspended,
 		OrderBy: "updated_at ASC",
 	}
 
@@ -437,8 +533,8 @@ func TestGetAccountsRoute_SuccessParamsOffsetAndCountAndStatusAndOrderBy(t *test
 		RawQuery: query.Encode(),
 	}
 
-	orderParams, err := orderUtil.GetOrderByParamsSecure(nil, params.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList)
-	accounts, total := database.GetAccountsAndTotal(params.Status, orderParams, params.Offset, params.Count, "")
+	orderParams, err := orderUtil.GetOrderByParamsSecure(nil, params.OrderBy, ",", accountModuleDto.GetAvailableAccountSortFieldList, "id")
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{Statuses: []entities.AccountStatus{params.Status}}, orderParams, params.Offset, params.Count)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -490,7 +586,7 @@ func TestGetAccountsRoute_SuccessParamsSearch(t *testing.T) {
 		RawQuery: query.Encode(),
 	}
 
-	accounts, total := database.GetAccountsAndTotal("", make(map[string]string), accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT, params.Search)
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{Search: params.Search}, []orderUtil.OrderParam{{Field: "id", Direction: "ASC"}}, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -540,7 +636,7 @@ func TestGetAccountsRoute_SuccessParamsSearchAndStatus(t *testing.T) {
 	}
 	params := &Params{
 		Search: "customer",
-		Status: entities.AccountStatusOn,
+		Status: entities.AccountStatusActive,
 	}
 
 	query := url.Values{}
@@ -552,7 +648,7 @@ func TestGetAccountsRoute_SuccessParamsSearchAndStatus(t *testing.T) {
 		RawQuery: query.Encode(),
 	}
 
-	accounts, total := database.GetAccountsAndTotal(params.Status, make(map[string]string), accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT, params.Search)
+	accounts, total := database.GetAccountsAndTotal(database.AccountFilter{Statuses: []entities.AccountStatus{params.Status}, Search: params.Search}, []orderUtil.OrderParam{{Field: "id", Direction: "ASC"}}, accountModuleDto.DEFAULT_ACCOUNT_OFFSET, accountModuleDto.DEFAULT_ACCOUNT_COUNT)
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("GET", u.String(), nil)
@@ -596,6 +692,141 @@ func TestGetAccountsRoute_SuccessParamsSearchAndStatus(t *testing.T) {
 	}
 }
 
+func TestGetAccountByIdRoute_FailInvalidId(t *testing.T) {
+	testCases := []struct {
+		name            string
+		id              string
+		expectedMessage string
+	}{
+		{"NotNumber", "abc", "Id is invalid"},
+		{"Zero", "0", "Id must be greater than or equal 1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &url.URL{
+				Path: fmt.Sprintf("/account/%s", tc.id),
+			}
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", u.String(), nil)
+			request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+			test.TestApp.ServeHTTP(response, request)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			var responseDto errorHelpers.ResponseBadRequestErrorHTTP
+			err := json.NewDecoder(response.Body).Decode(&responseDto)
+			assert.Nil(t, err)
+			assert.Equal(t, false, responseDto.Success)
+			assert.Equal(t, tc.expectedMessage, responseDto.Message)
+		})
+	}
+}
+
+func TestGetAccountByIdRoute_FailNotFound(t *testing.T) {
+	u := &url.URL{
+		Path: fmt.Sprintf("/account/%d", 999999),
+	}
+
+	assert.Nil(t, database.GetAccountById(999999), "Account must not exists")
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", u.String(), nil)
+	request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	var responseDto errorHelpers.ResponseNotFoundErrorHTTP
+	err := json.NewDecoder(response.Body).Decode(&responseDto)
+	assert.Nil(t, err)
+	assert.Equal(t, false, responseDto.Success)
+	assert.Equal(t, "Account not found", responseDto.Message)
+}
+
+func TestGetAccountByIdRoute_Success(t *testing.T) {
+	accountInfo := seeds.ACCOUNTS.ACCOUNT_2
+	u := &url.URL{
+		Path: fmt.Sprintf("/account/%d", accountInfo.Id),
+	}
+
+	account := database.GetAccountById(accountInfo.Id)
+	assert.NotNil(t, account)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", u.String(), nil)
+	request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var responseDto accountModuleDto.AccountDto
+	err := json.NewDecoder(response.Body).Decode(&responseDto)
+	assert.Nil(t, err)
+
+	assert.Equal(t, account.Balance.String(), responseDto.Balance)
+	test.CompareAccount(t, account, responseDto)
+}
+
+func TestGetAccountByAddressRoute_FailInvalidAddress(t *testing.T) {
+	u := &url.URL{
+		Path: fmt.Sprintf("/account/by-address/%s", "invalid-address"),
+	}
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", u.String(), nil)
+	request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	var responseDto errorHelpers.ResponseBadRequestErrorHTTP
+	err := json.NewDecoder(response.Body).Decode(&responseDto)
+	assert.Nil(t, err)
+	assert.Equal(t, false, responseDto.Success)
+	assert.Equal(t, "Address format is wrong", responseDto.Message)
+}
+
+func TestGetAccountByAddressRoute_FailNotFound(t *testing.T) {
+	address := "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+	u := &url.URL{
+		Path: fmt.Sprintf("/account/by-address/%s", address),
+	}
+
+	assert.Nil(t, database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, address), "Account must not exists")
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", u.String(), nil)
+	request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	var responseDto errorHelpers.ResponseNotFoundErrorHTTP
+	err := json.NewDecoder(response.Body).Decode(&responseDto)
+	assert.Nil(t, err)
+	assert.Equal(t, false, responseDto.Success)
+	assert.Equal(t, "Account not found", responseDto.Message)
+}
+
+func TestGetAccountByAddressRoute_Success(t *testing.T) {
+	accountInfo := seeds.ACCOUNTS.ACCOUNT_3
+	u := &url.URL{
+		Path: fmt.Sprintf("/account/by-address/%s", accountInfo.Address),
+	}
+
+	account := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, accountInfo.Address)
+	assert.NotNil(t, account)
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", u.String(), nil)
+	request.Header.Set("X-API-Key", config.AppConfig.AdminXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var responseDto accountModuleDto.AccountDto
+	err := json.NewDecoder(response.Body).Decode(&responseDto)
+	assert.Nil(t, err)
+
+	assert.Equal(t, accountInfo.Id, responseDto.Id)
+	test.CompareAccount(t, account, responseDto)
+}
+
 func validationCreateAccountTests(t *testing.T) {
 	validationTests := []struct {
 		name         string
@@ -615,7 +846,7 @@ func validationCreateAccountTests(t *testing.T) {
 				Address: "invalid address",
 				Name:    "John Doe",
 				Rank:    50,
-				Status:  entities.AccountStatusOn,
+				Status:  entities.AccountStatusActive,
 			},
 			http.StatusBadRequest,
 			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Address format is wrong"},
@@ -629,14 +860,25 @@ func validationCreateAccountTests(t *testing.T) {
 				Status:  "invalid status",
 			},
 			http.StatusBadRequest,
-			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: fmt.Sprintf("%s must be one of the next values: %s", "Status", strings.Join(entities.AccountStatusList, ","))},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: fmt.Sprintf("%s must be one of the next values: %s", "Status", strings.Join(entities.AccountInitialStatusList, ","))},
+		},
+		{
+			"FailNotInitialStatus",
+			accountModuleDto.PostCreateAccountRequestDto{
+				Address: "14yqg2y3a6HMgW9MiF5tVPAH4Dr1uxGKFJ",
+				Name:    "John Doe",
+				Rank:    50,
+				Status:  entities.AccountStatusArchived,
+			},
+			http.StatusBadRequest,
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: fmt.Sprintf("%s must be one of the next values: %s", "Status", strings.Join(entities.AccountInitialStatusList, ","))},
 		},
 		{
 			"FailMissingName",
 			accountModuleDto.PostCreateAccountRequestDto{
 				Address: "14yqg2y3a6HMgW9MiF5tVPAH4Dr1uxGKFJ",
 				Rank:    50,
-				Status:  entities.AccountStatusOn,
+				Status:  entities.AccountStatusActive,
 			},
 			http.StatusBadRequest,
 			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Name must be between 1 and 255 characters"},
@@ -647,7 +889,7 @@ func validationCreateAccountTests(t *testing.T) {
 				Address: "14yqg2y3a6HMgW9MiF5tVPAH4Dr1uxGKFJ",
 				Name:    strings.Repeat("a", 256),
 				Rank:    50,
-				Status:  entities.AccountStatusOn,
+				Status:  entities.AccountStatusActive,
 			},
 			http.StatusBadRequest,
 			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Name must be between 1 and 255 characters"},
@@ -657,7 +899,7 @@ func validationCreateAccountTests(t *testing.T) {
 			accountModuleDto.PostCreateAccountRequestDto{
 				Address: "14yqg2y3a6HMgW9MiF5tVPAH4Dr1uxGKFJ",
 				Name:    "John Doe",
-				Status:  entities.AccountStatusOn,
+				Status:  entities.AccountStatusActive,
 			},
 			http.StatusBadRequest,
 			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Rank must be between 0 and 100"},
@@ -668,7 +910,7 @@ func validationCreateAccountTests(t *testing.T) {
 				Address: "14yqg2y3a6HMgW9MiF5tVPAH4Dr1uxGKFJ",
 				Name:    "John Doe",
 				Rank:    101,
-				Status:  entities.AccountStatusOn,
+				Status:  entities.AccountStatusActive,
 			},
 			http.StatusBadRequest,
 			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Rank must be between 0 and 100"},
@@ -712,7 +954,7 @@ func TestCreateAccountRoute_FailAddressAlreadyExists(t *testing.T) {
 		Name:    "New Name",
 		Rank:    60,
 		Memo:    "New memo",
-		Status:  entities.AccountStatusOn,
+		Status:  entities.AccountStatusActive,
 	}
 	body, _ := json.Marshal(params)
 
@@ -720,7 +962,7 @@ func TestCreateAccountRoute_FailAddressAlreadyExists(t *testing.T) {
 		Path: fmt.Sprintf("/account"),
 	}
 
-	assert.Equal(t, true, database.IsAddressExists(nil, params.Address), "Address must exists")
+	assert.Equal(t, true, database.IsAddressExists(nil, entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Address), "Address must exists")
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("POST", u.String(), bytes.NewBuffer(body))
@@ -741,7 +983,7 @@ func TestCreateAccountRoute_FailAddressAlreadyExists(t *testing.T) {
 	assert.Equal(t, "Address already exists", responseDto.Message)
 
 	// Verify that the existing account was not modified
-	accountAfter := database.GetAccountByAddress(params.Address)
+	accountAfter := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Address)
 	assert.NotNil(t, accountAfter)
 	assert.Equal(t, accountInfo.Name, accountAfter.Name, "Name should not be changed")
 	assert.Equal(t, accountInfo.Rank, accountAfter.Rank, "Rank should not be changed")
@@ -762,7 +1004,7 @@ func TestCreateAccountRoute_Success(t *testing.T) {
 		Name:    "John Doe",
 		Rank:    50,
 		Memo:    "Test memo",
-		Status:  entities.AccountStatusOn,
+		Status:  entities.AccountStatusActive,
 	}
 	body, _ := json.Marshal(params)
 
@@ -770,7 +1012,7 @@ func TestCreateAccountRoute_Success(t *testing.T) {
 		Path: fmt.Sprintf("/account"),
 	}
 
-	assert.Equal(t, false, database.IsAddressExists(nil, params.Address), "Address must not exists")
+	assert.Equal(t, false, database.IsAddressExists(nil, entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Address), "Address must not exists")
 
 	response := httptest.NewRecorder()
 	request := httptest.NewRequest("POST", u.String(), bytes.NewBuffer(body))
@@ -794,7 +1036,7 @@ func TestCreateAccountRoute_Success(t *testing.T) {
 	assert.NotNil(t, responseDto.CreatedAt, "CreatedAt parameter should exist")
 	assert.NotNil(t, responseDto.UpdatedAt, "UpdatedAt parameter should exist")
 
-	accountAfter := database.GetAccountByAddress(params.Address)
+	accountAfter := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, params.Address)
 	assert.NotNil(t, accountAfter)
 
 	assert.Equal(t, responseDto.Id, accountAfter.Id)
@@ -0,0 +1,218 @@
+package auditTests
+
+import (
+	"encoding/json"
+	"fmt"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	accountModuleDto "go-gin-test-job/src/modules/account/dto"
+	auditModuleDto "go-gin-test-job/src/modules/audit/dto"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRoute(t *testing.T) {
+	// GetAuditLogs
+	validationGetAuditLogsTests(t)
+	t.Run("TestGetAuditLogsRoute_SuccessAccountChanges", TestGetAuditLogsRoute_SuccessAccountChanges)
+	t.Run("TestGetAuditLogsRoute_SuccessCursor", TestGetAuditLogsRoute_SuccessCursor)
+	t.Run("TestGetAuditLogsRoute_SuccessCronActor", TestGetAuditLogsRoute_SuccessCronActor)
+	t.Run("TestAuditLog_FailModify", TestAuditLog_FailModify)
+}
+
+func sendAuditRequest(t *testing.T, method string, path string, requestId string, body string) *httptest.ResponseRecorder {
+	request := test.NewAdminRequest(t, method, path, nil, body)
+	if requestId != "" {
+		request.Header.Set("X-Request-ID", requestId)
+	}
+	return test.ServeRequest(request)
+}
+
+func getAuditLogs(t *testing.T, query url.Values) auditModuleDto.GetAuditLogsResponseDto {
+	response := test.SendAdminRequest(t, "GET", "/audit", query, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	return test.DecodeResponse[auditModuleDto.GetAuditLogsResponseDto](t, response)
+}
+
+func decodeAuditValues(t *testing.T, data json.RawMessage) map[string]interface{} {
+	var values map[string]interface{}
+	err := json.Unmarshal(data, &values)
+	assert.Nil(t, err)
+	return values
+}
+
+func validationGetAuditLogsTests(t *testing.T) {
+	validationTests := []struct {
+		name         string
+		query        url.Values
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailAction",
+			url.Values{"action": {"rename"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Action must be one of the next values: create,update,delete,restore,purge,tag_attach,tag_detach,transition"},
+		},
+		{
+			"FailAccountIdMin",
+			url.Values{"accountId": {"0"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "AccountId must be greater than or equal 1"},
+		},
+		{
+			"FailAccountIdNotNumber",
+			url.Values{"accountId": {"first"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "accountId is invalid"},
+		},
+		{
+			"FailFromAfterTo",
+			url.Values{"from": {"200"}, "to": {"100"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "From must be less than or equal To"},
+		},
+		{
+			"FailCountMax",
+			url.Values{"count": {"1001"}},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: "Count must be less than or equal 1000"},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestGetAuditLogsRoute_"+tt.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, "GET", "/audit", tt.query, nil)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto)
+		})
+	}
+}
+
+func TestGetAuditLogsRoute_SuccessAccountChanges(t *testing.T) {
+	address := "1CzFSSeDNeqdoQuiwR3CzfEJt9bWsgwtcd"
+	response := sendAuditRequest(t, "POST", "/account", "audit-create",
+		fmt.Sprintf(`{"address": "%s", "name": "Audit Before", "rank": 10, "memo": "Audit memo", "status": "Active"}`, address))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "audit-create", response.Header().Get("X-Request-ID"))
+
+	accountDto := test.DecodeResponse[accountModuleDto.AccountDto](t, response)
+
+	response = sendAuditRequest(t, "PATCH", fmt.Sprintf("/account/%d", accountDto.Id), "audit-update", `{"name": "Audit After", "rank": 10}`)
+	assert.Equal(t, http.StatusOK, response.Code)
+	response = sendAuditRequest(t, "DELETE", fmt.Sprintf("/account/%d", accountDto.Id), "audit-delete", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := getAuditLogs(t, url.Values{"accountId": {strconv.FormatInt(accountDto.Id, 10)}})
+	assert.Nil(t, responseDto.NextCursor)
+	assert.Equal(t, 3, len(responseDto.List))
+	if len(responseDto.List) != 3 {
+		return
+	}
+	// The newest entry comes first
+	deleteEntry, updateEntry, createEntry := responseDto.List[0], responseDto.List[1], responseDto.List[2]
+	for _, entry := range responseDto.List {
+		assert.Equal(t, accountDto.Id, entry.AccountId)
+		assert.Equal(t, entities.AuditActorAdmin, entry.Actor)
+	}
+
+	assert.Equal(t, string(entities.AuditActionCreate), createEntry.Action)
+	assert.Equal(t, "audit-create", createEntry.RequestId)
+	assert.Equal(t, "null", string(createEntry.Before))
+	createdValues := decodeAuditValues(t, createEntry.After)
+	assert.Equal(t, "BTC", createdValues["chain"])
+	assert.Equal(t, "mainnet", createdValues["network"])
+	assert.Equal(t, address, createdValues["address"])
+	assert.Equal(t, "Audit Before", createdValues["name"])
+	assert.Equal(t, "Active", createdValues["status"])
+
+	// The unchanged rank is left out of the diff
+	assert.Equal(t, string(entities.AuditActionUpdate), updateEntry.Action)
+	assert.Equal(t, "audit-update", updateEntry.RequestId)
+	assert.Equal(t, map[string]interface{}{"name": "Audit Before"}, decodeAuditValues(t, updateEntry.Before))
+	assert.Equal(t, map[string]interface{}{"name": "Audit After"}, decodeAuditValues(t, updateEntry.After))
+
+	assert.Equal(t, string(entities.AuditActionDelete), deleteEntry.Action)
+	assert.Equal(t, "audit-delete", deleteEntry.RequestId)
+	assert.Equal(t, map[string]interface{}{"deleted_at": nil}, decodeAuditValues(t, deleteEntry.Before))
+	assert.NotNil(t, decodeAuditValues(t, deleteEntry.After)["deleted_at"])
+
+	// Filters by action
+	responseDto = getAuditLogs(t, url.Values{"accountId": {strconv.FormatInt(accountDto.Id, 10)}, "action": {string(entities.AuditActionUpdate)}})
+	assert.Equal(t, 1, len(responseDto.List))
+	if len(responseDto.List) == 1 {
+		assert.Equal(t, updateEntry.Id, responseDto.List[0].Id)
+	}
+}
+
+func TestGetAuditLogsRoute_SuccessCursor(t *testing.T) {
+	allDto := getAuditLogs(t, url.Values{"actor": {entities.AuditActorAdmin}, "count": {"4"}})
+	assert.Equal(t, 4, len(allDto.List))
+
+	ids := make([]int64, 0)
+	query := url.Values{"actor": {entities.AuditActorAdmin}, "count": {"2"}}
+	for page := 0; page < 2; page++ {
+		responseDto := getAuditLogs(t, query)
+		assert.Equal(t, 2, len(responseDto.List))
+		assert.NotNil(t, responseDto.NextCursor)
+		if responseDto.NextCursor == nil {
+			return
+		}
+		for _, entry := range responseDto.List {
+			ids = append(ids, entry.Id)
+		}
+		query.Set("cursor", *responseDto.NextCursor)
+	}
+	expectedIds := make([]int64, 0)
+	for _, entry := range allDto.List {
+		expectedIds = append(expectedIds, entry.Id)
+	}
+	assert.Equal(t, expectedIds, ids)
+}
+
+func TestGetAuditLogsRoute_SuccessCronActor(t *testing.T) {
+	// The cron tests changed the balances, only the confirmed and unconfirmed balances are in the diff
+	responseDto := getAuditLogs(t, url.Values{"actor": {entities.AuditActorCron}, "action": {string(entities.AuditActionUpdate)}})
+	assert.NotEqual(t, 0, len(responseDto.List))
+	for _, entry := range responseDto.List {
+		assert.Equal(t, string(entities.AuditActionUpdate), entry.Action)
+		afterValues := decodeAuditValues(t, entry.After)
+		assert.NotEqual(t, 0, len(afterValues))
+		for key := range afterValues {
+			assert.Contains(t, []string{"balance", "unconfirmed_balance"}, key)
+		}
+	}
+
+	// The cron lifecycle test activated a pending account and moved a rejected one to Error
+	responseDto = getAuditLogs(t, url.Values{"actor": {entities.AuditActorCron}, "action": {string(entities.AuditActionTransition)}})
+	statuses := make([]interface{}, 0)
+	for _, entry := range responseDto.List {
+		assert.Equal(t, string(entities.AuditActionTransition), entry.Action)
+		afterValues := decodeAuditValues(t, entry.After)
+		assert.Equal(t, 2, len(afterValues))
+		assert.NotNil(t, afterValues["status_reason"])
+		statuses = append(statuses, afterValues["status"])
+	}
+	assert.Contains(t, statuses, string(entities.AccountStatusActive))
+	assert.Contains(t, statuses, string(entities.AccountStatusError))
+}
+
+func TestAuditLog_FailModify(t *testing.T) {
+	responseDto := getAuditLogs(t, url.Values{"count": {"1"}})
+	assert.Equal(t, 1, len(responseDto.List))
+	if len(responseDto.List) != 1 {
+		return
+	}
+	entry := responseDto.List[0]
+
+	err := database.DbConn.Exec("UPDATE audit_log SET actor = ? WHERE id = ?", "other", entry.Id).Error
+	assert.NotNil(t, err)
+	err = database.DbConn.Exec("DELETE FROM audit_log WHERE id = ?", entry.Id).Error
+	assert.NotNil(t, err)
+
+	responseDto = getAuditLogs(t, url.Values{"count": {"1"}})
+	assert.Equal(t, []auditModuleDto.AuditLogDto{entry}, responseDto.List)
+}
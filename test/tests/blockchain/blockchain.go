@@ -0,0 +1,456 @@
+package blockchainTests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/modules/common/blockchain"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	btcAddress = "1CmSPVJifmK3HXqy2tYgbTSb4eExK4wqYT"
+	bchAddress = "bitcoincash:qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za963"
+)
+
+func TestBlockchainRoute(t *testing.T) {
+	// Bitcore
+	t.Run("TestBitcoreProvider_Fail", TestBitcoreProvider_Fail)
+	t.Run("TestBitcoreProvider_Success", TestBitcoreProvider_Success)
+	// Esplora
+	t.Run("TestEsploraProvider_Fail", TestEsploraProvider_Fail)
+	t.Run("TestEsploraProvider_Success", TestEsploraProvider_Success)
+	// Bitcoind
+	t.Run("TestBitcoindProvider_Fail", TestBitcoindProvider_Fail)
+	t.Run("TestBitcoindProvider_Success", TestBitcoindProvider_Success)
+	// Failover
+	t.Run("TestFailoverProvider_Fail", TestFailoverProvider_Fail)
+	t.Run("TestFailoverProvider_Success", TestFailoverProvider_Success)
+	t.Run("TestGetBalanceProvider_Success", TestGetBalanceProvider_Success)
+	// Transactions
+	t.Run("TestBitcoreProvider_SuccessTransactions", TestBitcoreProvider_SuccessTransactions)
+	t.Run("TestEsploraProvider_SuccessTransactions", TestEsploraProvider_SuccessTransactions)
+	t.Run("TestFailoverProvider_FailTransactions", TestFailoverProvider_FailTransactions)
+}
+
+// getUrls returns the provider URLs with the server URL for the chain network
+func getUrls(server *httptest.Server, chain entities.AccountChain, network entities.AccountNetwork) map[string]string {
+	return map[string]string{config.GetBlockchainUrlKey(string(chain), string(network)): server.URL}
+}
+
+func newStatusServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+///// Bitcore
+
+func TestBitcoreProvider_Fail(t *testing.T) {
+	provider := blockchain.NewBitcoreProvider(map[string]string{})
+	assert.False(t, provider.IsConfigured(entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	_, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.ErrorIs(t, err, blockchain.ErrNotConfigured)
+
+	statusTests := []struct {
+		name       string
+		status     int
+		isRejected bool
+	}{
+		{"FailNotFound", http.StatusNotFound, true},
+		{"FailBadRequest", http.StatusBadRequest, true},
+		{"FailTooManyRequests", http.StatusTooManyRequests, false},
+		{"FailServerError", http.StatusInternalServerError, false},
+	}
+	for _, tt := range statusTests {
+		t.Run("TestBitcoreProvider_"+tt.name, func(t *testing.T) {
+			server := newStatusServer(tt.status, `{}`)
+			defer server.Close()
+			provider := blockchain.NewBitcoreProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkMainnet))
+			_, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+			assert.Error(t, err)
+			assert.Equal(t, tt.isRejected, errors.Is(err, blockchain.ErrAddressRejected))
+		})
+	}
+}
+
+func TestBitcoreProvider_Success(t *testing.T) {
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"confirmed": 123456789, "unconfirmed": -23456789, "balance": 100000000}`))
+	}))
+	defer server.Close()
+
+	provider := blockchain.NewBitcoreProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	assert.True(t, provider.IsConfigured(entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	assert.False(t, provider.IsConfigured(entities.AccountChainBtc, entities.AccountNetworkTestnet))
+	balance, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.23456789", balance.Confirmed.String())
+	assert.Equal(t, "-0.23456789", balance.Unconfirmed.String())
+	assert.Equal(t, "1", balance.Total().String())
+	assert.Equal(t, blockchain.ProviderBitcore, balance.Source)
+	assert.Equal(t, fmt.Sprintf("/address/%s/balance", btcAddress), requestPath)
+
+	// Bitcore takes the CashAddr address without the prefix
+	provider = blockchain.NewBitcoreProvider(getUrls(server, entities.AccountChainBch, entities.AccountNetworkMainnet))
+	_, err = provider.GetAddressBalance(entities.AccountChainBch, entities.AccountNetworkMainnet, bchAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, "/address/qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za963/balance", requestPath)
+}
+
+///// Esplora
+
+func TestEsploraProvider_Fail(t *testing.T) {
+	statusTests := []struct {
+		name       string
+		status     int
+		body       string
+		isRejected bool
+	}{
+		{"FailBadRequest", http.StatusBadRequest, "Invalid Bitcoin address", true},
+		{"FailServerError", http.StatusBadGateway, "", false},
+		{"FailBody", http.StatusOK, "not json", false},
+	}
+	for _, tt := range statusTests {
+		t.Run("TestEsploraProvider_"+tt.name, func(t *testing.T) {
+			server := newStatusServer(tt.status, tt.body)
+			defer server.Close()
+			provider := blockchain.NewEsploraProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkMainnet))
+			_, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+			assert.Error(t, err)
+			assert.Equal(t, tt.isRejected, errors.Is(err, blockchain.ErrAddressRejected))
+		})
+	}
+}
+
+func TestEsploraProvider_Success(t *testing.T) {
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte(`{
+			"address": "1CmSPVJifmK3HXqy2tYgbTSb4eExK4wqYT",
+			"chain_stats": {"funded_txo_count": 3, "funded_txo_sum": 250000000, "spent_txo_count": 1, "spent_txo_sum": 100000001, "tx_count": 3},
+			"mempool_stats": {"funded_txo_count": 1, "funded_txo_sum": 5000, "spent_txo_count": 0, "spent_txo_sum": 0, "tx_count": 1}
+		}`))
+	}))
+	defer server.Close()
+
+	provider := blockchain.NewEsploraProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	balance, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.Nil(t, err)
+	// The mempool outputs are not confirmed
+	assert.Equal(t, "1.49999999", balance.Confirmed.String())
+	assert.Equal(t, "0.00005", balance.Unconfirmed.String())
+	assert.Equal(t, "1.50004999", balance.Total().String())
+	assert.Equal(t, blockchain.ProviderEsplora, balance.Source)
+	assert.Equal(t, fmt.Sprintf("/address/%s", btcAddress), requestPath)
+}
+
+///// Bitcoind
+
+func TestBitcoindProvider_Fail(t *testing.T) {
+	statusTests := []struct {
+		name       string
+		status     int
+		body       string
+		isRejected bool
+	}{
+		{"FailInvalidAddress", http.StatusInternalServerError, `{"result": null, "error": {"code": -5, "message": "Invalid address"}, "id": "balance"}`, true},
+		{"FailScanInProgress", http.StatusInternalServerError, `{"result": null, "error": {"code": -8, "message": "Scan already in progress"}, "id": "balance"}`, false},
+		{"FailScanNotCompleted", http.StatusOK, `{"result": {"success": false}, "error": null, "id": "balance"}`, false},
+		{"FailUnauthorized", http.StatusUnauthorized, ``, false},
+		{"FailBody", http.StatusServiceUnavailable, `Loading block index`, false},
+	}
+	for _, tt := range statusTests {
+		t.Run("TestBitcoindProvider_"+tt.name, func(t *testing.T) {
+			server := newStatusServer(tt.status, tt.body)
+			defer server.Close()
+			provider := blockchain.NewBitcoindProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkRegtest))
+			_, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkRegtest, btcAddress)
+			assert.Error(t, err)
+			assert.Equal(t, tt.isRejected, errors.Is(err, blockchain.ErrAddressRejected))
+		})
+	}
+}
+
+func TestBitcoindProvider_Success(t *testing.T) {
+	var rpcRequest blockchain.BitcoindRpcRequest
+	var username, password string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+		_ = json.NewDecoder(r.Body).Decode(&rpcRequest)
+		_, _ = w.Write([]byte(`{"result": {"success": true, "txouts": 9, "height": 120, "unspents": [], "total_amount": 0.50000001}, "error": null, "id": "balance"}`))
+	}))
+	defer server.Close()
+
+	// The RPC credentials are the URL user info
+	serverUrl, _ := url.Parse(server.URL)
+	serverUrl.User = url.UserPassword("rpcuser", "rpcpassword")
+	provider := blockchain.NewBitcoindProvider(map[string]string{
+		config.GetBlockchainUrlKey(string(entities.AccountChainBtc), string(entities.AccountNetworkRegtest)): serverUrl.String(),
+	})
+	balance, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkRegtest, btcAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.50000001", balance.Confirmed.String())
+	assert.True(t, balance.Unconfirmed.IsZero())
+	assert.Equal(t, blockchain.ProviderBitcoind, balance.Source)
+	assert.Equal(t, "rpcuser", username)
+	assert.Equal(t, "rpcpassword", password)
+	assert.Equal(t, "scantxoutset", rpcRequest.Method)
+	assert.Equal(t, []interface{}{"start", []interface{}{fmt.Sprintf("addr(%s)", btcAddress)}}, rpcRequest.Params)
+}
+
+///// Failover
+
+// countingProvider counts the calls of the wrapped provider
+type countingProvider struct {
+	blockchain.BalanceProvider
+	calls int
+}
+
+func (p *countingProvider) GetAddressBalance(chain entities.AccountChain, network entities.AccountNetwork, address string) (blockchain.AddressBalance, error) {
+	p.calls++
+	return p.BalanceProvider.GetAddressBalance(chain, network, address)
+}
+
+func TestFailoverProvider_Fail(t *testing.T) {
+	failingServer := newStatusServer(http.StatusBadGateway, "")
+	defer failingServer.Close()
+	rejectingServer := newStatusServer(http.StatusBadRequest, "Invalid Bitcoin address")
+	defer rejectingServer.Close()
+
+	// All providers fail
+	provider := blockchain.NewFailoverProvider([]blockchain.BalanceProvider{
+		blockchain.NewBitcoreProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+		blockchain.NewEsploraProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+	})
+	_, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, blockchain.ErrAddressRejected))
+	assert.Contains(t, err.Error(), "bitcore: Provider response status 502")
+	assert.Contains(t, err.Error(), "esplora: Provider response status 502")
+
+	// No provider has a URL for the chain network
+	_, err = provider.GetAddressBalance(entities.AccountChainLtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.ErrorIs(t, err, blockchain.ErrNotConfigured)
+
+	// The rejection is not retried with the next provider
+	nextProvider := &countingProvider{BalanceProvider: blockchain.NewEsploraProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet))}
+	provider = blockchain.NewFailoverProvider([]blockchain.BalanceProvider{
+		blockchain.NewEsploraProvider(getUrls(rejectingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+		nextProvider,
+	})
+	_, err = provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.ErrorIs(t, err, blockchain.ErrAddressRejected)
+	assert.Equal(t, 0, nextProvider.calls)
+}
+
+func TestFailoverProvider_Success(t *testing.T) {
+	failingServer := newStatusServer(http.StatusServiceUnavailable, "")
+	defer failingServer.Close()
+	esploraServer := newStatusServer(http.StatusOK, `{"chain_stats": {"funded_txo_sum": 700, "spent_txo_sum": 200}, "mempool_stats": {}}`)
+	defer esploraServer.Close()
+
+	firstProvider := &countingProvider{BalanceProvider: blockchain.NewBitcoreProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet))}
+	// The provider without a URL for the chain network is skipped
+	skippedProvider := &countingProvider{BalanceProvider: blockchain.NewBitcoindProvider(map[string]string{})}
+	provider := blockchain.NewFailoverProvider([]blockchain.BalanceProvider{
+		firstProvider,
+		skippedProvider,
+		blockchain.NewEsploraProvider(getUrls(esploraServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+	})
+	assert.True(t, provider.IsConfigured(entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	assert.False(t, provider.IsConfigured(entities.AccountChainBtc, entities.AccountNetworkTestnet))
+	balance, err := provider.GetAddressBalance(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.000005", balance.Confirmed.String())
+	assert.Equal(t, blockchain.ProviderEsplora, balance.Source)
+	assert.Equal(t, 1, firstProvider.calls)
+	assert.Equal(t, 0, skippedProvider.calls)
+}
+
+func TestGetBalanceProvider_Success(t *testing.T) {
+	providers := config.AppConfig.BlockchainProviders
+	defer func() {
+		config.AppConfig.BlockchainProviders = providers
+	}()
+
+	config.AppConfig.BlockchainProviders = []string{blockchain.ProviderBitcore}
+	assert.Equal(t, blockchain.ProviderBitcore, blockchain.GetBalanceProvider().Name())
+	assert.NotContains(t, blockchain.GetChainNetworks(), entities.AccountChainNetwork{Chain: entities.AccountChainBtc, Network: entities.AccountNetworkSignet})
+
+	// Esplora has a signet URL by default
+	config.AppConfig.BlockchainProviders = []string{blockchain.ProviderBitcore, blockchain.ProviderEsplora}
+	assert.Equal(t, "bitcore,esplora", blockchain.GetBalanceProvider().Name())
+	assert.Contains(t, blockchain.GetChainNetworks(), entities.AccountChainNetwork{Chain: entities.AccountChainBtc, Network: entities.AccountNetworkSignet})
+	assert.Contains(t, blockchain.GetChainNetworks(), entities.AccountChainNetwork{Chain: entities.AccountChainLtc, Network: entities.AccountNetworkMainnet})
+}
+
+///// Transactions
+
+const (
+	receiveTxid = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+	spendTxid   = "a1075db55d416d3ca199f55b6084e2115b9345e16c5cf302fc80e9d5fbf5d48d"
+	mempoolTxid = "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9"
+)
+
+func TestBitcoreProvider_SuccessTransactions(t *testing.T) {
+	requestPaths := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPaths = append(requestPaths, r.URL.RequestURI())
+		switch r.URL.Path {
+		case fmt.Sprintf("/address/%s/txs", btcAddress):
+			_, _ = w.Write([]byte(fmt.Sprintf(`[
+				{"mintTxid": "%s", "spentTxid": "", "value": 5000},
+				{"mintTxid": "%s", "spentTxid": "%s", "value": 100000000},
+				{"mintTxid": "%s", "spentTxid": "", "value": 40000000}
+			]`, mempoolTxid, receiveTxid, spendTxid, spendTxid)))
+		case "/tx/" + mempoolTxid:
+			_, _ = w.Write([]byte(`{"blockHeight": -1, "blockTime": "", "fee": 300, "confirmations": -1}`))
+		case "/tx/" + receiveTxid:
+			_, _ = w.Write([]byte(`{"blockHeight": 170, "blockTime": "2009-01-12T03:30:25.000Z", "fee": 0, "confirmations": 6}`))
+		case "/tx/" + spendTxid:
+			_, _ = w.Write([]byte(`{"blockHeight": 171, "blockTime": "2009-01-12T04:00:00.000Z", "fee": 1000, "confirmations": 5}`))
+		case "/tx/" + mempoolTxid + "/coins":
+			// The address spends a coin left out of the page and receives the change
+			_, _ = w.Write([]byte(fmt.Sprintf(`{
+				"inputs": [{"address": "%s", "value": 15000}],
+				"outputs": [{"address": "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "value": 9700}, {"address": "%s", "value": 5000}]
+			}`, btcAddress, btcAddress)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := blockchain.NewBitcoreProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	transactions, isComplete, err := provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 10, nil)
+	assert.Nil(t, err)
+	assert.True(t, isComplete)
+	assert.Equal(t, fmt.Sprintf("/address/%s/txs?limit=10", btcAddress), requestPaths[0])
+	if !assert.Equal(t, 3, len(transactions)) {
+		return
+	}
+	// The mempool transaction has no block
+	assert.Equal(t, mempoolTxid, transactions[0].Txid)
+	assert.Nil(t, transactions[0].BlockHeight)
+	assert.Equal(t, int64(0), transactions[0].Time)
+	assert.Equal(t, uint(0), transactions[0].Confirmations)
+	assert.Equal(t, "0.00005", transactions[0].Amount.String())
+	assert.Equal(t, "0.000003", transactions[0].Fee.String())
+	// The spent coin is taken from the spending transaction, its change is added back
+	assert.Equal(t, receiveTxid, transactions[1].Txid)
+	assert.Equal(t, "1", transactions[1].Amount.String())
+	assert.Equal(t, spendTxid, transactions[2].Txid)
+	assert.Equal(t, "-0.6", transactions[2].Amount.String())
+	assert.Equal(t, "0.00001", transactions[2].Fee.String())
+	if assert.NotNil(t, transactions[2].BlockHeight) {
+		assert.Equal(t, int64(171), *transactions[2].BlockHeight)
+	}
+	assert.Equal(t, int64(1231732800), transactions[2].Time)
+	assert.Equal(t, uint(5), transactions[2].Confirmations)
+
+	// The count limits the transactions details requests. A full coins page is not complete, the amount of
+	// the listed transaction is read from its coins
+	requestPaths = make([]string, 0)
+	transactions, isComplete, err = provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 1, nil)
+	assert.Nil(t, err)
+	assert.False(t, isComplete)
+	if assert.Equal(t, 1, len(transactions)) {
+		assert.Equal(t, "-0.0001", transactions[0].Amount.String())
+	}
+	assert.Equal(t, []string{fmt.Sprintf("/address/%s/txs?limit=1", btcAddress), "/tx/" + mempoolTxid, "/tx/" + mempoolTxid + "/coins"}, requestPaths)
+
+	// The settled transactions are listed without reading their details
+	requestPaths = make([]string, 0)
+	transactions, _, err = provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 10, map[string]bool{receiveTxid: true})
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(transactions)) {
+		assert.Equal(t, blockchain.AddressTransaction{Txid: receiveTxid, IsSettled: true}, transactions[1])
+		assert.False(t, transactions[2].IsSettled)
+	}
+	assert.NotContains(t, requestPaths, "/tx/"+receiveTxid)
+	assert.Equal(t, 3, len(requestPaths))
+}
+
+func TestEsploraProvider_SuccessTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/blocks/tip/height":
+			_, _ = w.Write([]byte(`175`))
+		case fmt.Sprintf("/address/%s/txs", btcAddress):
+			_, _ = w.Write([]byte(fmt.Sprintf(`[
+				{"txid": "%s", "fee": 300, "status": {"confirmed": false},
+					"vin": [{"prevout": {"scriptpubkey_address": "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "value": 5300}}],
+					"vout": [{"scriptpubkey_address": "%s", "value": 5000}]},
+				{"txid": "%s", "fee": 1000, "status": {"confirmed": true, "block_height": 171, "block_time": 1231732800},
+					"vin": [{"prevout": {"scriptpubkey_address": "%s", "value": 100000000}}],
+					"vout": [{"scriptpubkey_address": "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "value": 59999000}, {"scriptpubkey_address": "%s", "value": 40000000}]},
+				{"txid": "%s", "fee": 0, "status": {"confirmed": true, "block_height": 170, "block_time": 1231731025},
+					"vin": [{"prevout": null}],
+					"vout": [{"scriptpubkey_address": "%s", "value": 100000000}]}
+			]`, mempoolTxid, btcAddress, spendTxid, btcAddress, btcAddress, receiveTxid, btcAddress)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := blockchain.NewEsploraProvider(getUrls(server, entities.AccountChainBtc, entities.AccountNetworkMainnet))
+	transactions, isComplete, err := provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 10, nil)
+	assert.Nil(t, err)
+	assert.True(t, isComplete)
+	if !assert.Equal(t, 3, len(transactions)) {
+		return
+	}
+	assert.Equal(t, mempoolTxid, transactions[0].Txid)
+	assert.Nil(t, transactions[0].BlockHeight)
+	assert.Equal(t, "0.00005", transactions[0].Amount.String())
+	assert.Equal(t, "0.000003", transactions[0].Fee.String())
+	// The change output goes back to the address
+	assert.Equal(t, spendTxid, transactions[1].Txid)
+	assert.Equal(t, "-0.6", transactions[1].Amount.String())
+	assert.Equal(t, int64(1231732800), transactions[1].Time)
+	assert.Equal(t, uint(5), transactions[1].Confirmations)
+	// The coinbase input has no prevout
+	assert.Equal(t, receiveTxid, transactions[2].Txid)
+	assert.Equal(t, "1", transactions[2].Amount.String())
+	assert.Equal(t, uint(6), transactions[2].Confirmations)
+
+	// The count cuts the list
+	transactions, isComplete, err = provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 2, nil)
+	assert.Nil(t, err)
+	assert.False(t, isComplete)
+	assert.Equal(t, 2, len(transactions))
+}
+
+func TestFailoverProvider_FailTransactions(t *testing.T) {
+	failingServer := newStatusServer(http.StatusBadGateway, "")
+	defer failingServer.Close()
+
+	// Bitcoind has no address index
+	provider := blockchain.NewFailoverProvider([]blockchain.BalanceProvider{
+		blockchain.NewBitcoindProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+	})
+	_, _, err := provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 10, nil)
+	assert.ErrorIs(t, err, blockchain.ErrTransactionsNotSupported)
+
+	provider = blockchain.NewFailoverProvider([]blockchain.BalanceProvider{
+		blockchain.NewBitcoindProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+		blockchain.NewEsploraProvider(getUrls(failingServer, entities.AccountChainBtc, entities.AccountNetworkMainnet)),
+	})
+	_, _, err = provider.GetAddressTransactions(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress, 10, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "esplora: Provider response status 502")
+	assert.NotContains(t, err.Error(), "bitcoind")
+}
@@ -0,0 +1,95 @@
+package cronTests
+
+import (
+	"context"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/modules/common/electrum"
+	cronModule "go-gin-test-job/src/modules/cron"
+	currencyUtil "go-gin-test-job/src/utils/currency"
+	testElectrum "go-gin-test-job/test/electrum"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	electrumPendingAddress = "bcrt1qqypqxpq9qcrsszg2pvxq6rs0zqg3yyc5phstwt"
+	electrumActiveAddress  = "bcrt1qz5tpwxqergd3c8g7ruszzg3rysjjvfegkjy9xa"
+	electrumWaitTimeout    = 5 * time.Second
+	electrumWaitTick       = 50 * time.Millisecond
+)
+
+func TestElectrumSubscription_Success(t *testing.T) {
+	server, err := testElectrum.NewFakeServer()
+	assert.Nil(t, err)
+	defer server.Close()
+
+	chainNetwork := entities.AccountChainNetwork{Chain: entities.AccountChainBtc, Network: entities.AccountNetworkRegtest}
+	// The other regtest accounts keep their balances
+	for _, account := range database.GetPollingAccounts(chainNetwork) {
+		scripthash, err := electrum.GetScripthash(account.Chain, account.Network, account.Address)
+		assert.Nil(t, err)
+		server.SetBalance(scripthash, currencyUtil.ToSatoshi(account.Balance.String()).IntPart(), 0)
+	}
+
+	testAuditContext := database.AuditContext{Actor: "test"}
+	pendingAccount, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkRegtest, electrumPendingAddress, entities.AccountStatusPending, "Electrum Pending", 10, ""))
+	assert.Nil(t, err)
+	activeAccount, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkRegtest, electrumActiveAddress, entities.AccountStatusActive, "Electrum Active", 10, ""))
+	assert.Nil(t, err)
+	pendingScripthash, err := electrum.GetScripthash(entities.AccountChainBtc, entities.AccountNetworkRegtest, electrumPendingAddress)
+	assert.Nil(t, err)
+	activeScripthash, err := electrum.GetScripthash(entities.AccountChainBtc, entities.AccountNetworkRegtest, electrumActiveAddress)
+	assert.Nil(t, err)
+	server.SetBalance(pendingScripthash, 250000000, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		cronModule.StartElectrumSubscription(ctx, chainNetwork, server.URL())
+		close(stopped)
+	}()
+
+	// The subscribe reads the balance of the address with history and activates the pending account
+	assert.Eventually(t, func() bool {
+		account := database.GetAccountById(pendingAccount.Id)
+		return account.Status == entities.AccountStatusActive && account.Balance.Equal(currencyUtil.FromSatoshi(250000000))
+	}, electrumWaitTimeout, electrumWaitTick)
+	assert.Eventually(t, func() bool {
+		return server.SubscribeCount(activeScripthash) == 1
+	}, electrumWaitTimeout, electrumWaitTick)
+
+	// The notification updates the account right away
+	server.SetBalance(activeScripthash, 12345678, 1000)
+	assert.Eventually(t, func() bool {
+		return database.GetAccountById(activeAccount.Id).Balance.Equal(currencyUtil.FromSatoshi(12345678))
+	}, electrumWaitTimeout, electrumWaitTick)
+	activeAccount = database.GetAccountById(activeAccount.Id)
+	assert.Equal(t, currencyUtil.FromSatoshi(1000).String(), activeAccount.UnconfirmedBalance.String())
+	assert.Equal(t, currencyUtil.FromSatoshi(12346678).String(), activeAccount.TotalBalance.String())
+	history := database.GetAccountBalanceHistory(database.AccountBalanceHistoryFilter{AccountId: activeAccount.Id}, nil, 10)
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, electrum.BalanceSource, history[0].Source)
+	auditLogs := database.GetAuditLogs(database.AuditLogFilter{AccountId: &activeAccount.Id, Actor: entities.AuditActorElectrum}, nil, 10)
+	assert.Greater(t, len(auditLogs), 0)
+
+	// The subscription reconnects, subscribes again and reads the balance changed while it was disconnected
+	server.DropConnections()
+	server.SetBalance(activeScripthash, 87654321, 0)
+	assert.Eventually(t, func() bool {
+		return server.SubscribeCount(activeScripthash) == 2 && server.SubscribeCount(pendingScripthash) == 2
+	}, electrumWaitTimeout, electrumWaitTick)
+	assert.Eventually(t, func() bool {
+		return database.GetAccountById(activeAccount.Id).Balance.Equal(currencyUtil.FromSatoshi(87654321))
+	}, electrumWaitTimeout, electrumWaitTick)
+	assert.True(t, database.GetAccountById(pendingAccount.Id).Balance.Equal(currencyUtil.FromSatoshi(250000000)))
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(electrumWaitTimeout):
+		t.Fatal("Subscription is not stopped")
+	}
+}
@@ -0,0 +1,133 @@
+package cronTests
+
+import (
+	"fmt"
+	"go-gin-test-job/src/config"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	currencyUtil "go-gin-test-job/src/utils/currency"
+	timeUtil "go-gin-test-job/src/utils/time"
+	"go-gin-test-job/test"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	transactionAddress     = "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"
+	transactionReceiveTxid = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+	transactionMempoolTxid = "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9"
+)
+
+// runCronUntil runs the balance cron until the condition is met, the cron takes the least recently updated accounts
+func runCronUntil(t *testing.T, condition func() bool) {
+	for run := 0; run < 100; run++ {
+		if condition() {
+			return
+		}
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/cron/account-balance", nil)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-API-Key", config.AppConfig.CronXApiKey)
+		test.TestApp.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+	assert.True(t, condition())
+}
+
+func TestUpdateAccountsBalancesRoute_SuccessTransactions(t *testing.T) {
+	start := timeUtil.GetUnixTime()
+	testAuditContext := database.AuditContext{Actor: "test"}
+	account, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, transactionAddress, entities.AccountStatusActive, "Cron Transactions", 10, ""))
+	assert.Nil(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The other accounts keep their balances and have no transactions
+	httpmock.RegisterRegexpResponder(
+		"GET",
+		regexp.MustCompile(`^https://api\.bitcore\.io/api/BTC/mainnet/address/(\w+)/balance$`),
+		func(request *http.Request) (*http.Response, error) {
+			address := httpmock.MustGetSubmatch(request, 1)
+			if address == transactionAddress {
+				return httpmock.NewStringResponse(200, `{"confirmed": 100000000, "unconfirmed": 5000, "balance": 100005000}`), nil
+			}
+			account := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, address)
+			if account == nil {
+				return httpmock.NewStringResponse(404, `{"error": "Not found"}`), nil
+			}
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"confirmed": %d}`, currencyUtil.ToSatoshi(account.Balance.String()).IntPart())), nil
+		},
+	)
+	httpmock.RegisterRegexpResponder(
+		"GET",
+		regexp.MustCompile(`^https://api\.bitcore\.io/api/BTC/mainnet/address/(\w+)/txs\?limit=\d+$`),
+		func(request *http.Request) (*http.Response, error) {
+			if httpmock.MustGetSubmatch(request, 1) != transactionAddress {
+				return httpmock.NewStringResponse(200, `[]`), nil
+			}
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`[
+				{"mintTxid": "%s", "spentTxid": "", "value": 5000},
+				{"mintTxid": "%s", "spentTxid": "", "value": 100000000}
+			]`, transactionMempoolTxid, transactionReceiveTxid)), nil
+		},
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.bitcore.io/api/BTC/mainnet/tx/"+transactionReceiveTxid,
+		httpmock.NewStringResponder(200, `{"blockHeight": 170, "blockTime": "2009-01-12T03:30:25.000Z", "fee": 0, "confirmations": 6}`),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.bitcore.io/api/BTC/mainnet/tx/"+transactionMempoolTxid,
+		httpmock.NewStringResponder(200, `{"blockHeight": -1, "blockTime": "", "fee": 300, "confirmations": -1}`),
+	)
+
+	filter := database.TransactionFilter{AccountId: account.Id}
+	runCronUntil(t, func() bool {
+		return len(database.GetTransactions(filter, nil, 10)) == 2
+	})
+	transactions := database.GetTransactions(filter, nil, 10)
+	if !assert.Equal(t, 2, len(transactions)) {
+		return
+	}
+	// The mempool transaction is stored with the time it was first seen
+	mempoolTransaction := transactions[0]
+	assert.Equal(t, transactionMempoolTxid, mempoolTransaction.Txid)
+	assert.Nil(t, mempoolTransaction.BlockHeight)
+	assert.GreaterOrEqual(t, mempoolTransaction.Time, start)
+	assert.Equal(t, "0.00005", mempoolTransaction.Amount.String())
+	assert.Equal(t, transactionReceiveTxid, transactions[1].Txid)
+	assert.Equal(t, int64(1231731025), transactions[1].Time)
+	assert.Equal(t, uint(6), transactions[1].Confirmations)
+
+	// The next runs do not store the txids again, the mempool transaction is upgraded in place once it confirms.
+	// The transaction with enough confirmations is not read again
+	receiveCallKey := "GET https://api.bitcore.io/api/BTC/mainnet/tx/" + transactionReceiveTxid
+	receiveCallCount := httpmock.GetCallCountInfo()[receiveCallKey]
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.bitcore.io/api/BTC/mainnet/tx/"+transactionMempoolTxid,
+		httpmock.NewStringResponder(200, `{"blockHeight": 180, "blockTime": "2009-01-12T06:00:00.000Z", "fee": 300, "confirmations": 1}`),
+	)
+	runCronUntil(t, func() bool {
+		transactions = database.GetTransactions(filter, nil, 10)
+		return len(transactions) > 0 && transactions[0].BlockHeight != nil && transactions[0].Txid == transactionMempoolTxid
+	})
+	transactions = database.GetTransactions(filter, nil, 10)
+	if !assert.Equal(t, 2, len(transactions)) {
+		return
+	}
+	assert.Equal(t, mempoolTransaction.Id, transactions[0].Id)
+	if assert.NotNil(t, transactions[0].BlockHeight) {
+		assert.Equal(t, int64(180), *transactions[0].BlockHeight)
+	}
+	assert.Equal(t, int64(1231740000), transactions[0].Time)
+	assert.Equal(t, uint(1), transactions[0].Confirmations)
+	assert.Equal(t, receiveCallCount, httpmock.GetCallCountInfo()[receiveCallKey])
+}
@@ -10,19 +10,29 @@ import (
 	"go-gin-test-job/src/config"
 	"go-gin-test-job/src/database"
 	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/modules/common/blockchain"
 	arrayUtil "go-gin-test-job/src/utils/array"
 	currencyUtil "go-gin-test-job/src/utils/currency"
 	numberUtil "go-gin-test-job/src/utils/number"
 	timeUtil "go-gin-test-job/src/utils/time"
 	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"regexp"
 	"testing"
 )
 
 func TestCronRoute(t *testing.T) {
 	t.Run("TestUpdateAccountsBalancesRoute_Success", TestUpdateAccountsBalancesRoute_Success)
+	t.Run("TestUpdateAccountsBalancesRoute_SuccessBalanceHistory", TestUpdateAccountsBalancesRoute_SuccessBalanceHistory)
+	t.Run("TestUpdateAccountsBalancesRoute_SuccessUnchanged", TestUpdateAccountsBalancesRoute_SuccessUnchanged)
+	t.Run("TestUpdateAccountsBalancesRoute_SuccessLifecycle", TestUpdateAccountsBalancesRoute_SuccessLifecycle)
+	// Transactions
+	t.Run("TestUpdateAccountsBalancesRoute_SuccessTransactions", TestUpdateAccountsBalancesRoute_SuccessTransactions)
+	// Electrum
+	t.Run("TestElectrumSubscription_Success", TestElectrumSubscription_Success)
 }
 
 func TestUpdateAccountsBalancesRoute_Success(t *testing.T) {
@@ -32,22 +42,25 @@ func TestUpdateAccountsBalancesRoute_Success(t *testing.T) {
 		Path: fmt.Sprintf("/cron/account-balance"),
 	}
 
-	accountsBefore := database.GetAccountsBatch(config.AppConfig.CronBatchCount)
+	accountsBefore := database.GetAccountsBatch(blockchain.GetChainNetworks(), config.AppConfig.CronBatchCount)
 	assert.Greater(t, len(accountsBefore), 0)
 
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
 	mockAccountsBalance := make(map[int64]decimal.Decimal)
+	mockAccountsUnconfirmedBalance := make(map[int64]decimal.Decimal)
 	for _, accountBefore := range accountsBefore {
 		mockBalance := int64(numberUtil.GetRandomNumber(0, 10000000000))
+		mockUnconfirmedBalance := int64(numberUtil.GetRandomNumber(0, 100000000))
 		// Define the mock response
 		httpmock.RegisterResponder(
 			"GET",
 			fmt.Sprintf("https://api.bitcore.io/api/BTC/mainnet/address/%s/balance", accountBefore.Address),
-			httpmock.NewStringResponder(200, fmt.Sprintf(`{"confirmed": %d}`, mockBalance)),
+			httpmock.NewStringResponder(200, fmt.Sprintf(`{"confirmed": %d, "unconfirmed": %d, "balance": %d}`, mockBalance, mockUnconfirmedBalance, mockBalance+mockUnconfirmedBalance)),
 		)
 		mockAccountsBalance[accountBefore.Id] = currencyUtil.FromSatoshi(mockBalance)
+		mockAccountsUnconfirmedBalance[accountBefore.Id] = currencyUtil.FromSatoshi(mockUnconfirmedBalance)
 	}
 
 	response := httptest.NewRecorder()
@@ -86,8 +99,169 @@ func TestUpdateAccountsBalancesRoute_Success(t *testing.T) {
 		assert.Equal(t, (*accountBefore).Id, accountAfter.Id)
 		assert.Equal(t, (*accountBefore).Address, accountAfter.Address)
 		assert.Equal(t, mockAccountsBalance[accountAfter.Id].String(), accountAfter.Balance.String())
+		assert.Equal(t, mockAccountsUnconfirmedBalance[accountAfter.Id].String(), accountAfter.UnconfirmedBalance.String())
+		assert.Equal(t, mockAccountsBalance[accountAfter.Id].Add(mockAccountsUnconfirmedBalance[accountAfter.Id]).String(), accountAfter.TotalBalance.String())
 		assert.Equal(t, (*accountBefore).CreatedAt, accountAfter.CreatedAt)
 		assert.GreaterOrEqual(t, accountAfter.UpdatedAt, (*accountBefore).UpdatedAt)
 		assert.GreaterOrEqual(t, accountAfter.UpdatedAt, start)
 	}
 }
+
+func TestUpdateAccountsBalancesRoute_SuccessBalanceHistory(t *testing.T) {
+	start := timeUtil.GetUnixTime()
+	accountsBefore := database.GetAccountsBatch(blockchain.GetChainNetworks(), config.AppConfig.CronBatchCount)
+	assert.Greater(t, len(accountsBefore), 0)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The first account keeps its balance, the others get a new one
+	mockAccountsBalance := make(map[int64]decimal.Decimal)
+	for index, accountBefore := range accountsBefore {
+		mockBalance := currencyUtil.ToSatoshi(accountBefore.Balance.String()).IntPart()
+		if index > 0 {
+			mockBalance += 12345678
+		}
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://api.bitcore.io/api/BTC/mainnet/address/%s/balance", accountBefore.Address),
+			httpmock.NewStringResponder(200, fmt.Sprintf(`{"confirmed": %d}`, mockBalance)),
+		)
+		mockAccountsBalance[accountBefore.Id] = currencyUtil.FromSatoshi(mockBalance)
+	}
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/cron/account-balance", nil)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-API-Key", config.AppConfig.CronXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	for index, accountBefore := range accountsBefore {
+		filter := database.AccountBalanceHistoryFilter{AccountId: accountBefore.Id, CreatedFrom: &start}
+		history := database.GetAccountBalanceHistory(filter, nil, 10)
+		if index == 0 {
+			assert.Equal(t, 0, len(history), "unchanged balance must not be recorded")
+			continue
+		}
+		if !assert.Equal(t, 1, len(history)) {
+			continue
+		}
+		assert.Equal(t, accountBefore.Balance.String(), history[0].OldBalance.String())
+		assert.Equal(t, mockAccountsBalance[accountBefore.Id].String(), history[0].NewBalance.String())
+		assert.Equal(t, "0.12345678", history[0].Delta.String())
+		assert.Equal(t, blockchain.ProviderBitcore, history[0].Source)
+		assert.GreaterOrEqual(t, history[0].CreatedAt, start)
+	}
+
+	// The seeded change older than the retention window is pruned
+	retentionDays := config.AppConfig.BalanceHistoryRetentionDays
+	oldHistory := seeds.ACCOUNT_BALANCE_HISTORY.HISTORY_1
+	if retentionDays > 0 && oldHistory.CreatedAt < start-int64(retentionDays)*24*60*60 {
+		accountHistory := database.GetAccountBalanceHistory(database.AccountBalanceHistoryFilter{AccountId: oldHistory.AccountId}, nil, 1000)
+		for _, item := range accountHistory {
+			assert.NotEqual(t, oldHistory.Id, item.Id)
+		}
+	}
+}
+
+func TestUpdateAccountsBalancesRoute_SuccessUnchanged(t *testing.T) {
+	start := timeUtil.GetUnixTime()
+	accountsBefore := database.GetAccountsBatch(blockchain.GetChainNetworks(), config.AppConfig.CronBatchCount)
+	assert.Greater(t, len(accountsBefore), 0)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The provider returns the balances the accounts already have
+	for _, accountBefore := range accountsBefore {
+		httpmock.RegisterResponder(
+			"GET",
+			fmt.Sprintf("https://api.bitcore.io/api/BTC/mainnet/address/%s/balance", accountBefore.Address),
+			httpmock.NewStringResponder(200, fmt.Sprintf(
+				`{"confirmed": %d, "unconfirmed": %d}`,
+				currencyUtil.ToSatoshi(accountBefore.Balance.String()).IntPart(),
+				currencyUtil.ToSatoshi(accountBefore.UnconfirmedBalance.String()).IntPart(),
+			)),
+		)
+	}
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest("POST", "/cron/account-balance", nil)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-API-Key", config.AppConfig.CronXApiKey)
+	test.TestApp.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	// An unchanged poll is not a write, the version and the ETag stay, only the check time moves
+	for _, accountBefore := range accountsBefore {
+		accountAfter := database.GetAccountById(accountBefore.Id)
+		if !assert.NotNil(t, accountAfter) || accountBefore.Status != entities.AccountStatusActive || accountBefore.ErrorCount > 0 {
+			continue
+		}
+		assert.Equal(t, accountBefore.Version, accountAfter.Version)
+		assert.Equal(t, accountBefore.UpdatedAt, accountAfter.UpdatedAt)
+		if assert.NotNil(t, accountAfter.BalanceCheckedAt) {
+			assert.GreaterOrEqual(t, *accountAfter.BalanceCheckedAt, start)
+		}
+	}
+}
+
+func TestUpdateAccountsBalancesRoute_SuccessLifecycle(t *testing.T) {
+	testAuditContext := database.AuditContext{Actor: "test"}
+	pendingAccount, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, "16deTqnj9kfeN2F2DnHbWgZzfD9HztZS5H", entities.AccountStatusPending, "Cron Pending", 10, ""))
+	assert.Nil(t, err)
+	rejectedAccount, err := database.CreateAccount(database.DbConn, testAuditContext, entities.CreateAccount(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1EpUoPqaMT8JjcRg2cRweHEit8cCEw4GY3", entities.AccountStatusActive, "Cron Rejected", 10, ""))
+	assert.Nil(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The provider rejects one address, the other accounts keep their balances
+	httpmock.RegisterRegexpResponder(
+		"GET",
+		regexp.MustCompile(`^https://api\.bitcore\.io/api/BTC/mainnet/address/(\w+)/balance$`),
+		func(request *http.Request) (*http.Response, error) {
+			address := httpmock.MustGetSubmatch(request, 1)
+			if address == rejectedAccount.Address {
+				return httpmock.NewStringResponse(400, `{"error": "Invalid address"}`), nil
+			}
+			account := database.GetAccountByAddress(entities.AccountChainBtc, entities.AccountNetworkMainnet, address)
+			if account == nil {
+				return httpmock.NewStringResponse(404, `{"error": "Not found"}`), nil
+			}
+			return httpmock.NewStringResponse(200, fmt.Sprintf(`{"confirmed": %d}`, currencyUtil.ToSatoshi(account.Balance.String()).IntPart())), nil
+		},
+	)
+
+	// The cron takes the least recently checked accounts, it runs until both accounts have been checked enough
+	threshold := config.AppConfig.AccountErrorThreshold
+	for run := 0; run < 100; run++ {
+		pendingAccount = database.GetAccountById(pendingAccount.Id)
+		rejectedAccount = database.GetAccountById(rejectedAccount.Id)
+		if pendingAccount.Status != entities.AccountStatusPending && rejectedAccount.Status == entities.AccountStatusError {
+			break
+		}
+		assert.Less(t, int(rejectedAccount.ErrorCount), threshold)
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/cron/account-balance", nil)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-API-Key", config.AppConfig.CronXApiKey)
+		test.TestApp.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+
+	// The first successful check activates the new account
+	assert.Equal(t, entities.AccountStatusActive, pendingAccount.Status)
+	assert.Equal(t, "Balance checked", pendingAccount.StatusReason)
+	assert.Equal(t, uint(0), pendingAccount.ErrorCount)
+
+	// The repeatedly rejected address moves to Error and is not polled any more
+	assert.Equal(t, entities.AccountStatusError, rejectedAccount.Status)
+	assert.Contains(t, rejectedAccount.StatusReason, blockchain.ErrAddressRejected.Error())
+	assert.Equal(t, uint(threshold), rejectedAccount.ErrorCount)
+	for _, account := range database.GetAccountsBatch(blockchain.GetChainNetworks(), 1000) {
+		assert.NotEqual(t, rejectedAccount.Id, account.Id)
+	}
+}
@@ -0,0 +1,190 @@
+package electrumTests
+
+import (
+	"errors"
+	"go-gin-test-job/src/database/entities"
+	"go-gin-test-job/src/modules/common/electrum"
+	testElectrum "go-gin-test-job/test/electrum"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	btcAddress = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	// btcScripthash is the scripthash of btcAddress from the Electrum protocol docs
+	btcScripthash = "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	bchAddress    = "bitcoincash:qzg2nmnxmgpyw3jp3q4lgnnl8wh0kmnl253r8za963"
+	bchLegacy     = "1EBusex4rMQSqKZ46czvvjSvPkCZiEmtbt"
+	clientTimeout = 2 * time.Second
+)
+
+func TestElectrumRoute(t *testing.T) {
+	// Scripthash
+	t.Run("TestGetScripthash_Fail", TestGetScripthash_Fail)
+	t.Run("TestGetScripthash_Success", TestGetScripthash_Success)
+	// Client
+	t.Run("TestClient_Fail", TestClient_Fail)
+	t.Run("TestClient_Success", TestClient_Success)
+	t.Run("TestClient_SuccessNotification", TestClient_SuccessNotification)
+	t.Run("TestClient_SuccessNotificationBacklog", TestClient_SuccessNotificationBacklog)
+}
+
+func newServerClient(t *testing.T) (*testElectrum.FakeServer, *electrum.Client) {
+	server, err := testElectrum.NewFakeServer()
+	assert.Nil(t, err)
+	client, err := electrum.Dial(server.URL(), clientTimeout)
+	assert.Nil(t, err)
+	return server, client
+}
+
+///// Scripthash
+
+func TestGetScripthash_Fail(t *testing.T) {
+	_, err := electrum.GetScripthash(entities.AccountChainBtc, entities.AccountNetworkMainnet, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb")
+	assert.Error(t, err)
+	_, err = electrum.GetScripthash(entities.AccountChainBtc, entities.AccountNetworkTestnet, btcAddress)
+	assert.Error(t, err)
+}
+
+func TestGetScripthash_Success(t *testing.T) {
+	scripthash, err := electrum.GetScripthash(entities.AccountChainBtc, entities.AccountNetworkMainnet, btcAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, btcScripthash, scripthash)
+
+	// The CashAddr and the legacy forms of an address have the same script
+	cashAddrScripthash, err := electrum.GetScripthash(entities.AccountChainBch, entities.AccountNetworkMainnet, bchAddress)
+	assert.Nil(t, err)
+	legacyScripthash, err := electrum.GetScripthash(entities.AccountChainBch, entities.AccountNetworkMainnet, bchLegacy)
+	assert.Nil(t, err)
+	assert.Equal(t, cashAddrScripthash, legacyScripthash)
+}
+
+///// Client
+
+func TestClient_Fail(t *testing.T) {
+	_, err := electrum.Dial("http://127.0.0.1:50001", clientTimeout)
+	assert.Error(t, err)
+
+	server, client := newServerClient(t)
+	defer server.Close()
+	defer client.Close()
+
+	// The server error is returned as RpcError and keeps the connection open
+	server.SetError(btcScripthash, "scripthash is banned")
+	_, err = client.GetBalance(btcScripthash)
+	var rpcErr *electrum.RpcError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, "scripthash is banned", rpcErr.Message)
+	err = client.Call("unknown.method", []interface{}{}, nil)
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Nil(t, client.Ping())
+
+	// The dropped connection closes Done, the calls return the connection error
+	server.DropConnections()
+	select {
+	case <-client.Done():
+	case <-time.After(clientTimeout):
+		t.Fatal("Connection is not closed")
+	}
+	assert.Error(t, client.Err())
+	assert.Error(t, client.Ping())
+}
+
+func TestClient_Success(t *testing.T) {
+	server, client := newServerClient(t)
+	defer server.Close()
+	defer client.Close()
+
+	version, err := client.ServerVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"FakeElectrum 1.0", electrum.ProtocolVersion}, version)
+	assert.Nil(t, client.Ping())
+
+	balance, err := client.GetBalance(btcScripthash)
+	assert.Nil(t, err)
+	assert.Equal(t, electrum.ScripthashBalance{}, balance)
+
+	server.SetBalance(btcScripthash, 150000000, -2000)
+	balance, err = client.GetBalance(btcScripthash)
+	assert.Nil(t, err)
+	assert.Equal(t, electrum.ScripthashBalance{Confirmed: 150000000, Unconfirmed: -2000}, balance)
+
+	// The calls from several goroutines get their own responses
+	results := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			balance, err := client.GetBalance(btcScripthash)
+			if err == nil && balance.Confirmed != 150000000 {
+				err = errors.New("wrong balance")
+			}
+			results <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, <-results)
+	}
+}
+
+func TestClient_SuccessNotification(t *testing.T) {
+	server, client := newServerClient(t)
+	defer server.Close()
+	defer client.Close()
+
+	// The status of a scripthash without history is empty
+	status, err := client.Subscribe(btcScripthash)
+	assert.Nil(t, err)
+	assert.Equal(t, "", status)
+	assert.Equal(t, 1, server.SubscribeCount(btcScripthash))
+
+	server.SetBalance(btcScripthash, 1000, 0)
+	var notification electrum.Notification
+	select {
+	case notification = <-client.Notifications():
+	case <-time.After(clientTimeout):
+		t.Fatal("Notification is not received")
+	}
+	assert.Equal(t, btcScripthash, notification.Scripthash)
+	assert.NotEqual(t, "", notification.Status)
+
+	// The subscribe returns the current status
+	status, err = client.Subscribe(btcScripthash)
+	assert.Nil(t, err)
+	assert.Equal(t, notification.Status, status)
+}
+
+func TestClient_SuccessNotificationBacklog(t *testing.T) {
+	server, client := newServerClient(t)
+	defer server.Close()
+	defer client.Close()
+
+	_, err := client.Subscribe(btcScripthash)
+	assert.Nil(t, err)
+
+	// The unread notifications do not hold up the calls, the response comes after all of them
+	for i := 1; i <= 2000; i++ {
+		server.SetBalance(btcScripthash, int64(i), 0)
+	}
+	balance, err := client.GetBalance(btcScripthash)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2000), balance.Confirmed)
+
+	// The queued statuses of the scripthash are replaced by the last one
+	status, err := client.Subscribe(btcScripthash)
+	assert.Nil(t, err)
+	receivedCount := 0
+	for {
+		var notification electrum.Notification
+		select {
+		case notification = <-client.Notifications():
+		case <-time.After(clientTimeout):
+			t.Fatal("Last notification is not received")
+		}
+		receivedCount++
+		if notification.Status == status {
+			break
+		}
+	}
+	assert.LessOrEqual(t, receivedCount, 2)
+}
@@ -0,0 +1,317 @@
+package portfolioTests
+
+import (
+	"fmt"
+	"go-gin-test-job/src/common/dto"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	portfolioModuleDto "go-gin-test-job/src/modules/portfolio/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortfolioRoute(t *testing.T) {
+	// GetPortfolios
+	t.Run("TestGetPortfoliosRoute_Success", TestGetPortfoliosRoute_Success)
+	// GetPortfolioById
+	t.Run("TestGetPortfolioByIdRoute_FailNotFound", TestGetPortfolioByIdRoute_FailNotFound)
+	t.Run("TestGetPortfolioByIdRoute_FailIncludeOff", TestGetPortfolioByIdRoute_FailIncludeOff)
+	t.Run("TestGetPortfolioByIdRoute_SuccessWithoutOff", TestGetPortfolioByIdRoute_SuccessWithoutOff)
+	t.Run("TestGetPortfolioByIdRoute_SuccessIncludeOff", TestGetPortfolioByIdRoute_SuccessIncludeOff)
+	t.Run("TestGetPortfolioByIdRoute_SuccessEmpty", TestGetPortfolioByIdRoute_SuccessEmpty)
+	// CreatePortfolio
+	validationCreatePortfolioTests(t)
+	t.Run("TestCreatePortfolioRoute_FailNameAlreadyExists", TestCreatePortfolioRoute_FailNameAlreadyExists)
+	t.Run("TestCreatePortfolioRoute_Success", TestCreatePortfolioRoute_Success)
+	// UpdatePortfolio
+	t.Run("TestUpdatePortfolioRoute_FailNameAlreadyExists", TestUpdatePortfolioRoute_FailNameAlreadyExists)
+	t.Run("TestUpdatePortfolioRoute_Success", TestUpdatePortfolioRoute_Success)
+	// PortfolioAccount
+	t.Run("TestAddPortfolioAccountRoute_Fail", TestAddPortfolioAccountRoute_Fail)
+	t.Run("TestAddAndRemovePortfolioAccountRoute_Success", TestAddAndRemovePortfolioAccountRoute_Success)
+	// DeletePortfolio
+	t.Run("TestDeletePortfolioRoute_FailNotFound", TestDeletePortfolioRoute_FailNotFound)
+	t.Run("TestDeletePortfolioRoute_Success", TestDeletePortfolioRoute_Success)
+}
+
+func getPortfolio(t *testing.T, path string) portfolioModuleDto.GetPortfolioResponseDto {
+	response := test.SendAdminRequest(t, "GET", path, nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	return test.DecodeResponse[portfolioModuleDto.GetPortfolioResponseDto](t, response)
+}
+
+// expectedPortfolioSummary aggregates the accounts as they are in the database now, the cron tests change the balances
+func expectedPortfolioSummary(t *testing.T, accountIds []int64, includeOff bool) portfolioModuleDto.PortfolioSummaryDto {
+	var summary database.PortfolioSummary
+	chainBalances := make(map[entities.AccountChain]*database.PortfolioChainBalance)
+	for _, accountId := range accountIds {
+		account := database.GetAccountById(accountId)
+		assert.NotNil(t, account)
+		if !includeOff && !account.Status.IsPolling() {
+			continue
+		}
+		rank := account.Rank
+		summary.AccountCount++
+		chainBalance, exists := chainBalances[account.Chain]
+		if !exists {
+			chainBalance = &database.PortfolioChainBalance{Chain: account.Chain, Balance: decimal.Zero}
+			chainBalances[account.Chain] = chainBalance
+			summary.Balances = append(summary.Balances, chainBalance)
+		}
+		chainBalance.AccountCount++
+		chainBalance.Balance = chainBalance.Balance.Add(account.Balance)
+		if summary.MaxRank == nil || *summary.MaxRank < rank {
+			summary.MaxRank = &rank
+		}
+		if summary.MinRank == nil || *summary.MinRank > rank {
+			summary.MinRank = &rank
+		}
+	}
+	return portfolioModuleDto.CreatePortfolioSummaryDto(summary, includeOff)
+}
+
+func assertPortfolioSummary(t *testing.T, expected portfolioModuleDto.PortfolioSummaryDto, actual portfolioModuleDto.PortfolioSummaryDto) {
+	assert.Equal(t, expected.IncludeOff, actual.IncludeOff)
+	assert.Equal(t, expected.AccountCount, actual.AccountCount)
+	// Every chain has its own balance
+	assert.Equal(t, len(expected.Balances), len(actual.Balances))
+	actualBalances := make(map[entities.AccountChain]portfolioModuleDto.PortfolioChainBalanceDto)
+	for _, actualBalance := range actual.Balances {
+		actualBalances[actualBalance.Chain] = actualBalance
+	}
+	for _, expectedBalance := range expected.Balances {
+		actualBalance, exists := actualBalances[expectedBalance.Chain]
+		if !assert.True(t, exists, fmt.Sprintf("%s balance is missing", expectedBalance.Chain)) {
+			continue
+		}
+		assert.Equal(t, expectedBalance.Currency, actualBalance.Currency)
+		assert.Equal(t, expectedBalance.AccountCount, actualBalance.AccountCount)
+		assert.True(t, decimal.RequireFromString(expectedBalance.Balance).Equal(decimal.RequireFromString(actualBalance.Balance)),
+			fmt.Sprintf("%s balance %s != %s", expectedBalance.Chain, expectedBalance.Balance, actualBalance.Balance))
+	}
+	assert.Equal(t, expected.MaxRank, actual.MaxRank)
+	assert.Equal(t, expected.MinRank, actual.MinRank)
+}
+
+func getPortfolioAccountIds(portfolioDto portfolioModuleDto.GetPortfolioResponseDto) []int64 {
+	ids := make([]int64, 0)
+	for _, accountDto := range portfolioDto.Accounts {
+		ids = append(ids, accountDto.Id)
+	}
+	return ids
+}
+
+func TestGetPortfoliosRoute_Success(t *testing.T) {
+	response := test.SendAdminRequest(t, "GET", "/portfolio", nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[portfolioModuleDto.GetPortfoliosResponseDto](t, response)
+
+	// Portfolios are ordered by name
+	expectedNames := []string{seeds.PORTFOLIOS.PORTFOLIO_2.Name, seeds.PORTFOLIOS.PORTFOLIO_1.Name}
+	names := make([]string, 0)
+	for _, portfolioDto := range responseDto.List {
+		names = append(names, portfolioDto.Name)
+	}
+	assert.Equal(t, expectedNames, names)
+}
+
+func TestGetPortfolioByIdRoute_FailNotFound(t *testing.T) {
+	response := test.SendAdminRequest(t, "GET", fmt.Sprintf("/portfolio/%d", 999999), nil, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Portfolio not found", responseDto.Message)
+}
+
+func TestGetPortfolioByIdRoute_FailIncludeOff(t *testing.T) {
+	path := fmt.Sprintf("/portfolio/%d?includeOff=maybe", seeds.PORTFOLIOS.PORTFOLIO_1.Id)
+	response := test.SendAdminRequest(t, "GET", path, nil, nil)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+	assert.Equal(t, portfolioModuleDto.GetPortfolioSummaryRequestDtoQueryParseErrorMessage(nil), responseDto.Message)
+}
+
+func TestGetPortfolioByIdRoute_SuccessWithoutOff(t *testing.T) {
+	portfolio := seeds.PORTFOLIOS.PORTFOLIO_1
+	responseDto := getPortfolio(t, fmt.Sprintf("/portfolio/%d", portfolio.Id))
+	assert.Equal(t, portfolio.Id, responseDto.Id)
+	assert.Equal(t, portfolio.Name, responseDto.Name)
+
+	// The member list has the Off accounts, only the aggregates skip them
+	expectedIds := make([]int64, 0)
+	for _, portfolioAccount := range seeds.FillPortfolioAccountList() {
+		expectedIds = append(expectedIds, portfolioAccount.AccountId)
+	}
+	assert.Equal(t, expectedIds, getPortfolioAccountIds(responseDto))
+	assertPortfolioSummary(t, expectedPortfolioSummary(t, expectedIds, false), responseDto.Summary)
+}
+
+func TestGetPortfolioByIdRoute_SuccessIncludeOff(t *testing.T) {
+	portfolio := seeds.PORTFOLIOS.PORTFOLIO_1
+	responseDto := getPortfolio(t, fmt.Sprintf("/portfolio/%d?includeOff=true", portfolio.Id))
+
+	accountIds := getPortfolioAccountIds(responseDto)
+	assert.Equal(t, len(seeds.FillPortfolioAccountList()), len(accountIds))
+	expected := expectedPortfolioSummary(t, accountIds, true)
+	assert.Equal(t, int64(len(accountIds)), expected.AccountCount)
+	assertPortfolioSummary(t, expected, responseDto.Summary)
+}
+
+func TestGetPortfolioByIdRoute_SuccessEmpty(t *testing.T) {
+	responseDto := getPortfolio(t, fmt.Sprintf("/portfolio/%d?includeOff=true", seeds.PORTFOLIOS.PORTFOLIO_2.Id))
+	assert.Equal(t, 0, len(responseDto.Accounts))
+	assert.Equal(t, int64(0), responseDto.Summary.AccountCount)
+	assert.Equal(t, 0, len(responseDto.Summary.Balances))
+	assert.Nil(t, responseDto.Summary.MaxRank)
+	assert.Nil(t, responseDto.Summary.MinRank)
+}
+
+func validationCreatePortfolioTests(t *testing.T) {
+	nameErrorMessage := portfolioModuleDto.PortfolioNameErrorMessage("Name")
+	validationTests := []struct {
+		name         string
+		body         portfolioModuleDto.PostCreatePortfolioRequestDto
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailEmptyName",
+			portfolioModuleDto.PostCreatePortfolioRequestDto{Name: ""},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: nameErrorMessage},
+		},
+		{
+			"FailLongName",
+			portfolioModuleDto.PostCreatePortfolioRequestDto{Name: fmt.Sprintf("%0256d", 0)},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: nameErrorMessage},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestCreatePortfolioRoute_"+tt.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, "POST", "/portfolio", nil, tt.body)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto)
+		})
+	}
+}
+
+func TestCreatePortfolioRoute_FailNameAlreadyExists(t *testing.T) {
+	body := portfolioModuleDto.PostCreatePortfolioRequestDto{Name: seeds.PORTFOLIOS.PORTFOLIO_1.Name}
+	response := test.SendAdminRequest(t, "POST", "/portfolio", nil, body)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Portfolio name already exists", responseDto.Message)
+}
+
+func TestCreatePortfolioRoute_Success(t *testing.T) {
+	body := portfolioModuleDto.PostCreatePortfolioRequestDto{Name: "Hot wallets"}
+	response := test.SendAdminRequest(t, "POST", "/portfolio", nil, body)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[portfolioModuleDto.PortfolioDto](t, response)
+	assert.Equal(t, "Hot wallets", responseDto.Name)
+	assert.Greater(t, responseDto.CreatedAt, int64(0))
+
+	portfolio := database.GetPortfolioById(responseDto.Id)
+	assert.NotNil(t, portfolio)
+	assert.Equal(t, "Hot wallets", portfolio.Name)
+}
+
+func TestUpdatePortfolioRoute_FailNameAlreadyExists(t *testing.T) {
+	path := fmt.Sprintf("/portfolio/%d", seeds.PORTFOLIOS.PORTFOLIO_2.Id)
+	body := portfolioModuleDto.PatchUpdatePortfolioRequestDto{Name: seeds.PORTFOLIOS.PORTFOLIO_1.Name}
+	response := test.SendAdminRequest(t, "PATCH", path, nil, body)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Portfolio name already exists", responseDto.Message)
+}
+
+func TestUpdatePortfolioRoute_Success(t *testing.T) {
+	portfolio := seeds.PORTFOLIOS.PORTFOLIO_2
+	path := fmt.Sprintf("/portfolio/%d", portfolio.Id)
+	body := portfolioModuleDto.PatchUpdatePortfolioRequestDto{Name: "Customer deposits"}
+	response := test.SendAdminRequest(t, "PATCH", path, nil, body)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[portfolioModuleDto.PortfolioDto](t, response)
+	assert.Equal(t, portfolio.Id, responseDto.Id)
+	assert.Equal(t, "Customer deposits", responseDto.Name)
+}
+
+func TestAddPortfolioAccountRoute_Fail(t *testing.T) {
+	failTests := []struct {
+		name            string
+		path            string
+		expectedCode    int
+		expectedMessage string
+	}{
+		{"FailPortfolioNotFound", fmt.Sprintf("/portfolio/%d/account/%d", 999999, seeds.ACCOUNTS.ACCOUNT_2.Id), http.StatusNotFound, "Portfolio not found"},
+		{"FailAccountNotFound", fmt.Sprintf("/portfolio/%d/account/%d", seeds.PORTFOLIOS.PORTFOLIO_2.Id, 999999), http.StatusNotFound, "Account not found"},
+		{"FailWrongAccountId", fmt.Sprintf("/portfolio/%d/account/%s", seeds.PORTFOLIOS.PORTFOLIO_2.Id, "abc"), http.StatusBadRequest, "AccountId is invalid"},
+		{"FailZeroId", fmt.Sprintf("/portfolio/%d/account/%d", 0, seeds.ACCOUNTS.ACCOUNT_2.Id), http.StatusBadRequest, "Id must be greater than or equal 1"},
+	}
+
+	for _, tt := range failTests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, "PUT", tt.path, nil, nil)
+			assert.Equal(t, tt.expectedCode, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedMessage, responseDto.Message)
+		})
+	}
+}
+
+func TestAddAndRemovePortfolioAccountRoute_Success(t *testing.T) {
+	portfolio := seeds.PORTFOLIOS.PORTFOLIO_2
+	portfolioPath := fmt.Sprintf("/portfolio/%d", portfolio.Id)
+	accountIds := []int64{seeds.ACCOUNTS.ACCOUNT_1.Id, seeds.ACCOUNTS.ACCOUNT_2.Id}
+
+	// Adding twice keeps a single membership
+	for _, accountId := range []int64{accountIds[0], accountIds[1], accountIds[0]} {
+		response := test.SendAdminRequest(t, "PUT", fmt.Sprintf("%s/account/%d", portfolioPath, accountId), nil, nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+	responseDto := getPortfolio(t, portfolioPath)
+	assert.Equal(t, accountIds, getPortfolioAccountIds(responseDto))
+	assertPortfolioSummary(t, expectedPortfolioSummary(t, accountIds, false), responseDto.Summary)
+
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("%s/account/%d", portfolioPath, accountIds[0]), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	responseDto = getPortfolio(t, portfolioPath)
+	assert.Equal(t, accountIds[1:], getPortfolioAccountIds(responseDto))
+	assertPortfolioSummary(t, expectedPortfolioSummary(t, accountIds[1:], false), responseDto.Summary)
+}
+
+func TestDeletePortfolioRoute_FailNotFound(t *testing.T) {
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/portfolio/%d", 999999), nil, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Portfolio not found", responseDto.Message)
+}
+
+func TestDeletePortfolioRoute_Success(t *testing.T) {
+	portfolio := seeds.PORTFOLIOS.PORTFOLIO_2
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/portfolio/%d", portfolio.Id), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[dto.SuccessDto](t, response)
+	assert.Equal(t, true, responseDto.Success)
+
+	assert.Nil(t, database.GetPortfolioById(portfolio.Id))
+	// Member accounts are not touched
+	assert.NotNil(t, database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_2.Id))
+	assert.Equal(t, 0, len(database.GetPortfolioAccounts(portfolio.Id)))
+}
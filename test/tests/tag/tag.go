@@ -0,0 +1,188 @@
+package tagTests
+
+import (
+	"fmt"
+	"go-gin-test-job/src/common/dto"
+	errorHelpers "go-gin-test-job/src/common/error-helpers"
+	"go-gin-test-job/src/database"
+	"go-gin-test-job/src/database/entities"
+	tagModuleDto "go-gin-test-job/src/modules/tag/dto"
+	"go-gin-test-job/test"
+	"go-gin-test-job/test/seeds"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagRoute(t *testing.T) {
+	// GetTags
+	t.Run("TestGetTagsRoute_Success", TestGetTagsRoute_Success)
+	// GetTagById
+	t.Run("TestGetTagByIdRoute_FailNotFound", TestGetTagByIdRoute_FailNotFound)
+	t.Run("TestGetTagByIdRoute_Success", TestGetTagByIdRoute_Success)
+	// CreateTag
+	validationCreateTagTests(t)
+	t.Run("TestCreateTagRoute_FailNameAlreadyExists", TestCreateTagRoute_FailNameAlreadyExists)
+	t.Run("TestCreateTagRoute_Success", TestCreateTagRoute_Success)
+	// UpdateTag
+	t.Run("TestUpdateTagRoute_FailNameAlreadyExists", TestUpdateTagRoute_FailNameAlreadyExists)
+	t.Run("TestUpdateTagRoute_Success", TestUpdateTagRoute_Success)
+	// DeleteTag
+	t.Run("TestDeleteTagRoute_FailNotFound", TestDeleteTagRoute_FailNotFound)
+	t.Run("TestDeleteTagRoute_Success", TestDeleteTagRoute_Success)
+}
+
+func TestGetTagsRoute_Success(t *testing.T) {
+	response := test.SendAdminRequest(t, "GET", "/tag", nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[tagModuleDto.GetTagsResponseDto](t, response)
+
+	// Tags are ordered by name
+	expectedNames := []string{seeds.TAGS.TAG_2.Name, seeds.TAGS.TAG_3.Name, seeds.TAGS.TAG_1.Name}
+	names := make([]string, 0)
+	for _, tagDto := range responseDto.List {
+		names = append(names, tagDto.Name)
+	}
+	assert.Equal(t, expectedNames, names)
+}
+
+func TestGetTagByIdRoute_FailNotFound(t *testing.T) {
+	response := test.SendAdminRequest(t, "GET", fmt.Sprintf("/tag/%d", 999999), nil, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Tag not found", responseDto.Message)
+}
+
+func TestGetTagByIdRoute_Success(t *testing.T) {
+	tag := seeds.TAGS.TAG_1
+	response := test.SendAdminRequest(t, "GET", fmt.Sprintf("/tag/%d", tag.Id), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[tagModuleDto.TagDto](t, response)
+	assert.Equal(t, tag.Id, responseDto.Id)
+	assert.Equal(t, tag.Name, responseDto.Name)
+}
+
+func validationCreateTagTests(t *testing.T) {
+	nameErrorMessage := tagModuleDto.TagNameErrorMessage("Name")
+	validationTests := []struct {
+		name         string
+		body         tagModuleDto.PostCreateTagRequestDto
+		expectedBody errorHelpers.ResponseBadRequestErrorHTTP
+	}{
+		{
+			"FailEmptyName",
+			tagModuleDto.PostCreateTagRequestDto{Name: ""},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: nameErrorMessage},
+		},
+		{
+			"FailUppercaseName",
+			tagModuleDto.PostCreateTagRequestDto{Name: "Exchange"},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: nameErrorMessage},
+		},
+		{
+			"FailNameWithSpace",
+			tagModuleDto.PostCreateTagRequestDto{Name: "cold storage"},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: nameErrorMessage},
+		},
+		{
+			"FailLongName",
+			tagModuleDto.PostCreateTagRequestDto{Name: fmt.Sprintf("%065d", 0)},
+			errorHelpers.ResponseBadRequestErrorHTTP{Success: false, Message: nameErrorMessage},
+		},
+	}
+
+	for _, tt := range validationTests {
+		t.Run("TestCreateTagRoute_"+tt.name, func(t *testing.T) {
+			response := test.SendAdminRequest(t, "POST", "/tag", nil, tt.body)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+
+			responseDto := test.DecodeResponse[errorHelpers.ResponseBadRequestErrorHTTP](t, response)
+			assert.Equal(t, tt.expectedBody, responseDto)
+		})
+	}
+}
+
+func TestCreateTagRoute_FailNameAlreadyExists(t *testing.T) {
+	response := test.SendAdminRequest(t, "POST", "/tag", nil, tagModuleDto.PostCreateTagRequestDto{Name: seeds.TAGS.TAG_1.Name})
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Tag name already exists", responseDto.Message)
+}
+
+func TestCreateTagRoute_Success(t *testing.T) {
+	response := test.SendAdminRequest(t, "POST", "/tag", nil, tagModuleDto.PostCreateTagRequestDto{Name: "hot-wallet"})
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[tagModuleDto.TagDto](t, response)
+	assert.Equal(t, "hot-wallet", responseDto.Name)
+	assert.Greater(t, responseDto.CreatedAt, int64(0))
+
+	tag := database.GetTagById(responseDto.Id)
+	assert.NotNil(t, tag)
+	assert.Equal(t, "hot-wallet", tag.Name)
+}
+
+func TestUpdateTagRoute_FailNameAlreadyExists(t *testing.T) {
+	path := fmt.Sprintf("/tag/%d", seeds.TAGS.TAG_2.Id)
+	response := test.SendAdminRequest(t, "PATCH", path, nil, tagModuleDto.PatchUpdateTagRequestDto{Name: seeds.TAGS.TAG_1.Name})
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseConflictErrorHTTP](t, response)
+	assert.Equal(t, "Tag name already exists", responseDto.Message)
+}
+
+func TestUpdateTagRoute_Success(t *testing.T) {
+	tag := seeds.TAGS.TAG_2
+	path := fmt.Sprintf("/tag/%d", tag.Id)
+	response := test.SendAdminRequest(t, "PATCH", path, nil, tagModuleDto.PatchUpdateTagRequestDto{Name: "cold-storage"})
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[tagModuleDto.TagDto](t, response)
+	assert.Equal(t, tag.Id, responseDto.Id)
+	assert.Equal(t, "cold-storage", responseDto.Name)
+
+	// Accounts keep the renamed tag
+	account := database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_1.Id)
+	assert.NotNil(t, account)
+	tagNames := make([]string, 0)
+	for _, accountTag := range account.Tags {
+		tagNames = append(tagNames, accountTag.Name)
+	}
+	assert.Contains(t, tagNames, "cold-storage")
+}
+
+func TestDeleteTagRoute_FailNotFound(t *testing.T) {
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/tag/%d", 999999), nil, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	responseDto := test.DecodeResponse[errorHelpers.ResponseNotFoundErrorHTTP](t, response)
+	assert.Equal(t, "Tag not found", responseDto.Message)
+}
+
+func TestDeleteTagRoute_Success(t *testing.T) {
+	tag := seeds.TAGS.TAG_3
+	response := test.SendAdminRequest(t, "DELETE", fmt.Sprintf("/tag/%d", tag.Id), nil, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	responseDto := test.DecodeResponse[dto.SuccessDto](t, response)
+	assert.Equal(t, true, responseDto.Success)
+
+	assert.Nil(t, database.GetTagById(tag.Id))
+	// The account link is removed with the tag and the account gets a detach entry
+	account := database.GetAccountById(seeds.ACCOUNTS.ACCOUNT_4.Id)
+	assert.NotNil(t, account)
+	assert.Equal(t, 0, len(account.Tags))
+	logs := database.GetAuditLogs(database.AuditLogFilter{AccountId: &account.Id, Action: entities.AuditActionTagDetach}, nil, 10)
+	if assert.NotEqual(t, 0, len(logs)) {
+		assert.Equal(t, entities.AuditActorAdmin, logs[0].Actor)
+		if assert.NotNil(t, logs[0].Before) {
+			assert.JSONEq(t, fmt.Sprintf(`{"tag_id": %d}`, tag.Id), *logs[0].Before)
+		}
+		assert.Nil(t, logs[0].After)
+	}
+}